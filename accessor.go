@@ -0,0 +1,102 @@
+package ensure
+
+import (
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
+
+// Field adds a validator for the value returned by the accessor, reported under the provided name
+// (eg Field(sv, "Email", func(u User) string { return u.Email }, String().Matches(Email)))
+// Unlike HasFields, the compiler checks that the accessor and validator agree on the field's type
+// The name is used in error paths, field masks, and as the default display name; it
+// doesn't have to match a field, so accessors can also validate derived or unexported values
+func Field[T any, F any](sv *StructValidator[T], name string, accessor func(T) F, validator with.Validator[F], displayName ...string) *StructValidator[T] {
+	if name == "" {
+		panic("Field requires a name")
+	}
+
+	sv.addAccessor(&validField{
+		name:      name,
+		validator: validator,
+		check: checkFunc[T](func(s T, opts *with.ValidationOptions) error {
+			return validateWith(validator, accessor(s), opts)
+		}),
+	}, displayName)
+	return sv
+}
+
+// FieldAt adds a validator for the field that the accessor returns a pointer to
+// (eg FieldAt(sv, func(u *User) *string { return &u.Email }, String().Matches(Email)))
+// The field's name is found from its position in the struct, so renaming the field
+// can't leave a stale name behind; it panics if the accessor doesn't return a pointer
+// to one of the struct's own fields
+func FieldAt[T any, F any](sv *StructValidator[T], accessor func(*T) *F, validator with.Validator[F], displayName ...string) *StructValidator[T] {
+	field := fieldAtPointer(sv.refVal.Type(), accessor)
+	vf := &validField{
+		name:      field.Name,
+		validator: validator,
+		index:     field.Index,
+		pointer: func(s *T) any {
+			return accessor(s)
+		},
+	}
+
+	// Fields are read like the ones added with HasFields, except that reflection can
+	// only reference exported fields, so other unexported ones are read through the accessor
+	vf.setValidator(field.Type)
+
+	if vf.scalar == nil && !field.IsExported() {
+		vf.ref = nil
+		vf.check = checkFunc[T](func(s T, opts *with.ValidationOptions) error {
+			return validateWith(validator, *accessor(&s), opts)
+		})
+	}
+
+	sv.addAccessor(vf, displayName)
+	return sv
+}
+
+// fieldAtPointer calls the accessor with a new struct and returns the field the result points to
+// Fields are matched by offset and type, since zero-sized fields can share an offset with the next field
+func fieldAtPointer[T any, F any](refType reflect.Type, accessor func(*T) *F) reflect.StructField {
+	s := new(T)
+	ptr := accessor(s)
+
+	if ptr != nil {
+		start := reflect.ValueOf(s).Pointer()
+		addr := reflect.ValueOf(ptr).Pointer()
+		fieldType := reflect.TypeFor[F]()
+
+		for i := range refType.NumField() {
+			field := refType.Field(i)
+
+			if start+field.Offset == addr && field.Type == fieldType {
+				return field
+			}
+		}
+	}
+
+	panic(fmt.Sprintf("accessor for struct %s does not return a pointer to one of its fields", refType.String()))
+}
+
+// addAccessor adds a field whose type was checked by the compiler
+func (sv *StructValidator[T]) addAccessor(field *validField, displayNames []string) {
+	if len(displayNames) > 1 {
+		panic(fmt.Sprintf("field %s can only have one display name", field.name))
+	}
+
+	field.displayName = field.name
+
+	if len(displayNames) == 1 {
+		field.displayName = displayNames[0]
+	}
+
+	sv.fields = append(sv.fields, field)
+	sv.displayNames[field.name] = field.displayName
+
+	sv.setLastMasked = nil
+	sv.setLastGroups = func(groups []string) {
+		field.groups = append(field.groups, groups...)
+	}
+}
@@ -0,0 +1,163 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+)
+
+type accessorUser struct {
+	Marker  struct{}
+	Name    string
+	Email   string `json:"email"`
+	Age     int
+	Address accessorAddress
+	secret  string
+	labels  []string
+}
+
+type accessorAddress struct {
+	City string
+}
+
+var accessorEmail = ensure.String().Matches(ensure.Email)
+
+func TestField(t *testing.T) {
+	byName := ensure.Struct[accessorUser]().HasFields(with.Validators{
+		"Name":  ensure.String().IsNotEmpty(),
+		"Email": accessorEmail,
+		"Age":   ensure.Number[int]().IsGreaterThanOrEqualTo(18),
+	}, with.DisplayNames{
+		"Email": "Email address",
+	})
+
+	sv := ensure.Struct[accessorUser]()
+	ensure.Field(sv, "Name", func(u accessorUser) string { return u.Name }, ensure.String().IsNotEmpty())
+	ensure.Field(sv, "Email", func(u accessorUser) string { return u.Email }, accessorEmail, "Email address")
+	ensure.Field(sv, "Age", func(u accessorUser) int { return u.Age }, ensure.Number[int]().IsGreaterThanOrEqualTo(18))
+
+	testCases := map[string]struct {
+		value   accessorUser
+		options []*with.ValidationOptions
+	}{
+		"valid":       {value: accessorUser{Name: "Ann", Email: "ann@example.com", Age: 30}},
+		"invalid":     {value: accessorUser{Email: "nope", Age: 3}},
+		"collect all": {value: accessorUser{Email: "nope", Age: 3}, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"field mask":  {value: accessorUser{Email: "nope", Age: 3}, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Age"))}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected := byName.Validate(tc.value, tc.options...)
+			actual := sv.Validate(tc.value, tc.options...)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %#v; got %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestField_Derived(t *testing.T) {
+	sv := ensure.Struct[accessorUser]()
+	ensure.Field(sv, "secret", func(u accessorUser) string { return u.secret }, ensure.String().HasLength(4))
+	ensure.Field(sv, "Domain", func(u accessorUser) int { return len(u.Email) }, ensure.Number[int]().IsLessThan(10)).InGroups("strict")
+
+	paths := validationErrorPaths(t, sv.Validate(accessorUser{Email: "ann@example.com"}, with.Options(
+		with.OptionCollectAllErrors(),
+		with.OptionGroups("strict"),
+	)))
+
+	expect := map[string]string{
+		"/secret": "secret: length must equal 4; got 0",
+		"/Domain": "Domain: number must be less than 10; got 15",
+	}
+
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v; got %v", expect, paths)
+	}
+
+	// Fields outside the selected groups are skipped
+	if err := sv.Validate(accessorUser{secret: "abcd", Email: "ann@example.com"}); err != nil {
+		t.Errorf("expected no error; got %s", err)
+	}
+}
+
+func TestFieldAt(t *testing.T) {
+	sv := ensure.Struct[accessorUser]()
+	ensure.FieldAt(sv, func(u *accessorUser) *string { return &u.Email }, accessorEmail, "Email address")
+	// Name shares an offset with the zero-sized Marker field
+	ensure.FieldAt(sv, func(u *accessorUser) *string { return &u.Name }, ensure.String())
+	ensure.FieldAt(sv, func(u *accessorUser) *string { return &u.secret }, ensure.String().IsNotEmpty())
+	ensure.FieldAt(sv, func(u *accessorUser) *[]string { return &u.labels }, ensure.Array[string]().IsNotEmpty())
+
+	paths := validationErrorPaths(t, sv.Validate(accessorUser{Email: "nope"}, with.Options(with.OptionCollectAllErrors())))
+
+	expect := map[string]string{
+		"/Email":  "Email address: string does not match expected pattern",
+		"/secret": "secret: must not be empty",
+		"/labels": "labels: must not be empty",
+	}
+
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v; got %v", expect, paths)
+	}
+
+	children := sv.Describe().Children
+	names := []string{children[0].Name, children[1].Name, children[2].Name}
+
+	if !reflect.DeepEqual(names, []string{"Email", "Name", "secret"}) {
+		t.Errorf("expected fields to be named after the struct's fields; got %v", names)
+	}
+}
+
+func TestField_JSONSchema(t *testing.T) {
+	sv := ensure.Struct[accessorUser]()
+	ensure.FieldAt(sv, func(u *accessorUser) *string { return &u.Email }, ensure.String().IsNotEmpty())
+	ensure.FieldAt(sv, func(u *accessorUser) *string { return &u.secret }, ensure.String().IsNotEmpty())
+	ensure.Field(sv, "Age", func(u accessorUser) string { return u.Name }, ensure.String().IsNotEmpty())
+	ensure.Field(sv, "Domain", func(u accessorUser) int { return len(u.Email) }, ensure.Number[int]().IsLessThan(10))
+
+	// only exported fields with the validator's type are part of the schema
+	expectSchema(t, sv, `{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "minLength": 1}
+		}
+	}`)
+}
+
+func TestField_Panic(t *testing.T) {
+	var other string
+
+	testCases := map[string]func(){
+		"empty name": func() {
+			ensure.Field(ensure.Struct[accessorUser](), "", func(u accessorUser) string { return u.Name }, ensure.String())
+		},
+		"too many display names": func() {
+			ensure.Field(ensure.Struct[accessorUser](), "Name", func(u accessorUser) string { return u.Name }, ensure.String(), "Name", "Full name")
+		},
+		"nil pointer": func() {
+			ensure.FieldAt(ensure.Struct[accessorUser](), func(u *accessorUser) *string { return nil }, ensure.String())
+		},
+		"pointer outside struct": func() {
+			ensure.FieldAt(ensure.Struct[accessorUser](), func(u *accessorUser) *string { return &other }, ensure.String())
+		},
+		"nested field": func() {
+			ensure.FieldAt(ensure.Struct[accessorUser](), func(u *accessorUser) *string { return &u.Address.City }, ensure.String())
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
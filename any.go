@@ -1,6 +1,7 @@
 package ensure
 
 import (
+	"context"
 	"github.com/chriscasto/go-ensure/with"
 	"reflect"
 )
@@ -13,13 +14,12 @@ type AnyValidator[T any] struct {
 
 // Any instantiates and returns an instance of AnyValidator
 func Any[T any](validators ...with.Validator[T]) *AnyValidator[T] {
-	var zero T
-
 	if len(validators) == 0 {
 		panic("at least one validator must be provided")
 	}
 
-	typeStr := reflect.ValueOf(zero).Type().String()
+	// TypeFor is used since TypeOf returns nil for the zero value of an interface such as any
+	typeStr := reflect.TypeFor[T]().String()
 
 	return &AnyValidator[T]{
 		validators: validators,
@@ -34,6 +34,18 @@ func (av *AnyValidator[T]) WithError(str string) *AnyValidator[T] {
 	return av
 }
 
+// defaultError returns the error used when none of the validators pass
+// A message set with WithError() is used as-is rather than being translated
+func (av *AnyValidator[T]) defaultError(opts *with.ValidationOptions) error {
+	msg := av.opts.DefaultError().Error()
+
+	if msg != with.DefaultAnyValidatorErrorMsg {
+		return &ValidationError{err: msg, code: CodeAnyNone, custom: true}
+	}
+
+	return translate(newCheckError(CodeAnyNone, nil), opts)
+}
+
 // Type returns a string with the type this validator expects
 func (av *AnyValidator[T]) Type() string {
 	return av.t
@@ -48,11 +60,19 @@ func (av *AnyValidator[T]) WithOptions(opts ...with.AnyOption) *AnyValidator[T]
 
 // Validate applies all validators against a value of the expected type and returns an error if all fail
 func (av *AnyValidator[T]) Validate(i T, options ...*with.ValidationOptions) error {
-	vOpts := getValidationOptions(options)
+	return av.validate(i, getValidationOptions(options))
+}
+
+// validate applies all validators against a value with the provided options
+func (av *AnyValidator[T]) validate(i T, vOpts *with.ValidationOptions) error {
 	errByIdx := make(map[int]error)
 
 	for idx, validator := range av.validators {
-		if err := validator.Validate(i, vOpts); err != nil {
+		if err := validateWith(validator, i, vOpts); err != nil {
+			if cErr := contextError(vOpts); cErr != nil {
+				return cErr
+			}
+
 			errByIdx[idx] = err
 		} else {
 			// If any pass without error, consider it a success
@@ -72,7 +92,7 @@ func (av *AnyValidator[T]) Validate(i T, options ...*with.ValidationOptions) err
 			}
 
 			if !vErrs.HasErrors() {
-				vErrs.Append(av.opts.DefaultError())
+				vErrs.Append(av.defaultError(vOpts))
 			}
 
 			return vErrs
@@ -88,18 +108,28 @@ func (av *AnyValidator[T]) Validate(i T, options ...*with.ValidationOptions) err
 	// Since we return on the first nil above, the only way we get here is
 	// if we aren't passing through any of the errors. We return the default
 	// error message instead
-	return av.opts.DefaultError()
+	return av.defaultError(vOpts)
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// in the nested validators and stops early with a CanceledError once ctx is done
+func (av *AnyValidator[T]) ValidateContext(ctx context.Context, i T, options ...*with.ValidationOptions) error {
+	return av.Validate(i, contextOptions(ctx, options))
 }
 
 // ValidateUntyped applies all validators against a value of an unknown type and returns an error if all fail
 func (av *AnyValidator[T]) ValidateUntyped(i any, options ...*with.ValidationOptions) error {
+	vOpts := getValidationOptions(options)
+
 	for _, validator := range av.validators {
-		if err := validator.ValidateUntyped(i, options...); err == nil {
+		if err := validator.ValidateUntyped(i, vOpts); err == nil {
 			// If any pass without error, consider it a success
 			return nil
+		} else if cErr := contextError(vOpts); cErr != nil {
+			return cErr
 		}
 	}
 
 	// If we haven't encountered a success, return an error
-	return av.opts.DefaultError()
+	return av.defaultError(vOpts)
 }
@@ -1,9 +1,10 @@
 package ensure
 
 import (
-	"fmt"
+	"context"
 	"github.com/chriscasto/go-ensure/with"
 	"reflect"
+	"slices"
 )
 
 // ArrayValidator contains information and logic used to validate an array of type T
@@ -19,9 +20,8 @@ type ComparableArrayValidator[T comparable] struct {
 
 // Array constructs an ArrayValidator instance of type T and returns a pointer to it
 func Array[T any]() *ArrayValidator[T] {
-	var zero T
-
-	typeStr := fmt.Sprintf("[]%s", reflect.TypeOf(zero).String())
+	// TypeFor is used since TypeOf returns nil for the zero value of an interface such as any
+	typeStr := reflect.TypeFor[[]T]().String()
 
 	return &ArrayValidator[T]{
 		typeStr: typeStr,
@@ -31,9 +31,8 @@ func Array[T any]() *ArrayValidator[T] {
 
 // ComparableArray constructs a ComparableArrayValidator instance of type T and returns a pointer to it
 func ComparableArray[T comparable]() *ComparableArrayValidator[T] {
-	var zero T
-
-	typeStr := fmt.Sprintf("[]%s", reflect.TypeOf(zero).String())
+	// TypeFor is used since TypeOf returns nil for the zero value of an interface such as any
+	typeStr := reflect.TypeFor[[]T]().String()
 
 	return &ComparableArrayValidator[T]{
 		ArrayValidator[T]{
@@ -51,6 +50,7 @@ func (av *ArrayValidator[T]) Type() string {
 // HasLengthWhere adds a NumberValidator for validating the length of the array
 func (av *ArrayValidator[T]) HasLengthWhere(nv *NumberValidator[int]) *ArrayValidator[T] {
 	av.checks.AddHasLengthWhere(nv)
+	av.checks.Record(rule{name: "HasLengthWhere", nested: nv})
 	return av
 }
 
@@ -58,6 +58,7 @@ func (av *ArrayValidator[T]) HasLengthWhere(nv *NumberValidator[int]) *ArrayVali
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(0))
 func (av *ArrayValidator[T]) IsEmpty() *ArrayValidator[T] {
 	av.checks.AddIsEmpty()
+	av.checks.Record(rule{name: "IsEmpty"})
 	return av
 }
 
@@ -65,6 +66,7 @@ func (av *ArrayValidator[T]) IsEmpty() *ArrayValidator[T] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().DoesNotEqual(0))
 func (av *ArrayValidator[T]) IsNotEmpty() *ArrayValidator[T] {
 	av.checks.AddIsNotEmpty()
+	av.checks.Record(rule{name: "IsNotEmpty"})
 	return av
 }
 
@@ -72,6 +74,7 @@ func (av *ArrayValidator[T]) IsNotEmpty() *ArrayValidator[T] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(l))
 func (av *ArrayValidator[T]) HasCount(l int) *ArrayValidator[T] {
 	av.checks.AddHasLength(l)
+	av.checks.Record(rule{name: "HasCount", params: map[string]any{"expected": l}})
 	return av
 }
 
@@ -79,6 +82,7 @@ func (av *ArrayValidator[T]) HasCount(l int) *ArrayValidator[T] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().IsGreaterThan(l))
 func (av *ArrayValidator[T]) HasMoreThan(l int) *ArrayValidator[T] {
 	av.checks.AddIsLongerThan(l)
+	av.checks.Record(rule{name: "HasMoreThan", params: map[string]any{"expected": l}})
 	return av
 }
 
@@ -86,12 +90,14 @@ func (av *ArrayValidator[T]) HasMoreThan(l int) *ArrayValidator[T] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().IsLessThan(l))
 func (av *ArrayValidator[T]) HasFewerThan(l int) *ArrayValidator[T] {
 	av.checks.AddIsShorterThan(l)
+	av.checks.Record(rule{name: "HasFewerThan", params: map[string]any{"expected": l}})
 	return av
 }
 
 // Each assigns a Validator to be used for validating array values
 func (av *ArrayValidator[T]) Each(ev with.Validator[T]) *ArrayValidator[T] {
 	av.checks.AddIterValValidator(ev)
+	av.checks.Record(rule{name: "Each", nested: ev})
 	return av
 }
 
@@ -105,7 +111,35 @@ func (av *ArrayValidator[T]) ValidateUntyped(value any, options ...*with.Validat
 
 // Validate applies all checks against an array and returns an error if any fail
 func (av *ArrayValidator[T]) Validate(arr []T, options ...*with.ValidationOptions) error {
-	return av.checks.Evaluate(arr, getValidationOptions(options))
+	return av.validate(arr, getValidationOptions(options))
+}
+
+// validate applies all checks against an array with the provided options
+func (av *ArrayValidator[T]) validate(arr []T, opts *with.ValidationOptions) error {
+	return av.checks.Evaluate(arr, opts)
+}
+
+// validateRef validates the array that a struct field or getter refers to
+func (av *ArrayValidator[T]) validateRef(ref any, opts *with.ValidationOptions) error {
+	return av.validate(deref[[]T](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (av *ArrayValidator[T]) valueType() reflect.Type {
+	return reflect.TypeFor[[]T]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (av *ArrayValidator[T]) ValidateContext(ctx context.Context, arr []T, options ...*with.ValidationOptions) error {
+	return av.Validate(arr, contextOptions(ctx, options))
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (av *ArrayValidator[T]) WithMessage(tmpl string) *ArrayValidator[T] {
+	av.checks.SetMessage(tmpl)
+	return av
 }
 
 // Is adds the provided function as a check against any values to be validated
@@ -113,6 +147,17 @@ func (av *ArrayValidator[T]) Is(fn func([]T) error) *ArrayValidator[T] {
 	av.checks.Append(func(val []T, _ *with.ValidationOptions) error {
 		return fn(val)
 	})
+	av.checks.Record(rule{name: "Is"})
+	return av
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (av *ArrayValidator[T]) IsCtx(fn func(context.Context, []T) error) *ArrayValidator[T] {
+	av.checks.Append(func(val []T, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	av.checks.Record(rule{name: "IsCtx"})
 	return av
 }
 
@@ -131,8 +176,9 @@ func (cv *ComparableArrayValidator[T]) Contains(item T) *ComparableArrayValidato
 			}
 		}
 
-		return fmt.Errorf(`array must contain value "%v"`, item)
+		return newCheckError(CodeArrayContains, map[string]any{"expected": item})
 	})
+	cv.checks.Record(rule{name: "Contains", params: map[string]any{"expected": item}})
 	return cv
 }
 
@@ -141,12 +187,13 @@ func (cv *ComparableArrayValidator[T]) DoesNotContain(item T) *ComparableArrayVa
 	cv.checks.Append(func(val []T, _ *with.ValidationOptions) error {
 		for _, v := range val {
 			if v == item {
-				return fmt.Errorf(`array must not contain value "%v"`, item)
+				return newCheckError(CodeArrayNotContains, map[string]any{"expected": item})
 			}
 		}
 
 		return nil
 	})
+	cv.checks.Record(rule{name: "DoesNotContain", params: map[string]any{"expected": item}})
 	return cv
 }
 
@@ -163,12 +210,13 @@ func (cv *ComparableArrayValidator[T]) ContainsOnly(items ...T) *ComparableArray
 			_, ok := allow[v]
 
 			if !ok {
-				return fmt.Errorf(`array must contain only allowed values; value "%v" not allowed`, v)
+				return newCheckError(CodeArrayContainsOnly, map[string]any{"actual": v})
 			}
 		}
 
 		return nil
 	})
+	cv.checks.Record(rule{name: "ContainsOnly", params: map[string]any{"values": slices.Clone(items)}})
 	return cv
 }
 
@@ -181,7 +229,7 @@ func (cv *ComparableArrayValidator[T]) ContainsNoDuplicates() *ComparableArrayVa
 			_, ok := found[v]
 
 			if ok {
-				return fmt.Errorf(`array must not contain duplicate values; value "%v" is repeated`, v)
+				return newCheckError(CodeArrayUnique, map[string]any{"actual": v})
 			}
 
 			found[v] = true
@@ -189,6 +237,7 @@ func (cv *ComparableArrayValidator[T]) ContainsNoDuplicates() *ComparableArrayVa
 
 		return nil
 	})
+	cv.checks.Record(rule{name: "ContainsNoDuplicates"})
 	return cv
 }
 
@@ -209,8 +258,9 @@ func (cv *ComparableArrayValidator[T]) ContainsAnyOf(items ...T) *ComparableArra
 			}
 		}
 
-		return fmt.Errorf(`array must contain one of the expected values`)
+		return newCheckError(CodeArrayContainsAny, nil)
 	})
+	cv.checks.Record(rule{name: "ContainsAnyOf", params: map[string]any{"values": slices.Clone(items)}})
 	return cv
 }
 
@@ -227,11 +277,19 @@ func (cv *ComparableArrayValidator[T]) DoesNotContainAnyOf(items ...T) *Comparab
 			_, ok := expect[v]
 
 			if ok {
-				return fmt.Errorf(`array must not contain any prohibited values`)
+				return newCheckError(CodeArrayNotContainsAny, nil)
 			}
 		}
 
 		return nil
 	})
+	cv.checks.Record(rule{name: "DoesNotContainAnyOf", params: map[string]any{"values": slices.Clone(items)}})
+	return cv
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (cv *ComparableArrayValidator[T]) WithMessage(tmpl string) *ComparableArrayValidator[T] {
+	cv.checks.SetMessage(tmpl)
 	return cv
 }
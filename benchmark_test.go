@@ -0,0 +1,266 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"runtime/debug"
+	"slices"
+	"testing"
+)
+
+type benchAddress struct {
+	Street string
+	City   string
+	Zip    string
+}
+
+type benchUser struct {
+	Name    string
+	Email   string
+	Age     int
+	Score   float64
+	Active  bool
+	Tags    []string
+	Meta    map[string]string
+	Address benchAddress
+	Manager *benchAddress
+}
+
+func (u benchUser) DisplayName() string {
+	return u.Name
+}
+
+func (u benchUser) Home() benchAddress {
+	return u.Address
+}
+
+var (
+	benchString = ensure.String().IsNotEmpty().IsShorterThan(64).Matches(ensure.Email)
+	benchNumber = ensure.Number[int]().IsGreaterThanOrEqualTo(18).IsLessThan(130)
+	benchArray  = ensure.Array[string]().IsNotEmpty().HasFewerThan(10).Each(ensure.String().IsLongerThan(1))
+	benchMap    = ensure.Map[string, string]().IsNotEmpty().EachKey(ensure.String().Matches(ensure.Alpha)).EachValue(ensure.String().IsNotEmpty())
+
+	benchAddressValidator = ensure.Struct[benchAddress]().HasFields(with.Validators{
+		"Street": ensure.String().IsNotEmpty(),
+		"City":   ensure.String().IsNotEmpty(),
+		"Zip":    ensure.String().HasLength(5).Matches(ensure.Numbers),
+	})
+
+	benchUserValidator = newBenchUserValidator()
+
+	benchGetterValidator = ensure.Struct[benchUser]().HasGetters(with.Validators{
+		"DisplayName": ensure.String().IsNotEmpty(),
+		"Home":        benchAddressValidator,
+	})
+
+	benchValidUser = benchUser{
+		Name:    "Gopher",
+		Email:   "gopher@example.com",
+		Age:     30,
+		Score:   1.5,
+		Active:  true,
+		Tags:    []string{"go", "ensure"},
+		Meta:    map[string]string{"team": "core"},
+		Address: benchAddress{Street: "1 Main St", City: "Springfield", Zip: "12345"},
+	}
+)
+
+// newBenchUserValidator returns a validator for benchUser, with its methods added using Field
+// so they're called directly rather than through reflection
+func newBenchUserValidator() *ensure.StructValidator[benchUser] {
+	sv := ensure.Struct[benchUser]().HasFields(with.Validators{
+		"Name":    ensure.String().IsNotEmpty(),
+		"Email":   benchString,
+		"Age":     benchNumber,
+		"Score":   ensure.Number[float64]().IsPositive(),
+		"Active":  ensure.Bool().IsTrue(),
+		"Tags":    benchArray,
+		"Meta":    benchMap,
+		"Address": benchAddressValidator,
+		"Manager": ensure.OptionalPointer[benchAddress](benchAddressValidator),
+	})
+
+	ensure.Field(sv, "DisplayName", benchUser.DisplayName, ensure.String().IsNotEmpty())
+
+	return ensure.Field(sv, "Home", benchUser.Home, benchAddressValidator)
+}
+
+func BenchmarkString(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchString.Validate("gopher@example.com"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNumber(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchNumber.Validate(30); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkArray(b *testing.B) {
+	tags := []string{"go", "ensure", "validation"}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchArray.Validate(tags); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	meta := map[string]string{"team": "core", "role": "maintainer"}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchMap.Validate(meta); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct(b *testing.B) {
+	addr := benchAddress{Street: "1 Main St", City: "Springfield", Zip: "12345"}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchAddressValidator.Validate(addr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct_Nested(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchUserValidator.Validate(benchValidUser); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct_Getters(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchGetterValidator.Validate(benchValidUser); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct_CollectAllErrors(b *testing.B) {
+	opts := with.Options(with.OptionCollectAllErrors())
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchUserValidator.Validate(benchValidUser, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct_Invalid(b *testing.B) {
+	invalid := benchValidUser
+	invalid.Email = "nope"
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := benchUserValidator.Validate(invalid); err == nil {
+			b.Fatal("expected error")
+		}
+	}
+}
+
+// TestValidate_Allocs checks that the values used by the benchmarks are validated
+// without allocating, including nested structs and methods added with Field
+func TestValidate_Allocs(t *testing.T) {
+	if raceEnabled() {
+		t.Skip("the race detector allocates as it tracks memory")
+	}
+
+	tags := []string{"go", "ensure", "validation"}
+	meta := map[string]string{"team": "core", "role": "maintainer"}
+	addr := benchAddress{Street: "1 Main St", City: "Springfield", Zip: "12345"}
+	collectAll := with.Options(with.OptionCollectAllErrors())
+
+	testCases := map[string]func() error{
+		"string": func() error { return benchString.Validate("gopher@example.com") },
+		"number": func() error { return benchNumber.Validate(30) },
+		"array":  func() error { return benchArray.Validate(tags) },
+		"map":    func() error { return benchMap.Validate(meta) },
+		"struct": func() error { return benchAddressValidator.Validate(addr) },
+		"nested": func() error { return benchUserValidator.Validate(benchValidUser) },
+		"collect all errors": func() error {
+			return benchUserValidator.Validate(benchValidUser, collectAll)
+		},
+		"untyped": func() error { return benchUserValidator.ValidateUntyped(benchValidUser) },
+	}
+
+	for name, validate := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var err error
+
+			allocs := testing.AllocsPerRun(100, func() {
+				err = validate()
+			})
+
+			if err != nil {
+				t.Fatalf("expected no error; got %s", err)
+			}
+
+			if allocs != 0 {
+				t.Errorf("expected no allocations; got %v per run", allocs)
+			}
+		})
+	}
+}
+
+// TestValidate_GetterAllocs checks that getters added with HasGetters allocate no more
+// than the single value reflection allocates for each call
+func TestValidate_GetterAllocs(t *testing.T) {
+	if raceEnabled() {
+		t.Skip("the race detector allocates as it tracks memory")
+	}
+
+	var err error
+
+	allocs := testing.AllocsPerRun(100, func() {
+		err = benchGetterValidator.Validate(benchValidUser)
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	if allocs > 2 {
+		t.Errorf("expected at most one allocation for each of the 2 getters; got %v per run", allocs)
+	}
+}
+
+// raceEnabled returns true if the tests were built with the race detector
+func raceEnabled() bool {
+	info, ok := debug.ReadBuildInfo()
+
+	return ok && slices.ContainsFunc(info.Settings, func(setting debug.BuildSetting) bool {
+		return setting.Key == "-race" && setting.Value == "true"
+	})
+}
@@ -1,13 +1,20 @@
 package ensure
 
-import "github.com/chriscasto/go-ensure/with"
+import (
+	"context"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
 
 const boolType = "bool"
 
 // BooleanValidator contains information and logic used to validate a boolean value
 type BooleanValidator struct {
-	expectTrue  bool
-	expectFalse bool
+	expectTrue   bool
+	expectFalse  bool
+	trueMessage  string
+	falseMessage string
+	lastMessage  *string
 }
 
 // Bool returns an initialized BooleanValidator
@@ -26,40 +33,89 @@ func (bv *BooleanValidator) Type() string {
 // IsTrue will cause the validator to return an error if the value is not true
 func (bv *BooleanValidator) IsTrue() *BooleanValidator {
 	bv.expectTrue = true
+	bv.lastMessage = &bv.trueMessage
 	return bv
 }
 
 // IsFalse will cause the validator to return an error if the value is not false
 func (bv *BooleanValidator) IsFalse() *BooleanValidator {
 	bv.expectFalse = true
+	bv.lastMessage = &bv.falseMessage
 	return bv
 }
 
+// WithMessage overrides the message returned by the preceding IsTrue() or IsFalse() rule
+func (bv *BooleanValidator) WithMessage(tmpl string) *BooleanValidator {
+	if bv.lastMessage == nil {
+		panic("WithMessage must follow a rule")
+	}
+
+	*bv.lastMessage = tmpl
+	return bv
+}
+
+// checkError returns the error for a failed rule, using the custom message if one was set
+func (bv *BooleanValidator) checkError(code string, tmpl string, b bool, opts *with.ValidationOptions) error {
+	err := newCheckError(code, nil)
+
+	if tmpl != "" {
+		return overrideMessage(err, tmpl, b)
+	}
+
+	return translate(err, opts)
+}
+
 // ValidateUntyped accepts an arbitrary input type and validates it if it's a boolean
-func (bv *BooleanValidator) ValidateUntyped(i interface{}, _ ...*with.ValidationOptions) error {
+func (bv *BooleanValidator) ValidateUntyped(i interface{}, options ...*with.ValidationOptions) error {
 	b, ok := i.(bool)
 
 	if !ok {
 		return NewTypeError("boolean expected")
 	}
 
-	return bv.Validate(b)
+	return bv.Validate(b, options...)
 }
 
 // Validate applies all checks against a boolean value and returns an error if any fail
-func (bv *BooleanValidator) Validate(b bool, _ ...*with.ValidationOptions) error {
+func (bv *BooleanValidator) Validate(b bool, options ...*with.ValidationOptions) error {
+	return bv.validate(b, getValidationOptions(options))
+}
+
+// validate applies all checks against a boolean value with the provided options
+func (bv *BooleanValidator) validate(b bool, opts *with.ValidationOptions) error {
 	// There are really only two possibilities, so we can just check those
 	// directly rather than using an array of functions
 
 	// It also doesn't make sense to return multiple errors, since there is no valid scenario for that
 
 	if bv.expectTrue && b != true {
-		return NewValidationError("expected true but got false")
+		return bv.checkError(CodeBoolTrue, bv.trueMessage, b, opts)
 	}
 
 	if bv.expectFalse && b != false {
-		return NewValidationError("expected false but got true")
+		return bv.checkError(CodeBoolFalse, bv.falseMessage, b, opts)
 	}
 
 	return nil
 }
+
+// validateScalar validates a boolean read from a struct field
+func (bv *BooleanValidator) validateScalar(s scalar, opts *with.ValidationOptions) error {
+	return bv.validate(s.bool, opts)
+}
+
+// validateRef validates the boolean that a struct field or getter refers to
+func (bv *BooleanValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return bv.validate(deref[bool](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (bv *BooleanValidator) valueType() reflect.Type {
+	return reflect.TypeFor[bool]()
+}
+
+// ValidateContext is like Validate
+// Boolean checks never use ctx, but it is accepted for consistency with other validators
+func (bv *BooleanValidator) ValidateContext(ctx context.Context, b bool, options ...*with.ValidationOptions) error {
+	return bv.Validate(b, contextOptions(ctx, options))
+}
@@ -0,0 +1,209 @@
+package ensure
+
+import (
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Plural categories, as defined by the Unicode CLDR
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// Message is a template used to render the message for an error code
+// Placeholders such as {expected} are replaced with the matching value from the error's params
+type Message struct {
+	// Text is the template used when no plural form applies
+	Text string
+
+	// Count is the name of the param used to select a plural form, if the message has any
+	Count string
+
+	// Forms maps plural categories (eg PluralOne) to templates
+	Forms map[string]string
+}
+
+// Catalog contains the translated messages and display names for a single locale
+type Catalog struct {
+	// Locale is a language tag such as "de" or "pt-BR"
+	Locale string
+
+	// Messages maps error codes to message templates
+	Messages map[string]Message
+
+	// DisplayNames maps the display names set with with.DisplayNames to translated names
+	DisplayNames map[string]string
+
+	// Plural returns the plural category for a count
+	// It is required if any of the messages have plural forms
+	Plural func(n int) string
+}
+
+// PluralOneOther is a plural rule for languages that only distinguish between
+// one and everything else, such as English and German
+func PluralOneOther(n int) string {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// catalogs contains every registered catalog, keyed by lowercase locale
+var (
+	catalogs   = map[string]*Catalog{}
+	catalogsMu sync.RWMutex
+)
+
+// RegisterCatalog makes a catalog available to validation using with.OptionLocale()
+// Registering a catalog for a locale that already has one replaces it, including
+// "en", which can be used to reword the default messages
+// Any messages missing from a catalog are rendered in English
+func RegisterCatalog(c *Catalog) {
+	if c == nil || c.Locale == "" {
+		panic("catalog must have a locale")
+	}
+
+	for code, msg := range c.Messages {
+		if len(msg.Forms) > 0 && (msg.Count == "" || c.Plural == nil) {
+			panic(fmt.Sprintf(`message for code "%s" has plural forms but no count or plural rule`, code))
+		}
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	catalogs[normalizeLocale(c.Locale)] = c
+}
+
+// normalizeLocale converts a locale to the form used as a key for catalogs (eg "pt_BR" to "pt-br")
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// findCatalog returns the catalog registered for a locale, falling back to its
+// base language (eg "de" for "de-AT"), or nil if there is none
+func findCatalog(locale string) *Catalog {
+	locale = normalizeLocale(locale)
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	if c, ok := catalogs[locale]; ok {
+		return c
+	}
+
+	if base, _, found := strings.Cut(locale, "-"); found {
+		return catalogs[base]
+	}
+
+	return nil
+}
+
+// translate returns a copy of err with its message rendered by the catalog for
+// the locale in opts
+// Errors without a code, or with a custom message, are returned unchanged
+func translate(err error, opts *with.ValidationOptions) error {
+	vErr, ok := err.(*ValidationError)
+
+	if !ok || vErr.code == "" || vErr.custom {
+		return err
+	}
+
+	c := findCatalog(opts.Locale())
+
+	if c == nil {
+		return err
+	}
+
+	msg, ok := c.Messages[vErr.code]
+
+	if !ok {
+		return err
+	}
+
+	translated := *vErr
+	translated.err = msg.render(vErr.params, c.Plural)
+	return &translated
+}
+
+// translateDisplayName returns the display name for a field in the locale in opts
+func translateDisplayName(name string, opts *with.ValidationOptions) string {
+	if c := findCatalog(opts.Locale()); c != nil {
+		if translated, ok := c.DisplayNames[name]; ok {
+			return translated
+		}
+	}
+
+	return name
+}
+
+// render fills in the message template with params, using the plural form for
+// the count param if there is one
+func (m Message) render(params map[string]any, plural func(int) string) string {
+	tmpl := m.Text
+
+	if m.Count != "" && plural != nil {
+		if n, ok := toCount(params[m.Count]); ok {
+			if form, ok := m.Forms[plural(n)]; ok {
+				tmpl = form
+			}
+		}
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+
+	for name, val := range params {
+		pairs = append(pairs, "{"+name+"}", formatParam(val))
+	}
+
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+// toCount converts a numeric param to an int for choosing a plural form
+// Numbers with a fractional component can't be used as a count
+func toCount(val any) (int, bool) {
+	ref := reflect.ValueOf(val)
+
+	switch ref.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(ref.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(ref.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := ref.Float()
+		return int(f), f == math.Trunc(f)
+	default:
+		return 0, false
+	}
+}
+
+// formatParam returns the string used to represent a param in a message
+// Numbers without a String method use the same verbs number check messages have always used (%d and %g)
+func formatParam(val any) string {
+	switch v := val.(type) {
+	case time.Time:
+		return v.Format(timeFormat)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	switch reflect.ValueOf(val).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", val)
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%g", val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
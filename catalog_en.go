@@ -0,0 +1,81 @@
+package ensure
+
+// englishMessages is the built-in English catalog, used to render messages for
+// any locale or code that doesn't have a registered translation
+var englishMessages = map[string]Message{
+	CodeStringEquals:           {Text: `string must equal "{expected}"`},
+	CodeStringNotEquals:        {Text: `string must not equal "{expected}"`},
+	CodeStringStartsWith:       {Text: `string must start with "{expected}"`},
+	CodeStringNotStartsWith:    {Text: `string must not start with "{expected}"`},
+	CodeStringEndsWith:         {Text: `string must end with "{expected}"`},
+	CodeStringNotEndsWith:      {Text: `string must not end with "{expected}"`},
+	CodeStringContains:         {Text: `string must contain "{expected}"`},
+	CodeStringNotContains:      {Text: `string must not contain "{expected}"`},
+	CodeStringOneOf:            {Text: `string must be one of the permitted values`},
+	CodeStringNotOneOf:         {Text: `string must not be one of the prohibited values`},
+	CodeStringMatches:          {Text: `string does not match expected pattern`},
+	CodeStringEmpty:            {Text: `must be empty`},
+	CodeStringNotEmpty:         {Text: `must not be empty`},
+	CodeStringLengthEquals:     {Text: `length must equal {expected}; got {actual}`},
+	CodeStringLengthGreater:    {Text: `must have a length greater than {expected}; got {actual}`},
+	CodeStringLengthLess:       {Text: `must have a length less than {expected}; got {actual}`},
+	CodeStringDuration:         {Text: `string must be a valid duration; got "{actual}"`},
+	CodeNumberEquals:           {Text: `number must equal {expected}; got {actual}`},
+	CodeNumberNotEquals:        {Text: `number must not equal {expected}; got {actual}`},
+	CodeNumberLess:             {Text: `number must be less than {expected}; got {actual}`},
+	CodeNumberLessOrEqual:      {Text: `number must be less than or equal to {expected}; got {actual}`},
+	CodeNumberGreater:          {Text: `number must be greater than {expected}; got {actual}`},
+	CodeNumberGreaterOrEqual:   {Text: `number must be greater than or equal to {expected}; got {actual}`},
+	CodeNumberRange:            {Text: `number must be in the range [{min}, {max}); got {actual}`},
+	CodeNumberEven:             {Text: `number must be even; got {actual}`},
+	CodeNumberOdd:              {Text: `number must be odd; got {actual}`},
+	CodeNumberOneOf:            {Text: `number must be one of the permitted values`},
+	CodeNumberNotOneOf:         {Text: `number must not be one of the prohibited values`},
+	CodeBoolTrue:               {Text: `expected true but got false`},
+	CodeBoolFalse:              {Text: `expected false but got true`},
+	CodeArrayEmpty:             {Text: `must be empty`},
+	CodeArrayNotEmpty:          {Text: `must not be empty`},
+	CodeArrayLengthEquals:      {Text: `length must equal {expected}; got {actual}`},
+	CodeArrayLengthGreater:     {Text: `must have a length greater than {expected}; got {actual}`},
+	CodeArrayLengthLess:        {Text: `must have a length less than {expected}; got {actual}`},
+	CodeArrayContains:          {Text: `array must contain value "{expected}"`},
+	CodeArrayNotContains:       {Text: `array must not contain value "{expected}"`},
+	CodeArrayContainsOnly:      {Text: `array must contain only allowed values; value "{actual}" not allowed`},
+	CodeArrayUnique:            {Text: `array must not contain duplicate values; value "{actual}" is repeated`},
+	CodeArrayContainsAny:       {Text: `array must contain one of the expected values`},
+	CodeArrayNotContainsAny:    {Text: `array must not contain any prohibited values`},
+	CodeMapEmpty:               {Text: `must be empty`},
+	CodeMapNotEmpty:            {Text: `must not be empty`},
+	CodeMapLengthEquals:        {Text: `length must equal {expected}; got {actual}`},
+	CodeMapLengthGreater:       {Text: `must have a length greater than {expected}; got {actual}`},
+	CodeMapLengthLess:          {Text: `must have a length less than {expected}; got {actual}`},
+	CodePointerRequired:        {Text: `required value cannot be missing`},
+	CodeAnyNone:                {Text: `none of the possible validators passed`},
+	CodeTimeBefore:             {Text: `time must be before {expected}; got {actual}`},
+	CodeTimeAfter:              {Text: `time must be after {expected}; got {actual}`},
+	CodeTimeRange:              {Text: `time must be in the range [{min}, {max}); got {actual}`},
+	CodeTimePast:               {Text: `time must be in the past; got {actual}`},
+	CodeTimeFuture:             {Text: `time must be in the future; got {actual}`},
+	CodeTimeWithin:             {Text: `time must be within {expected} of now; got {actual}`},
+	CodeTimeWeekday:            {Text: `time must be on a weekday; got {actual}`},
+	CodeTimeLocation:           {Text: `time must be in location "{expected}"; got "{actual}"`},
+	CodeDurationEquals:         {Text: `duration must equal {expected}; got {actual}`},
+	CodeDurationNotEquals:      {Text: `duration must not equal {expected}; got {actual}`},
+	CodeDurationLess:           {Text: `duration must be less than {expected}; got {actual}`},
+	CodeDurationLessOrEqual:    {Text: `duration must be less than or equal to {expected}; got {actual}`},
+	CodeDurationGreater:        {Text: `duration must be greater than {expected}; got {actual}`},
+	CodeDurationGreaterOrEqual: {Text: `duration must be greater than or equal to {expected}; got {actual}`},
+	CodeDurationRange:          {Text: `duration must be in the range [{min}, {max}); got {actual}`},
+	CodeDurationMultipleOf:     {Text: `duration must be a multiple of {expected}; got {actual}`},
+	CodeStructFieldEquals:      {Text: `must equal {other}`},
+	CodeStructFieldLess:        {Text: `must be less than {other}`},
+	CodeStructFieldRequired:    {Text: `required when {other} is {expected}`},
+	CodeObjectRequiredKey:      {Text: `key "{key}" is required`},
+	CodeObjectAdditionalKey:    {Text: `key "{key}" is not allowed`},
+	CodeSchemaType:             {Text: `must be of type {expected}; got {actual}`},
+	CodeSchemaEnum:             {Text: `must be one of the permitted values`},
+	CodeSchemaConst:            {Text: `must equal {expected}`},
+	CodeSchemaNot:              {Text: `must not match the schema`},
+	CodeSchemaMultipleOf:       {Text: `number must be a multiple of {expected}; got {actual}`},
+	CodeSchemaContains:         {Text: `must contain an item that matches the schema`},
+}
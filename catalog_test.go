@@ -0,0 +1,202 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+)
+
+type catalogPerson struct {
+	Name string
+	Nick *string
+}
+
+func (p catalogPerson) GetName() string {
+	return p.Name
+}
+
+func init() {
+	ensure.RegisterCatalog(&ensure.Catalog{
+		Locale: "de",
+		Plural: ensure.PluralOneOther,
+		Messages: map[string]ensure.Message{
+			ensure.CodeStringLengthEquals: {
+				Text:  "muss {expected} Zeichen lang sein; hat {actual}",
+				Count: "expected",
+				Forms: map[string]string{
+					ensure.PluralOne: "muss genau ein Zeichen lang sein; hat {actual}",
+				},
+			},
+			ensure.CodeNumberLess:      {Text: "Zahl muss kleiner als {expected} sein"},
+			ensure.CodeBoolTrue:        {Text: "muss wahr sein"},
+			ensure.CodePointerRequired: {Text: "darf nicht fehlen"},
+			ensure.CodeAnyNone:         {Text: "keiner der Validatoren war erfolgreich"},
+			ensure.CodeStringDuration:  {Text: "muss eine Dauer sein", Count: "actual", Forms: map[string]string{}},
+		},
+		DisplayNames: map[string]string{
+			"Full Name": "Vollständiger Name",
+			"Nick":      "Spitzname",
+		},
+	})
+
+	ensure.RegisterCatalog(&ensure.Catalog{
+		Locale: "pt_BR",
+		Messages: map[string]ensure.Message{
+			ensure.CodeStringNotEmpty: {Text: "não pode estar vazio"},
+		},
+	})
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	de := with.Options(with.OptionLocale("de-AT"))
+
+	testCases := map[string]struct {
+		err    error
+		expect string
+	}{
+		"plural one":        {ensure.String().HasLength(1).Validate("abc", de), "muss genau ein Zeichen lang sein; hat 3"},
+		"plural other":      {ensure.String().HasLength(2).Validate("abc", de), "muss 2 Zeichen lang sein; hat 3"},
+		"no plural forms":   {ensure.Number[int]().IsLessThan(1).Validate(2, de), "Zahl muss kleiner als 1 sein"},
+		"missing message":   {ensure.Number[int]().IsGreaterThan(3).Validate(2, de), "number must be greater than 3; got 2"},
+		"bool":              {ensure.Bool().IsTrue().ValidateUntyped(false, de), "muss wahr sein"},
+		"pointer":           {ensure.Pointer[string](ensure.String()).Validate(nil, de), "darf nicht fehlen"},
+		"pointer untyped":   {ensure.Pointer[string](ensure.String()).ValidateUntyped((*string)(nil), de), "darf nicht fehlen"},
+		"any":               {ensure.Any[string](ensure.String().HasLength(2)).Validate("a", de), "keiner der Validatoren war erfolgreich"},
+		"any untyped":       {ensure.Any[string](ensure.String().HasLength(2)).ValidateUntyped("a", de), "keiner der Validatoren war erfolgreich"},
+		"any custom":        {ensure.Any[string](ensure.String()).WithError("custom").ValidateUntyped(1, de), "custom"},
+		"count not numeric": {ensure.DurationString(ensure.Duration()).Validate("abc", de), "muss eine Dauer sein"},
+		"custom check":      {ensure.String().Is(func(string) error { return errors.New("nope") }).Validate("a", de), "nope"},
+		"normalized locale": {ensure.String().IsNotEmpty().Validate("", with.Options(with.OptionLocale("pt-br"))), "não pode estar vazio"},
+		"unknown locale":    {ensure.String().IsNotEmpty().Validate("", with.Options(with.OptionLocale("fr-CA"))), "must not be empty"},
+		"unknown language":  {ensure.String().IsNotEmpty().Validate("", with.Options(with.OptionLocale("fr"))), "must not be empty"},
+		"default locale":    {ensure.String().HasLength(1).Validate("abc"), "length must equal 1; got 3"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.err == nil || tc.err.Error() != tc.expect {
+				t.Errorf(`expected "%s"; got "%v"`, tc.expect, tc.err)
+			}
+		})
+	}
+}
+
+func TestCatalog_DisplayNames(t *testing.T) {
+	validPerson := ensure.Struct[catalogPerson]().HasFields(with.Validators{
+		"Nick": ensure.Pointer[string](ensure.String()),
+	}).HasGetters(with.Validators{
+		"GetName": ensure.String().HasLength(1),
+	}, with.DisplayNames{
+		"GetName": "Full Name",
+	})
+
+	opts := with.Options(with.OptionLocale("de"), with.OptionCollectAllErrors())
+	err := validPerson.Validate(catalogPerson{Name: "abc"}, opts)
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil || len(vErrs.ValidationErrors()) != 2 {
+		t.Fatalf(`expected two validation errors; got "%v"`, err)
+	}
+
+	expect := []string{
+		"Spitzname: darf nicht fehlen",
+		"Vollständiger Name: muss genau ein Zeichen lang sein; hat 3",
+	}
+
+	for i, vErr := range vErrs.ValidationErrors() {
+		if vErr.Error() != expect[i] {
+			t.Errorf(`expected "%s"; got "%s"`, expect[i], vErr.Error())
+		}
+	}
+
+	// paths keep the untranslated field names
+	if path := vErrs.ValidationErrors()[1].Path().String(); path != "GetName" {
+		t.Errorf(`expected path "GetName"; got "%s"`, path)
+	}
+}
+
+func TestRegisterCatalog_Panic(t *testing.T) {
+	testCases := map[string]*ensure.Catalog{
+		"nil catalog": nil,
+		"no locale":   {},
+		"no count": {Locale: "xx", Plural: ensure.PluralOneOther, Messages: map[string]ensure.Message{
+			ensure.CodeStringNotEmpty: {Forms: map[string]string{ensure.PluralOne: "one"}},
+		}},
+		"no plural rule": {Locale: "xx", Messages: map[string]ensure.Message{
+			ensure.CodeStringNotEmpty: {Count: "actual", Forms: map[string]string{ensure.PluralOne: "one"}},
+		}},
+	}
+
+	for name, c := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			ensure.RegisterCatalog(c)
+		})
+	}
+}
+
+func TestPluralOneOther(t *testing.T) {
+	for n, expect := range map[int]string{0: ensure.PluralOther, 1: ensure.PluralOne, 2: ensure.PluralOther} {
+		if got := ensure.PluralOneOther(n); got != expect {
+			t.Errorf(`expected "%s" for %d; got "%s"`, expect, n, got)
+		}
+	}
+}
+
+func TestToCount(t *testing.T) {
+	testCases := map[string]struct {
+		val    any
+		expect int
+		ok     bool
+	}{
+		"int":           {3, 3, true},
+		"int8":          {int8(-2), -2, true},
+		"uint":          {uint16(4), 4, true},
+		"whole float":   {2.0, 2, true},
+		"fractional":    {float32(1.5), 1, false},
+		"not a number":  {"3", 0, false},
+		"missing param": {nil, 0, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			n, ok := ensure.ToCount(tc.val)
+
+			if n != tc.expect || ok != tc.ok {
+				t.Errorf("expected (%d, %t); got (%d, %t)", tc.expect, tc.ok, n, ok)
+			}
+		})
+	}
+}
+
+func TestCatalog_NumberFormatting(t *testing.T) {
+	// numbers in messages are formatted the same way they were before messages came from catalogs
+	testCases := map[string]struct {
+		err    error
+		expect string
+	}{
+		"int":           {ensure.Number[int]().IsLessThan(-5).Validate(12), "number must be less than -5; got 12"},
+		"uint":          {ensure.Number[uint64]().Equals(18446744073709551615).Validate(1), "number must equal 18446744073709551615; got 1"},
+		"float":         {ensure.Number[float64]().IsLessThan(1.5).Validate(2.25), "number must be less than 1.5; got 2.25"},
+		"whole float":   {ensure.Number[float64]().IsGreaterThan(3).Validate(2), "number must be greater than 3; got 2"},
+		"float32":       {ensure.Number[float32]().Equals(0.1).Validate(0.2), "number must equal 0.1; got 0.2"},
+		"large float":   {ensure.Number[float64]().IsLessThan(1e7).Validate(123456789), "number must be less than 1e+07; got 1.23456789e+08"},
+		"small float":   {ensure.Number[float64]().IsGreaterThan(0.0001).Validate(0.00001), "number must be greater than 0.0001; got 1e-05"},
+		"float range":   {ensure.Number[float64]().IsInRange(0.5, 1e21).Validate(0.25), "number must be in the range [0.5, 1e+21); got 0.25"},
+		"string length": {ensure.String().HasLength(3).Validate("ab"), "length must equal 3; got 2"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.err == nil || tc.err.Error() != tc.expect {
+				t.Errorf(`expected "%s"; got "%v"`, tc.expect, tc.err)
+			}
+		})
+	}
+}
@@ -1,10 +1,7 @@
 package ensure
 
 import (
-	"errors"
-	"fmt"
 	"github.com/chriscasto/go-ensure/with"
-	"iter"
 )
 
 // iterable describes values that can be treated as sequences
@@ -20,9 +17,42 @@ type lengthy[K comparable, V any] interface {
 // checkFunc defines a function that performs a check against a value
 type checkFunc[T any] func(T, *with.ValidationOptions) error
 
+// optionsValidator is implemented by the validators in this package so they can validate
+// values for each other without allocating a slice for the variadic options
+type optionsValidator[T any] interface {
+	validate(T, *with.ValidationOptions) error
+}
+
+// validateWith validates a value with the provided validator and options
+func validateWith[T any](v with.Validator[T], val T, opts *with.ValidationOptions) error {
+	if ov, ok := v.(optionsValidator[T]); ok {
+		return ov.validate(val, opts)
+	}
+
+	return v.Validate(val, opts)
+}
+
+// rule records a check by the name of the builder method that added it and the
+// params it was given, so a validator can be exported once it's built
+type rule struct {
+	name   string
+	params map[string]any
+
+	// nested is a validator the rule applies to part of the value, such as the
+	// length validator passed to HasLengthWhere or the item validator passed to Each
+	nested with.UntypedValidator
+}
+
 // valChecks is a collection of checkFunc functions
 type valChecks[T any] struct {
 	c []checkFunc[T]
+
+	// rules describes the checks added by builder methods, in the order they were added
+	rules []rule
+
+	// setLastMessage overrides the message of the most recently added check,
+	// which may belong to a nested collection
+	setLastMessage func(tmpl string)
 }
 
 // newValChecks creates a new valChecks struct with an optional set of initial checkFunc functions
@@ -35,6 +65,33 @@ func newValChecks[T any](initChecks ...checkFunc[T]) *valChecks[T] {
 // Append adds another checkFunc to a valChecks collection
 func (vc *valChecks[T]) Append(check checkFunc[T]) {
 	vc.c = append(vc.c, check)
+
+	idx := len(vc.c) - 1
+
+	vc.setLastMessage = func(tmpl string) {
+		orig := vc.c[idx]
+
+		vc.c[idx] = func(val T, opts *with.ValidationOptions) error {
+			if err := orig(val, opts); err != nil {
+				return overrideMessage(err, tmpl, val)
+			}
+			return nil
+		}
+	}
+}
+
+// SetMessage overrides the message of the most recently added check
+func (vc *valChecks[T]) SetMessage(tmpl string) {
+	if vc.setLastMessage == nil {
+		panic("WithMessage must follow a rule")
+	}
+
+	vc.setLastMessage(tmpl)
+}
+
+// Record adds a rule describing a check that has been added to the collection
+func (vc *valChecks[T]) Record(r rule) {
+	vc.rules = append(vc.rules, r)
 }
 
 // Count returns the number of functions in the collection
@@ -43,43 +100,77 @@ func (vc *valChecks[T]) Count() int {
 }
 
 // Evaluate runs every checkFunc against a value and returns any errors
+// If the context in opts is done, evaluation stops and a CanceledError is returned instead
 func (vc *valChecks[T]) Evaluate(target T, opts *with.ValidationOptions) error {
-	if opts.CollectAllErrors() {
-		vErrs := newValidationErrors()
+	var vErrs *ValidationErrors
 
-		for _, fn := range vc.c {
-			if err := fn(target, opts); err != nil {
-				vErrs.Append(err)
-			}
+	for _, fn := range vc.c {
+		if err := contextError(opts); err != nil {
+			return err
 		}
 
-		if vErrs.HasErrors() {
-			return vErrs
-		}
+		if err := fn(target, opts); err != nil {
+			if cErr := contextError(opts); cErr != nil {
+				return cErr
+			}
 
-		return nil
-	} else {
-		for _, fn := range vc.c {
-			if err := fn(target, opts); err != nil {
-				return err
+			if !opts.CollectAllErrors() {
+				return translate(err, opts)
 			}
+
+			appendError(&vErrs, translate(err, opts))
 		}
 	}
 
-	return nil
+	return collectedErrors(vErrs)
+}
+
+// lenCodes holds the error codes used by length checks for a particular kind of value
+type lenCodes struct {
+	empty    string
+	notEmpty string
+	equals   string
+	greater  string
+	less     string
+}
+
+var stringLenCodes = lenCodes{
+	empty:    CodeStringEmpty,
+	notEmpty: CodeStringNotEmpty,
+	equals:   CodeStringLengthEquals,
+	greater:  CodeStringLengthGreater,
+	less:     CodeStringLengthLess,
+}
+
+var arrayLenCodes = lenCodes{
+	empty:    CodeArrayEmpty,
+	notEmpty: CodeArrayNotEmpty,
+	equals:   CodeArrayLengthEquals,
+	greater:  CodeArrayLengthGreater,
+	less:     CodeArrayLengthLess,
+}
+
+var mapLenCodes = lenCodes{
+	empty:    CodeMapEmpty,
+	notEmpty: CodeMapNotEmpty,
+	equals:   CodeMapLengthEquals,
+	greater:  CodeMapLengthGreater,
+	less:     CodeMapLengthLess,
 }
 
 // lenChecks is an extension of valChecks that can set additional checks around the length of a value
 type lenChecks[K comparable, V any, T lengthy[K, V]] struct {
 	*valChecks[T]
 	lenChecks *valChecks[int]
+	codes     lenCodes
 }
 
 // newLenChecks returns a new instance of a lenChecks struct wrapped around a parent valChecks
-func newLenChecks[K comparable, V any, T lengthy[K, V]]() *lenChecks[K, V, T] {
+func newLenChecks[K comparable, V any, T lengthy[K, V]](codes lenCodes) *lenChecks[K, V, T] {
 	return &lenChecks[K, V, T]{
 		valChecks: newValChecks[T](),
 		lenChecks: newValChecks[int](),
+		codes:     codes,
 	}
 }
 
@@ -93,13 +184,14 @@ func (lc *lenChecks[K, V, T]) addLenCheck(lenCheck func(int, *with.ValidationOpt
 	}
 
 	lc.lenChecks.Append(lenCheck)
+	lc.setLastMessage = lc.lenChecks.setLastMessage
 }
 
 // AddIsEmpty adds a length check that asserts length is 0
 func (lc *lenChecks[K, V, T]) AddIsEmpty() {
 	lc.addLenCheck(func(l int, _ *with.ValidationOptions) error {
 		if l != 0 {
-			return errors.New(`must be empty`)
+			return newCheckError(lc.codes.empty, map[string]any{"actual": l})
 		}
 		return nil
 	})
@@ -109,7 +201,7 @@ func (lc *lenChecks[K, V, T]) AddIsEmpty() {
 func (lc *lenChecks[K, V, T]) AddIsNotEmpty() {
 	lc.addLenCheck(func(l int, _ *with.ValidationOptions) error {
 		if l == 0 {
-			return errors.New(`must not be empty`)
+			return newCheckError(lc.codes.notEmpty, nil)
 		}
 		return nil
 	})
@@ -119,7 +211,7 @@ func (lc *lenChecks[K, V, T]) AddIsNotEmpty() {
 func (lc *lenChecks[K, V, T]) AddHasLength(i int) {
 	lc.addLenCheck(func(l int, _ *with.ValidationOptions) error {
 		if l != i {
-			return fmt.Errorf(`length must equal %d; got %d`, i, l)
+			return newCheckError(lc.codes.equals, map[string]any{"expected": i, "actual": l})
 		}
 		return nil
 	})
@@ -129,7 +221,7 @@ func (lc *lenChecks[K, V, T]) AddHasLength(i int) {
 func (lc *lenChecks[K, V, T]) AddIsLongerThan(i int) {
 	lc.addLenCheck(func(l int, _ *with.ValidationOptions) error {
 		if l <= i {
-			return fmt.Errorf(`must have a length greater than %d; got %d`, i, l)
+			return newCheckError(lc.codes.greater, map[string]any{"expected": i, "actual": l})
 		}
 		return nil
 	})
@@ -139,7 +231,7 @@ func (lc *lenChecks[K, V, T]) AddIsLongerThan(i int) {
 func (lc *lenChecks[K, V, T]) AddIsShorterThan(i int) {
 	lc.addLenCheck(func(l int, _ *with.ValidationOptions) error {
 		if l >= i {
-			return fmt.Errorf(`must have a length less than %d; got %d`, i, l)
+			return newCheckError(lc.codes.less, map[string]any{"expected": i, "actual": l})
 		}
 		return nil
 	})
@@ -148,10 +240,7 @@ func (lc *lenChecks[K, V, T]) AddIsShorterThan(i int) {
 // AddHasLengthWhere adds a length check based on the passed int validator
 func (lc *lenChecks[K, V, T]) AddHasLengthWhere(nv *NumberValidator[int]) {
 	lc.addLenCheck(func(l int, opts *with.ValidationOptions) error {
-		if err := nv.Validate(l, opts); err != nil {
-			return err
-		}
-		return nil
+		return nv.validate(l, opts)
 	})
 }
 
@@ -160,46 +249,94 @@ type iterChecks[K comparable, V any, T iterable[K, V]] struct {
 	*lenChecks[K, V, T]
 	iterKeyChecks *valChecks[K]
 	iterValChecks *valChecks[V]
-	toSeq         func(T) iter.Seq2[K, V]
+	evalItems     func(*iterChecks[K, V, T], T, *with.ValidationOptions) error
+	toSegment     func(K) PathSegment
 	checkAdded    bool
 }
 
 // newIterChecks creates a new instance of iterChecks
-func newIterChecks[K comparable, V any, T iterable[K, V]](toSeq func(T) iter.Seq2[K, V]) *iterChecks[K, V, T] {
+func newIterChecks[K comparable, V any, T iterable[K, V]](
+	codes lenCodes,
+	evalItems func(*iterChecks[K, V, T], T, *with.ValidationOptions) error,
+	toSegment func(K) PathSegment,
+) *iterChecks[K, V, T] {
 	return &iterChecks[K, V, T]{
-		lenChecks:     newLenChecks[K, V, T](),
+		lenChecks:     newLenChecks[K, V, T](codes),
 		iterKeyChecks: newValChecks[K](),
 		iterValChecks: newValChecks[V](),
-		toSeq:         toSeq,
+		evalItems:     evalItems,
+		toSegment:     toSegment,
 	}
 }
 
 // newArrIterChecks creates a new instance of iterChecks specific to an array
 func newArrIterChecks[V any]() *iterChecks[int, V, []V] {
 	return newIterChecks[int, V, []V](
-		func(arr []V) iter.Seq2[int, V] {
-			return func(yield func(int, V) bool) {
-				for k, v := range arr {
-					if !yield(k, v) {
-						return
-					}
+		arrayLenCodes,
+		func(ic *iterChecks[int, V, []V], arr []V, opts *with.ValidationOptions) error {
+			var vErrs *ValidationErrors
+
+			for k, v := range arr {
+				if err := ic.evalItem(k, v, opts, &vErrs); err != nil {
+					return err
 				}
 			}
-		})
+
+			return collectedErrors(vErrs)
+		},
+		indexSegment,
+	)
 }
 
 // newMapIterChecks creates a new instance of iterChecks specific to a map
 func newMapIterChecks[K comparable, V any]() *iterChecks[K, V, map[K]V] {
 	return newIterChecks[K, V, map[K]V](
-		func(it map[K]V) iter.Seq2[K, V] {
-			return func(yield func(K, V) bool) {
-				for k, v := range it {
-					if !yield(k, v) {
-						return
-					}
+		mapLenCodes,
+		func(ic *iterChecks[K, V, map[K]V], mp map[K]V, opts *with.ValidationOptions) error {
+			var vErrs *ValidationErrors
+
+			for k, v := range mp {
+				if err := ic.evalItem(k, v, opts, &vErrs); err != nil {
+					return err
 				}
 			}
-		})
+
+			return collectedErrors(vErrs)
+		},
+		func(key K) PathSegment {
+			return keySegment(key)
+		},
+	)
+}
+
+// evalItem evaluates the key and value checks against a single item
+// It returns an error if evaluation should stop, either because the context is done
+// or because the item failed and not all errors are being collected
+func (ic *iterChecks[K, V, T]) evalItem(k K, v V, opts *with.ValidationOptions, vErrs **ValidationErrors) error {
+	if err := ic.itemError(ic.iterKeyChecks.Evaluate(k, opts), k, opts, vErrs); err != nil {
+		return err
+	}
+
+	return ic.itemError(ic.iterValChecks.Evaluate(v, opts), k, opts, vErrs)
+}
+
+// itemError attributes an error from the checks against an item to the item's key,
+// and either collects it or returns it so evaluation stops
+func (ic *iterChecks[K, V, T]) itemError(err error, k K, opts *with.ValidationOptions, vErrs **ValidationErrors) error {
+	if err == nil {
+		return nil
+	}
+
+	if cErr := contextError(opts); cErr != nil {
+		return cErr
+	}
+
+	if !opts.CollectAllErrors() {
+		return prependPath(err, ic.toSegment(k))
+	}
+
+	appendError(vErrs, prependPath(err, ic.toSegment(k)))
+	return nil
 }
 
 // addIterSeqCheck appends a check to the root valCheck that will evaluate each individual value in the sequence
@@ -211,37 +348,7 @@ func (ic *iterChecks[K, V, T]) addIterSeqCheck() {
 
 	// append the check that will evaluate keys and values to the main list of checks
 	ic.Append(func(it T, opts *with.ValidationOptions) error {
-		seq := ic.toSeq(it)
-
-		if opts.CollectAllErrors() {
-			vErrs := newValidationErrors()
-
-			for k, v := range seq {
-				if err := ic.iterKeyChecks.Evaluate(k, opts); err != nil {
-					vErrs.Append(err)
-				}
-
-				if err := ic.iterValChecks.Evaluate(v, opts); err != nil {
-					vErrs.Append(err)
-				}
-			}
-
-			if vErrs.HasErrors() {
-				return vErrs
-			}
-		} else {
-			for k, v := range seq {
-				if err := ic.iterKeyChecks.Evaluate(k, opts); err != nil {
-					return err
-				}
-
-				if err := ic.iterValChecks.Evaluate(v, opts); err != nil {
-					return err
-				}
-			}
-		}
-
-		return nil
+		return ic.evalItems(ic, it, opts)
 	})
 
 	// make sure to mark that the check has been added so it doesn't get added again
@@ -252,12 +359,13 @@ func (ic *iterChecks[K, V, T]) addIterSeqCheck() {
 func (ic *iterChecks[K, V, T]) AddIterKeyCheck(check func(K, *with.ValidationOptions) error) {
 	ic.addIterSeqCheck()
 	ic.iterKeyChecks.Append(check)
+	ic.setLastMessage = ic.iterKeyChecks.setLastMessage
 }
 
 // AddIterKeyValidator adds a check to evaluate a validator against the iterable's keys
 func (ic *iterChecks[K, V, T]) AddIterKeyValidator(v with.Validator[K]) {
 	ic.AddIterKeyCheck(func(val K, opts *with.ValidationOptions) error {
-		return v.Validate(val, opts)
+		return validateWith(v, val, opts)
 	})
 }
 
@@ -265,11 +373,12 @@ func (ic *iterChecks[K, V, T]) AddIterKeyValidator(v with.Validator[K]) {
 func (ic *iterChecks[K, V, T]) AddIterValCheck(check func(V, *with.ValidationOptions) error) {
 	ic.addIterSeqCheck()
 	ic.iterValChecks.Append(check)
+	ic.setLastMessage = ic.iterValChecks.setLastMessage
 }
 
 // AddIterValValidator adds a check to evaluate a validator against the iterable's values
 func (ic *iterChecks[K, V, T]) AddIterValValidator(v with.Validator[V]) {
 	ic.AddIterValCheck(func(val V, opts *with.ValidationOptions) error {
-		return v.Validate(val, opts)
+		return validateWith(v, val, opts)
 	})
 }
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/chriscasto/go-ensure"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tagKey is the struct tag key read by ensure.FromTags
+const tagKey = "ensure"
+
+// packageInfo describes the package to generate validation functions for
+type packageInfo struct {
+	name    string
+	types   *types.Package
+	structs []structInfo
+
+	// imports maps the path of each package the generated file refers to to the name it's imported as
+	imports map[string]string
+
+	// ensureName and withName are the names the ensure and with packages are imported as
+	ensureName string
+	withName   string
+
+	// used holds the package-level names already taken, so generated names don't collide
+	used map[string]bool
+}
+
+// structInfo describes a struct with ensure tags
+type structInfo struct {
+	name   string
+	fields []fieldInfo
+}
+
+// fieldInfo describes a field with an ensure tag, or a nested struct with fields that have them
+type fieldInfo struct {
+	name    string
+	typeStr string
+	tag     string
+	varName string
+
+	// convert is set when the field has a named type, which is converted to typeStr
+	// before it's validated, the same way ensure.FromTag validates named types
+	convert bool
+
+	// fields holds the fields of a nested struct, which has no tag of its own
+	fields []fieldInfo
+}
+
+// tagCheckers maps the field types supported by ensure tags to a function that checks a tag for that type
+// Named types are looked up by the type they're validated as (see unnamedTypeName)
+var tagCheckers = map[string]func(tag string) error{}
+
+// addTagChecker registers checkers for type T, along with pointers, slices, and maps of T
+func addTagChecker[T any](name string) {
+	tagCheckers[name] = checkTag[T]
+	tagCheckers["*"+name] = checkTag[*T]
+	tagCheckers["[]"+name] = checkTag[[]T]
+	tagCheckers["map[string]"+name] = checkTag[map[string]T]
+}
+
+// checkTag returns an error if the tag isn't valid for a field of type F
+func checkTag[F any](tag string) error {
+	_, err := ensure.TryFromTag[F](tag)
+	return err
+}
+
+func init() {
+	addTagChecker[string]("string")
+	addTagChecker[bool]("bool")
+	addTagChecker[int]("int")
+	addTagChecker[int8]("int8")
+	addTagChecker[int16]("int16")
+	addTagChecker[int32]("int32")
+	addTagChecker[int64]("int64")
+	addTagChecker[uint]("uint")
+	addTagChecker[uint8]("uint8")
+	addTagChecker[uint16]("uint16")
+	addTagChecker[uint32]("uint32")
+	addTagChecker[uint64]("uint64")
+	addTagChecker[float32]("float32")
+	addTagChecker[float64]("float64")
+}
+
+// loadPackage type checks the package in dir and collects the structs with ensure tags
+// The output file is skipped so a previously generated file doesn't affect the result
+// If names is set, only those structs are included, and each one must exist and have ensure tags
+func loadPackage(dir string, output string, names []string) (*packageInfo, error) {
+	absDir, err := filepath.Abs(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Dependencies are type checked from source, since building export data for them with
+	// go list would also build this package, including the output file
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			if filename == filepath.Join(absDir, output) {
+				return parser.ParseFile(fset, filename, src, parser.PackageClauseOnly)
+			}
+
+			return parser.ParseFile(fset, filename, src, parser.AllErrors|parser.ParseComments)
+		},
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	lp := pkgs[0]
+
+	// Type errors are expected when other files use the functions in the skipped output file,
+	// and don't stop the types of fields from being resolved
+	for _, pkgErr := range lp.Errors {
+		if pkgErr.Kind != packages.TypeError {
+			return nil, pkgErr
+		}
+	}
+
+	pkg := &packageInfo{
+		name:    lp.Name,
+		types:   lp.Types,
+		imports: map[string]string{},
+		used:    map[string]bool{},
+	}
+
+	for _, name := range lp.Types.Scope().Names() {
+		pkg.used[name] = true
+	}
+
+	pkg.ensureName = pkg.uniqueName("ensure")
+	pkg.withName = pkg.uniqueName("with")
+
+	for _, file := range lp.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := lp.TypesInfo.Defs[ts.Name].Type().Underlying().(*types.Struct)
+
+				if !ok || ts.TypeParams != nil || (names != nil && !slices.Contains(names, ts.Name.Name)) {
+					continue
+				}
+
+				pkg.used[funcName(ts.Name.Name)] = true
+
+				fields, err := pkg.loadFields(cfg.Fset, ts.Name.Name, st, nil)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if len(fields) > 0 {
+					pkg.structs = append(pkg.structs, structInfo{name: ts.Name.Name, fields: fields})
+				}
+			}
+		}
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(pkg.structs, func(s structInfo) bool { return s.name == name }) {
+			return nil, fmt.Errorf("type %s is not a struct with ensure tags", name)
+		}
+	}
+
+	if len(pkg.structs) == 0 {
+		return nil, fmt.Errorf("no structs with ensure tags in package %s", pkg.name)
+	}
+
+	return pkg, nil
+}
+
+// loadFields collects the fields with ensure tags in a struct, returning an error
+// with the field's position if a tag is invalid
+// Like ensure.FromTags, it includes exported structs without a tag that have fields with tags
+// The parents hold the names of the fields the struct is nested in
+func (pkg *packageInfo) loadFields(fset *token.FileSet, structName string, st *types.Struct, parents []string) ([]fieldInfo, error) {
+	var fields []fieldInfo
+
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup(tagKey)
+		fieldPath := append(slices.Clip(parents), field.Name())
+
+		if tag == "-" {
+			continue
+		}
+
+		if !ok {
+			nested, isStruct := field.Type().Underlying().(*types.Struct)
+
+			if !isStruct || !field.Exported() {
+				continue
+			}
+
+			nestedFields, err := pkg.loadFields(fset, structName, nested, fieldPath)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(nestedFields) > 0 {
+				fields = append(fields, fieldInfo{name: field.Name(), fields: nestedFields})
+			}
+
+			continue
+		}
+
+		pos := fset.Position(field.Pos())
+		pathStr := strings.Join(fieldPath, ".")
+
+		if !field.Exported() {
+			return nil, fmt.Errorf("%s: ensure tag on field %s: field is not exported", pos, pathStr)
+		}
+
+		typeStr := unnamedTypeName(field.Type())
+		check, ok := tagCheckers[typeStr]
+
+		if !ok {
+			return nil, fmt.Errorf("%s: ensure tag on field %s: type %s is not supported", pos, pathStr, types.TypeString(field.Type(), types.RelativeTo(pkg.types)))
+		}
+
+		if err := check(tag); err != nil {
+			return nil, fmt.Errorf("%s: ensure tag on field %s: %w", pos, pathStr, err)
+		}
+
+		fields = append(fields, fieldInfo{
+			name:    field.Name(),
+			typeStr: typeStr,
+			tag:     tag,
+			varName: pkg.uniqueName("ensure" + exportedName(structName) + strings.Join(fieldPath, "")),
+			convert: typeStr != types.TypeString(types.Unalias(field.Type()), nil),
+		})
+	}
+
+	return fields, nil
+}
+
+// uniqueName returns a package-level name based on name that hasn't been used yet
+// Names built from struct and field names can collide (eg struct AB with field C and
+// struct A with field BC), so later ones are numbered
+func (pkg *packageInfo) uniqueName(name string) string {
+	unique := name
+
+	for i := 2; pkg.used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	pkg.used[unique] = true
+	return unique
+}
+
+// unnamedTypeName returns the name of the type ensure.FromTags validates a field's value as
+// (eg string for `type Email string`, or *string for *Email)
+// The supported types are all built from basic types, so the name never needs a package qualifier
+// As with FromTags, slices and maps are converted but their elements aren't, so any named
+// element type gives a name that no checker is registered for
+func unnamedTypeName(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return "*" + unnamedTypeName(u.Elem())
+	case *types.Slice:
+		return "[]" + exactTypeName(u.Elem())
+	case *types.Map:
+		return "map[" + exactTypeName(u.Key()) + "]" + exactTypeName(u.Elem())
+	case *types.Basic:
+		return types.Typ[u.Kind()].Name()
+	default:
+		return types.TypeString(t, nil)
+	}
+}
+
+// exactTypeName returns the name of a type without converting named types
+// Aliases of basic types (eg byte) are given the name of the type they stand for
+func exactTypeName(t types.Type) string {
+	if basic, ok := types.Unalias(t).(*types.Basic); ok {
+		return types.Typ[basic.Kind()].Name()
+	}
+
+	return types.TypeString(t, nil)
+}
+
+// exportedName returns name with the first letter in upper case
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// funcName returns the name of the generated function for a struct, which is only exported if the struct is
+func funcName(structName string) string {
+	if ast.IsExported(structName) {
+		return "Validate" + structName
+	}
+
+	return "validate" + exportedName(structName)
+}
+
+// generate returns the formatted source of the file with a validation function for each struct
+func generate(pkg *packageInfo) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by ensuregen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.name)
+	fmt.Fprintf(&buf, "import (\n")
+
+	pkg.imports["github.com/chriscasto/go-ensure"] = pkg.ensureName
+	pkg.imports["github.com/chriscasto/go-ensure/with"] = pkg.withName
+	paths := make([]string, 0, len(pkg.imports))
+
+	for importPath := range pkg.imports {
+		paths = append(paths, importPath)
+	}
+
+	sort.Strings(paths)
+
+	// Packages are only named explicitly when the name differs from the last element of the
+	// path, except for this module's root package, which is always named ensure
+	for _, importPath := range paths {
+		if name := pkg.imports[importPath]; name != path.Base(importPath) && name != "ensure" {
+			fmt.Fprintf(&buf, "\t%s %s\n", name, strconv.Quote(importPath))
+		} else {
+			fmt.Fprintf(&buf, "\t%s\n", strconv.Quote(importPath))
+		}
+	}
+
+	fmt.Fprintf(&buf, ")\n\n")
+
+	// Validators are built once, from the same tags FromTags reads
+	fmt.Fprintf(&buf, "var (\n")
+
+	for _, s := range pkg.structs {
+		pkg.writeVars(&buf, s.fields)
+	}
+
+	fmt.Fprintf(&buf, ")\n")
+
+	for _, s := range pkg.structs {
+		fmt.Fprintf(&buf, "\n// %s validates a %s against the rules in its ensure tags\n", funcName(s.name), s.name)
+		fmt.Fprintf(&buf, "// It returns the same errors as ensure.FromTags[%s]().Validate without using reflection\n", s.name)
+		fmt.Fprintf(&buf, "func %s(s %s, options ...*%s.ValidationOptions) error {\n", funcName(s.name), s.name, pkg.withName)
+		fmt.Fprintf(&buf, "\trun := %s.NewStructRun(options...)\n\n", pkg.ensureName)
+		pkg.writeFields(&buf, s.fields, "s")
+		fmt.Fprintf(&buf, "\treturn run.Err()\n}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// writeVars writes the declarations of the validators for fields, including those of nested structs
+func (pkg *packageInfo) writeVars(buf *bytes.Buffer, fields []fieldInfo) {
+	for _, f := range fields {
+		if f.fields != nil {
+			pkg.writeVars(buf, f.fields)
+			continue
+		}
+
+		fmt.Fprintf(buf, "\t%s = %s.FromTag[%s](%s)\n", f.varName, pkg.ensureName, f.typeStr, strconv.Quote(f.tag))
+	}
+}
+
+// valueExpr returns the expression for the field's value in the struct in expr, converted
+// to the type it's validated as, so named types are validated without reflection
+func (f fieldInfo) valueExpr(expr string) string {
+	value := expr + "." + f.name
+
+	if !f.convert {
+		return value
+	}
+
+	// pointer types are parenthesized so the conversion isn't read as a dereference
+	if strings.HasPrefix(f.typeStr, "*") {
+		return "(" + f.typeStr + ")(" + value + ")"
+	}
+
+	return f.typeStr + "(" + value + ")"
+}
+
+// writeFields writes the code that validates each of the fields of the struct in expr
+// Nested structs are validated in a function literal with a run of their own, the same way
+// FromTags validates them, so their errors are returned together as the error of the field
+func (pkg *packageInfo) writeFields(buf *bytes.Buffer, fields []fieldInfo, expr string) {
+	for _, f := range fields {
+		fmt.Fprintf(buf, "if opts, ok := run.Field(%q); ok {\n", f.name)
+
+		if f.fields != nil {
+			fmt.Fprintf(buf, "err := func() error {\nrun := %s.NewStructRun(opts)\n\n", pkg.ensureName)
+			pkg.writeFields(buf, f.fields, expr+"."+f.name)
+			fmt.Fprintf(buf, "return run.Err()\n}()\n\n")
+			fmt.Fprintf(buf, "if run.Fail(err, %q, %q) {\n", f.name, f.name)
+		} else {
+			fmt.Fprintf(buf, "if run.Fail(%s.Validate(%s, opts), %q, %q) {\n", f.varName, f.valueExpr(expr), f.name, f.name)
+		}
+
+		fmt.Fprintf(buf, "return run.Err()\n}\n}\n\n")
+	}
+}
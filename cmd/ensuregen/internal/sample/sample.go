@@ -0,0 +1,42 @@
+// Package sample has structs with ensure tags, used to check that the functions generated
+// by ensuregen return the same errors as ensure.FromTags
+package sample
+
+import "time"
+
+//go:generate go run github.com/chriscasto/go-ensure/cmd/ensuregen
+
+type Email string
+
+type Labels []string
+
+type Code = string
+
+type SignupRequest struct {
+	Username string            `ensure:"required,len>=3,len<=64,match=alphanum"`
+	Email    Email             `ensure:"required,match=email"`
+	Backup   *Email            `ensure:"match=email"`
+	Age      int               `ensure:">=13,<150"`
+	Score    float64           `ensure:"positive"`
+	Agreed   bool              `ensure:"required"`
+	Nickname *string           `ensure:"len<=32"`
+	Manager  *string           `ensure:"required"`
+	Tags     Labels            `ensure:"unique,len<=3,each(len>=2)"`
+	Invite   Code              `ensure:"len=6"`
+	Meta     map[string]string `ensure:"keys(match=alpha),values(required)"`
+	Home     address
+	Created  time.Time
+	Internal string `ensure:"-"`
+	Untagged string
+}
+
+type address struct {
+	Street, City string `ensure:"required"`
+	Zip          string `json:"zip"`
+	Geo          location
+}
+
+type location struct {
+	Lat float64 `ensure:">=-90,<=90"`
+	Lng float64 `ensure:">=-180,<=180"`
+}
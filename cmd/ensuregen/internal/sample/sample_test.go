@@ -0,0 +1,96 @@
+package sample
+
+import (
+	"context"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestValidateSignupRequest(t *testing.T) {
+	validator := ensure.FromTags[SignupRequest]()
+
+	valid := SignupRequest{
+		Username: "gopher",
+		Email:    "gopher@example.com",
+		Age:      30,
+		Score:    1.5,
+		Agreed:   true,
+		Manager:  ptr("boss"),
+		Tags:     Labels{"go", "ensure"},
+		Invite:   "abc123",
+		Meta:     map[string]string{"team": "core"},
+		Home:     address{Street: "1 Main St", City: "Springfield", Geo: location{Lat: 45, Lng: -120}},
+	}
+
+	invalid := SignupRequest{
+		Username: "a!",
+		Email:    "nope",
+		Age:      7,
+		Score:    -1,
+		Backup:   ptr[Email]("nope"),
+		Nickname: ptr("this nickname is much too long to be accepted"),
+		Tags:     Labels{"go", "go", "x", "y"},
+		Invite:   "abc",
+		Meta:     map[string]string{"team1": ""},
+		Home:     address{Zip: "12345", Geo: location{Lat: 100, Lng: 200}},
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := map[string]struct {
+		value   SignupRequest
+		options []*with.ValidationOptions
+	}{
+		"valid":                 {value: valid},
+		"valid collect all":     {value: valid, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"invalid":               {value: invalid},
+		"invalid collect all":   {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"invalid empty":         {value: SignupRequest{}, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"field mask":            {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors(), with.OptionFieldMask("Age", "Tags"))}},
+		"field mask excluded":   {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Agreed"))}},
+		"field mask nested":     {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors(), with.OptionFieldMask("Home.City", "Home.Geo.Lng"))}},
+		"field mask first":      {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Home"))}},
+		"field mask nested geo": {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Home.Geo"))}},
+		"canceled context":      {value: invalid, options: []*with.ValidationOptions{with.Options(with.OptionContext(canceled))}},
+		"canceled valid value":  {value: valid, options: []*with.ValidationOptions{with.Options(with.OptionContext(canceled))}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected := validator.Validate(tc.value, tc.options...)
+			actual := ValidateSignupRequest(tc.value, tc.options...)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %#v; got %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	validator := ensure.FromTags[address]()
+
+	testCases := map[string]address{
+		"valid":       {Street: "1 Main St", City: "Springfield"},
+		"invalid":     {Zip: "12345"},
+		"invalid geo": {Street: "1 Main St", City: "Springfield", Geo: location{Lat: -91}},
+	}
+
+	for name, value := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected := validator.Validate(value)
+			actual := validateAddress(value)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %#v; got %#v", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Command ensuregen generates validation functions for structs with ensure tags
+// that read fields directly instead of through reflection
+//
+// Usage:
+//
+//	ensuregen [-type Name,...] [-output file] [dir]
+//
+// It is usually run with go generate, by adding a directive to one of the package's files:
+//
+//	//go:generate go run github.com/chriscasto/go-ensure/cmd/ensuregen
+//
+// For each struct with ensure tags, it writes a function such as ValidateSignupRequest
+// that returns the same errors as ensure.FromTags[SignupRequest]().Validate
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultOutput is the name of the file written to the package directory if -output isn't set
+const defaultOutput = "ensure_gen.go"
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "ensuregen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line arguments and writes the generated file
+func run(args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("ensuregen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	typeNames := flags.String("type", "", "comma-separated list of struct names; defaults to every struct with ensure tags")
+	output := flags.String("output", "", "output file name; defaults to <dir>/"+defaultOutput)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected at most one directory; got %d arguments", flags.NArg())
+	}
+
+	dir := "."
+
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+
+	outPath := *output
+
+	if outPath == "" {
+		outPath = filepath.Join(dir, defaultOutput)
+	}
+
+	var names []string
+
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	pkg, err := loadPackage(dir, filepath.Base(outPath), names)
+
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(pkg)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outPath, src, 0o644)
+}
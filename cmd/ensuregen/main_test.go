@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePackage writes a module with a single file to a temporary directory and returns the directory
+func writePackage(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/types\n\ngo 1.23\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestRun_Sample(t *testing.T) {
+	dir := filepath.Join("internal", "sample")
+	output := filepath.Join(t.TempDir(), defaultOutput)
+
+	if err := run([]string{"-output", output, dir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := os.ReadFile(output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("generated file does not match %s; run go generate ./...", filepath.Join(dir, defaultOutput))
+	}
+}
+
+func TestRun_DefaultOutput(t *testing.T) {
+	dir := writePackage(t, "package types\n\ntype user struct {\n\tName string `ensure:\"required\"`\n}\n")
+
+	// A previously generated file is ignored
+	if err := os.WriteFile(filepath.Join(dir, defaultOutput), []byte("package types\n\nvar x = \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{dir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"// Code generated by ensuregen; DO NOT EDIT.",
+		`ensureUserName = ensure.FromTag[string]("required")`,
+		"func validateUser(s user, options ...*with.ValidationOptions) error {",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated file to contain %q", expected)
+		}
+	}
+}
+
+func TestRun_Types(t *testing.T) {
+	dir := writePackage(t, "package types\n\n"+
+		"type First struct {\n\tName string `ensure:\"required\"`\n}\n\n"+
+		"type Second struct {\n\tName string `ensure:\"required\"`\n}\n")
+
+	if err := run([]string{"-type", "Second", dir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(src), "ValidateFirst") {
+		t.Errorf("expected First to be skipped")
+	}
+
+	if !strings.Contains(string(src), "ValidateSecond") {
+		t.Errorf("expected ValidateSecond to be generated")
+	}
+}
+
+func TestRun_Names(t *testing.T) {
+	dir := writePackage(t, "package types\n\n"+
+		"var ensureUserName = 1\n\n"+
+		"type user struct {\n\tName string `ensure:\"required\"`\n}\n\n"+
+		"type AB struct {\n\tC string `ensure:\"required\"`\n}\n\n"+
+		"type A struct {\n\tBC string `ensure:\"required\"`\n}\n")
+
+	if err := run([]string{dir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// names that are already taken, or were taken by an earlier field, are numbered
+	for _, expected := range []string{
+		`ensureUserName2 = ensure.FromTag[string]("required")`,
+		`ensureABC       = ensure.FromTag[string]("required")`,
+		`ensureABC2      = ensure.FromTag[string]("required")`,
+		"ensureUserName2.Validate(s.Name, opts)",
+		"ensureABC.Validate(s.C, opts)",
+		"ensureABC2.Validate(s.BC, opts)",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated file to contain %q", expected)
+		}
+	}
+}
+
+func TestRun_Imports(t *testing.T) {
+	dir := writePackage(t, "package types\n\n"+
+		"import (\n\t\"example.com/types/kinds\"\n\tother \"example.com/types/kinds/v2\"\n)\n\n"+
+		"var with = 1\n\n"+
+		"type T struct {\n"+
+		"\tEmail kinds.Email `ensure:\"match=email\"`\n"+
+		"\tCount *other.Count `ensure:\">0\"`\n"+
+		"\tNames other.Names `ensure:\"len<=2\"`\n"+
+		"}\n")
+
+	for pkgDir, src := range map[string]string{
+		"kinds":    "package kinds\n\ntype Email string\n",
+		"kinds/v2": "package kinds\n\ntype Count int\n\ntype Names = []string\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, pkgDir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, pkgDir, "kinds.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := run([]string{dir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected no error; got %s", err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, defaultOutput))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// packages are numbered if an identifier already uses their name, and named types are
+	// converted to the types they're validated as, so their packages aren't imported
+	for _, expected := range []string{
+		"\twith2 \"github.com/chriscasto/go-ensure/with\"\n",
+		"func ValidateT(s T, options ...*with2.ValidationOptions) error {",
+		`ensureTEmail = ensure.FromTag[string]("match=email")`,
+		`ensureTCount = ensure.FromTag[*int](">0")`,
+		`ensureTNames = ensure.FromTag[[]string]("len<=2")`,
+		"ensureTEmail.Validate(string(s.Email), opts)",
+		"ensureTCount.Validate((*int)(s.Count), opts)",
+		"ensureTNames.Validate(s.Names, opts)",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated file to contain %q", expected)
+		}
+	}
+
+	if strings.Contains(string(src), "example.com/types/kinds") {
+		t.Errorf("expected the packages of named types not to be imported")
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		src      string
+		expected string
+	}{
+		"unknown flag": {
+			args:     []string{"-unknown"},
+			expected: "flag provided but not defined: -unknown",
+		},
+		"too many arguments": {
+			args:     []string{"a", "b"},
+			expected: "expected at most one directory; got 2 arguments",
+		},
+		"missing directory": {
+			args:     []string{filepath.Join(t.TempDir(), "missing")},
+			expected: "no such file or directory",
+		},
+		"syntax error": {
+			src:      "package types\n\ntype T struct {\n",
+			expected: "expected",
+		},
+		"no structs": {
+			src:      "package types\n\ntype T struct {\n\tName string `json:\"name\"`\n\tSkip string `ensure:\"-\"`\n}\n\ntype G[V any] struct {\n\tValue V `ensure:\"required\"`\n}\n\nfunc F() {}\n\ntype N int\n",
+			expected: "no structs with ensure tags in package types",
+		},
+		"missing type": {
+			args:     []string{"-type", "Missing"},
+			src:      "package types\n\ntype T struct {\n\tName string `ensure:\"required\"`\n}\n",
+			expected: "type Missing is not a struct with ensure tags",
+		},
+		"unexported field": {
+			src:      "package types\n\ntype T struct {\n\tname string `ensure:\"required\"`\n}\n",
+			expected: "types.go:4:2: ensure tag on field name: field is not exported",
+		},
+		"unsupported type": {
+			src:      "package types\n\ntype T struct {\n\tValue []*string `ensure:\"required\"`\n}\n",
+			expected: "types.go:4:2: ensure tag on field Value: type []*string is not supported",
+		},
+		"embedded field": {
+			src:      "package types\n\nimport \"time\"\n\ntype T struct {\n\t*time.Time `ensure:\"required\"`\n}\n",
+			expected: "types.go:6:8: ensure tag on field Time: type *time.Time is not supported",
+		},
+		"embedded generic field": {
+			src:      "package types\n\ntype G[V any] struct{}\n\ntype T struct {\n\tG[int] `ensure:\"required\"`\n}\n",
+			expected: "types.go:6:2: ensure tag on field G: type G[int] is not supported",
+		},
+		"embedded generic field with type parameters": {
+			src:      "package types\n\ntype G[K, V any] struct{}\n\ntype T struct {\n\tG[int, string] `ensure:\"required\"`\n}\n",
+			expected: "types.go:6:2: ensure tag on field G: type G[int, string] is not supported",
+		},
+		"nested unexported field": {
+			src:      "package types\n\ntype T struct {\n\tInner inner\n}\n\ntype inner struct {\n\tname string `ensure:\"required\"`\n}\n",
+			expected: "types.go:8:2: ensure tag on field Inner.name: field is not exported",
+		},
+		"named element type": {
+			src:      "package types\n\ntype Email string\n\ntype T struct {\n\tValues []Email `ensure:\"len<=2\"`\n}\n",
+			expected: "types.go:6:2: ensure tag on field Values: type []Email is not supported",
+		},
+		"outside a module": {
+			args:     []string{t.TempDir()},
+			expected: "go.mod file not found",
+		},
+		"invalid tag": {
+			src:      "package types\n\ntype T struct {\n\tName string `ensure:\"len>=x\"`\n}\n",
+			expected: "types.go:4:2: ensure tag on field Name:",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			args := tc.args
+
+			if tc.src != "" {
+				args = append(args, writePackage(t, tc.src))
+			}
+
+			err := run(args, &bytes.Buffer{})
+
+			if err == nil {
+				t.Fatalf("expected error containing %q; got nil", tc.expected)
+			}
+
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error containing %q; got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestFuncName(t *testing.T) {
+	testCases := map[string]string{
+		"User":    "ValidateUser",
+		"user":    "validateUser",
+		"éclair":  "validateÉclair",
+		"address": "validateAddress",
+	}
+
+	for structName, expected := range testCases {
+		if actual := funcName(structName); actual != expected {
+			t.Errorf("expected %s; got %s", expected, actual)
+		}
+	}
+}
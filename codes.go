@@ -0,0 +1,86 @@
+package ensure
+
+// Error codes returned by ValidationError.Code() for the built-in checks
+// Codes are stable and can be relied on to identify a failure, unlike error
+// messages, which may change
+//
+// Any values used by a check are available from ValidationError.Params()
+// The value passed when the check was built is named "expected" (or "min" and
+// "max" for ranges), and the value that failed is named "actual"
+const (
+	CodeStringEquals           = "string.eq"
+	CodeStringNotEquals        = "string.ne"
+	CodeStringStartsWith       = "string.prefix"
+	CodeStringNotStartsWith    = "string.not_prefix"
+	CodeStringEndsWith         = "string.suffix"
+	CodeStringNotEndsWith      = "string.not_suffix"
+	CodeStringContains         = "string.contains"
+	CodeStringNotContains      = "string.not_contains"
+	CodeStringOneOf            = "string.one_of"
+	CodeStringNotOneOf         = "string.not_one_of"
+	CodeStringMatches          = "string.pattern"
+	CodeStringEmpty            = "string.empty"
+	CodeStringNotEmpty         = "string.not_empty"
+	CodeStringLengthEquals     = "string.length.eq"
+	CodeStringLengthGreater    = "string.length.gt"
+	CodeStringLengthLess       = "string.length.lt"
+	CodeStringDuration         = "string.duration"
+	CodeNumberEquals           = "number.eq"
+	CodeNumberNotEquals        = "number.ne"
+	CodeNumberLess             = "number.lt"
+	CodeNumberLessOrEqual      = "number.lte"
+	CodeNumberGreater          = "number.gt"
+	CodeNumberGreaterOrEqual   = "number.gte"
+	CodeNumberRange            = "number.range"
+	CodeNumberEven             = "number.even"
+	CodeNumberOdd              = "number.odd"
+	CodeNumberOneOf            = "number.one_of"
+	CodeNumberNotOneOf         = "number.not_one_of"
+	CodeBoolTrue               = "bool.true"
+	CodeBoolFalse              = "bool.false"
+	CodeArrayEmpty             = "array.empty"
+	CodeArrayNotEmpty          = "array.not_empty"
+	CodeArrayLengthEquals      = "array.length.eq"
+	CodeArrayLengthGreater     = "array.length.gt"
+	CodeArrayLengthLess        = "array.length.lt"
+	CodeArrayContains          = "array.contains"
+	CodeArrayNotContains       = "array.not_contains"
+	CodeArrayContainsOnly      = "array.contains_only"
+	CodeArrayUnique            = "array.unique"
+	CodeArrayContainsAny       = "array.contains_any"
+	CodeArrayNotContainsAny    = "array.not_contains_any"
+	CodeMapEmpty               = "map.empty"
+	CodeMapNotEmpty            = "map.not_empty"
+	CodeMapLengthEquals        = "map.length.eq"
+	CodeMapLengthGreater       = "map.length.gt"
+	CodeMapLengthLess          = "map.length.lt"
+	CodePointerRequired        = "pointer.required"
+	CodeAnyNone                = "any.none"
+	CodeTimeBefore             = "time.before"
+	CodeTimeAfter              = "time.after"
+	CodeTimeRange              = "time.range"
+	CodeTimePast               = "time.past"
+	CodeTimeFuture             = "time.future"
+	CodeTimeWithin             = "time.within"
+	CodeTimeWeekday            = "time.weekday"
+	CodeTimeLocation           = "time.location"
+	CodeDurationEquals         = "duration.eq"
+	CodeDurationNotEquals      = "duration.ne"
+	CodeDurationLess           = "duration.lt"
+	CodeDurationLessOrEqual    = "duration.lte"
+	CodeDurationGreater        = "duration.gt"
+	CodeDurationGreaterOrEqual = "duration.gte"
+	CodeDurationRange          = "duration.range"
+	CodeDurationMultipleOf     = "duration.multiple_of"
+	CodeStructFieldEquals      = "struct.field_eq"
+	CodeStructFieldLess        = "struct.field_lt"
+	CodeStructFieldRequired    = "struct.required_if"
+	CodeObjectRequiredKey      = "object.required"
+	CodeObjectAdditionalKey    = "object.additional"
+	CodeSchemaType             = "schema.type"
+	CodeSchemaEnum             = "schema.enum"
+	CodeSchemaConst            = "schema.const"
+	CodeSchemaNot              = "schema.not"
+	CodeSchemaMultipleOf       = "schema.multiple_of"
+	CodeSchemaContains         = "schema.contains"
+)
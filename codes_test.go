@@ -0,0 +1,176 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidationError_Codes(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	clock := with.Options(with.OptionClock(func() time.Time { return now }))
+	str := "abc"
+	nilStr := (*string)(nil)
+
+	testCases := map[string]struct {
+		err    error
+		code   string
+		params map[string]any
+	}{
+		"string equals":          {ensure.String().Equals("a").Validate(str), ensure.CodeStringEquals, map[string]any{"expected": "a", "actual": str}},
+		"string not equals":      {ensure.String().DoesNotEqual(str).Validate(str), ensure.CodeStringNotEquals, map[string]any{"expected": str, "actual": str}},
+		"string starts with":     {ensure.String().StartsWith("x").Validate(str), ensure.CodeStringStartsWith, map[string]any{"expected": "x", "actual": str}},
+		"string not starts with": {ensure.String().DoesNotStartWith("a").Validate(str), ensure.CodeStringNotStartsWith, map[string]any{"expected": "a", "actual": str}},
+		"string ends with":       {ensure.String().EndsWith("x").Validate(str), ensure.CodeStringEndsWith, map[string]any{"expected": "x", "actual": str}},
+		"string not ends with":   {ensure.String().DoesNotEndWith("c").Validate(str), ensure.CodeStringNotEndsWith, map[string]any{"expected": "c", "actual": str}},
+		"string contains":        {ensure.String().Contains("x").Validate(str), ensure.CodeStringContains, map[string]any{"expected": "x", "actual": str}},
+		"string not contains":    {ensure.String().DoesNotContain("b").Validate(str), ensure.CodeStringNotContains, map[string]any{"expected": "b", "actual": str}},
+		"string one of":          {ensure.String().IsOneOf([]string{"x"}).Validate(str), ensure.CodeStringOneOf, map[string]any{"expected": []string{"x"}, "actual": str}},
+		"string not one of":      {ensure.String().IsNotOneOf([]string{str}).Validate(str), ensure.CodeStringNotOneOf, map[string]any{"actual": str}},
+		"string matches":         {ensure.String().Matches(ensure.Numbers).Validate(str), ensure.CodeStringMatches, map[string]any{"actual": str}},
+		"string empty":           {ensure.String().IsEmpty().Validate(str), ensure.CodeStringEmpty, map[string]any{"actual": 3}},
+		"string not empty":       {ensure.String().IsNotEmpty().Validate(""), ensure.CodeStringNotEmpty, nil},
+		"string length":          {ensure.String().HasLength(2).Validate(str), ensure.CodeStringLengthEquals, map[string]any{"expected": 2, "actual": 3}},
+		"string longer":          {ensure.String().IsLongerThan(3).Validate(str), ensure.CodeStringLengthGreater, map[string]any{"expected": 3, "actual": 3}},
+		"string shorter":         {ensure.String().IsShorterThan(3).Validate(str), ensure.CodeStringLengthLess, map[string]any{"expected": 3, "actual": 3}},
+		"string duration":        {ensure.DurationString(ensure.Duration()).Validate(str), ensure.CodeStringDuration, map[string]any{"actual": str}},
+		"string length where":    {ensure.String().HasLengthWhere(ensure.Length().IsLessThan(2)).Validate(str), ensure.CodeNumberLess, map[string]any{"expected": 2, "actual": 3}},
+		"number equals":          {ensure.Number[int]().Equals(1).Validate(2), ensure.CodeNumberEquals, map[string]any{"expected": 1, "actual": 2}},
+		"number not equals":      {ensure.Number[int]().DoesNotEqual(2).Validate(2), ensure.CodeNumberNotEquals, map[string]any{"expected": 2, "actual": 2}},
+		"number less":            {ensure.Number[int]().IsLessThan(1).Validate(2), ensure.CodeNumberLess, map[string]any{"expected": 1, "actual": 2}},
+		"number less or equal":   {ensure.Number[int]().IsLessThanOrEqualTo(1).Validate(2), ensure.CodeNumberLessOrEqual, map[string]any{"expected": 1, "actual": 2}},
+		"number greater":         {ensure.Number[int]().IsGreaterThan(3).Validate(2), ensure.CodeNumberGreater, map[string]any{"expected": 3, "actual": 2}},
+		"number greater or equal": {
+			ensure.Number[int]().IsGreaterThanOrEqualTo(3).Validate(2), ensure.CodeNumberGreaterOrEqual, map[string]any{"expected": 3, "actual": 2},
+		},
+		"number range":       {ensure.Number[float64]().IsInRange(1, 2).Validate(3), ensure.CodeNumberRange, map[string]any{"min": 1.0, "max": 2.0, "actual": 3.0}},
+		"number even":        {ensure.Number[int]().IsEven().Validate(3), ensure.CodeNumberEven, map[string]any{"actual": 3}},
+		"number odd":         {ensure.Number[int]().IsOdd().Validate(2), ensure.CodeNumberOdd, map[string]any{"actual": 2}},
+		"number one of":      {ensure.Number[int]().IsOneOf([]int{1}).Validate(2), ensure.CodeNumberOneOf, map[string]any{"expected": []int{1}, "actual": 2}},
+		"number not one of":  {ensure.Number[int]().IsNotOneOf([]int{2}).Validate(2), ensure.CodeNumberNotOneOf, map[string]any{"actual": 2}},
+		"bool true":          {ensure.Bool().IsTrue().Validate(false), ensure.CodeBoolTrue, nil},
+		"bool false":         {ensure.Bool().IsFalse().Validate(true), ensure.CodeBoolFalse, nil},
+		"array empty":        {ensure.Array[int]().IsEmpty().Validate([]int{1}), ensure.CodeArrayEmpty, map[string]any{"actual": 1}},
+		"array not empty":    {ensure.Array[int]().IsNotEmpty().Validate([]int{}), ensure.CodeArrayNotEmpty, nil},
+		"array count":        {ensure.Array[int]().HasCount(2).Validate([]int{1}), ensure.CodeArrayLengthEquals, map[string]any{"expected": 2, "actual": 1}},
+		"array more than":    {ensure.Array[int]().HasMoreThan(1).Validate([]int{1}), ensure.CodeArrayLengthGreater, map[string]any{"expected": 1, "actual": 1}},
+		"array fewer than":   {ensure.Array[int]().HasFewerThan(1).Validate([]int{1}), ensure.CodeArrayLengthLess, map[string]any{"expected": 1, "actual": 1}},
+		"array contains":     {ensure.ComparableArray[int]().Contains(2).Validate([]int{1}), ensure.CodeArrayContains, map[string]any{"expected": 2}},
+		"array not contains": {ensure.ComparableArray[int]().DoesNotContain(1).Validate([]int{1}), ensure.CodeArrayNotContains, map[string]any{"expected": 1}},
+		"array only":         {ensure.ComparableArray[int]().ContainsOnly(2).Validate([]int{1}), ensure.CodeArrayContainsOnly, map[string]any{"actual": 1}},
+		"array unique":       {ensure.ComparableArray[int]().ContainsNoDuplicates().Validate([]int{1, 1}), ensure.CodeArrayUnique, map[string]any{"actual": 1}},
+		"array any of":       {ensure.ComparableArray[int]().ContainsAnyOf(2).Validate([]int{1}), ensure.CodeArrayContainsAny, nil},
+		"array none of":      {ensure.ComparableArray[int]().DoesNotContainAnyOf(1).Validate([]int{1}), ensure.CodeArrayNotContainsAny, nil},
+		"map empty":          {ensure.Map[string, int]().IsEmpty().Validate(map[string]int{"a": 1}), ensure.CodeMapEmpty, map[string]any{"actual": 1}},
+		"map not empty":      {ensure.Map[string, int]().IsNotEmpty().Validate(map[string]int{}), ensure.CodeMapNotEmpty, nil},
+		"map count":          {ensure.Map[string, int]().HasCount(2).Validate(map[string]int{}), ensure.CodeMapLengthEquals, map[string]any{"expected": 2, "actual": 0}},
+		"map more than":      {ensure.Map[string, int]().HasMoreThan(0).Validate(map[string]int{}), ensure.CodeMapLengthGreater, map[string]any{"expected": 0, "actual": 0}},
+		"map fewer than":     {ensure.Map[string, int]().HasFewerThan(0).Validate(map[string]int{}), ensure.CodeMapLengthLess, map[string]any{"expected": 0, "actual": 0}},
+		"struct field equals": {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password").Validate(fieldRuleForm{Password: "a"}), ensure.CodeStructFieldEquals,
+			map[string]any{"other": "Password"},
+		},
+		"struct field less": {
+			ensure.Struct[fieldRuleForm]().FieldLessThan("Min", "Max").Validate(fieldRuleForm{}), ensure.CodeStructFieldLess, map[string]any{"other": "Max"},
+		},
+		"struct field required": {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", "US").Validate(fieldRuleForm{Country: "US"}), ensure.CodeStructFieldRequired,
+			map[string]any{"other": "Country", "expected": "US"},
+		},
+		"object required": {
+			ensure.Object().RequiredKey("id", ensure.String()).Validate(map[string]any{}), ensure.CodeObjectRequiredKey, map[string]any{"key": "id"},
+		},
+		"object additional": {
+			ensure.Object().NoAdditionalKeys().Validate(map[string]any{"id": 1}), ensure.CodeObjectAdditionalKey, map[string]any{"key": "id"},
+		},
+		"schema type": {
+			mustFromJSONSchema(`{"type": ["string", "null"]}`).ValidateUntyped(1.0), ensure.CodeSchemaType, map[string]any{"expected": "string or null", "actual": "number"},
+		},
+		"schema enum": {
+			mustFromJSONSchema(`{"enum": ["a"]}`).ValidateUntyped("b"), ensure.CodeSchemaEnum, map[string]any{"expected": []any{"a"}},
+		},
+		"schema const":     {mustFromJSONSchema(`{"const": "a"}`).ValidateUntyped("b"), ensure.CodeSchemaConst, map[string]any{"expected": "a"}},
+		"schema not":       {mustFromJSONSchema(`{"not": {}}`).ValidateUntyped("b"), ensure.CodeSchemaNot, nil},
+		"pointer required": {ensure.Pointer[string](ensure.String()).Validate(nilStr), ensure.CodePointerRequired, nil},
+		"any none":         {ensure.Any[string](ensure.String().HasLength(1)).Validate(str), ensure.CodeAnyNone, nil},
+		"any none untyped": {ensure.Any[string](ensure.String().HasLength(1)).ValidateUntyped(str), ensure.CodeAnyNone, nil},
+		"time before":      {ensure.Time().IsBefore(now).Validate(now), ensure.CodeTimeBefore, map[string]any{"expected": now, "actual": now}},
+		"time after":       {ensure.Time().IsAfter(now).Validate(now), ensure.CodeTimeAfter, map[string]any{"expected": now, "actual": now}},
+		"time range":       {ensure.Time().IsBetween(now, now).Validate(now), ensure.CodeTimeRange, map[string]any{"min": now, "max": now, "actual": now}},
+		"time past":        {ensure.Time().IsInPast().Validate(now, clock), ensure.CodeTimePast, map[string]any{"actual": now}},
+		"time future":      {ensure.Time().IsInFuture().Validate(now, clock), ensure.CodeTimeFuture, map[string]any{"actual": now}},
+		"time within": {
+			ensure.Time().IsWithin(time.Hour).Validate(saturday, clock), ensure.CodeTimeWithin, map[string]any{"expected": time.Hour, "actual": saturday},
+		},
+		"time weekday": {ensure.Time().IsWeekday().Validate(saturday), ensure.CodeTimeWeekday, map[string]any{"actual": "Saturday"}},
+		"time location": {
+			ensure.Time().IsInLocation(time.Local).Validate(now), ensure.CodeTimeLocation, map[string]any{"expected": "Local", "actual": "UTC"},
+		},
+		"duration equals": {
+			ensure.Duration().Equals(time.Second).Validate(0), ensure.CodeDurationEquals, map[string]any{"expected": time.Second, "actual": time.Duration(0)},
+		},
+		"duration not equals": {
+			ensure.Duration().IsNotZero().Validate(0), ensure.CodeDurationNotEquals, map[string]any{"expected": time.Duration(0), "actual": time.Duration(0)},
+		},
+		"duration less": {
+			ensure.Duration().IsNegative().Validate(0), ensure.CodeDurationLess, map[string]any{"expected": time.Duration(0), "actual": time.Duration(0)},
+		},
+		"duration less or equal": {
+			ensure.Duration().IsLessThanOrEqualTo(0).Validate(1), ensure.CodeDurationLessOrEqual, map[string]any{"expected": time.Duration(0), "actual": time.Duration(1)},
+		},
+		"duration greater": {
+			ensure.Duration().IsPositive().Validate(0), ensure.CodeDurationGreater, map[string]any{"expected": time.Duration(0), "actual": time.Duration(0)},
+		},
+		"duration greater or equal": {
+			ensure.Duration().IsGreaterThanOrEqualTo(1).Validate(0), ensure.CodeDurationGreaterOrEqual, map[string]any{"expected": time.Duration(1), "actual": time.Duration(0)},
+		},
+		"duration range": {
+			ensure.Duration().IsInRange(1, 2).Validate(0), ensure.CodeDurationRange, map[string]any{"min": time.Duration(1), "max": time.Duration(2), "actual": time.Duration(0)},
+		},
+		"duration multiple of": {
+			ensure.Duration().IsMultipleOf(time.Second).Validate(1), ensure.CodeDurationMultipleOf, map[string]any{"expected": time.Second, "actual": time.Duration(1)},
+		},
+		"custom check": {ensure.String().Is(func(string) error { return errors.New("nope") }).Validate(str), ensure.CodeInvalid, nil},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			vErrs := ensure.NewValidationErrors()
+			vErrs.Append(tc.err)
+
+			if len(vErrs.ValidationErrors()) != 1 {
+				t.Fatalf(`expected a single validation error; got "%v"`, tc.err)
+			}
+
+			vErr := vErrs.ValidationErrors()[0]
+
+			if vErr.Code() != tc.code {
+				t.Errorf(`expected code "%s"; got "%s"`, tc.code, vErr.Code())
+			}
+
+			if !reflect.DeepEqual(vErr.Params(), tc.params) {
+				t.Errorf("expected params %v; got %v", tc.params, vErr.Params())
+			}
+		})
+	}
+}
+
+func TestValidationError_Is(t *testing.T) {
+	err := ensure.String().HasLength(2).Validate("abc")
+
+	if errors.Is(err, ensure.NewValidationError("length must equal 2; got 3")) {
+		t.Errorf("expected errors without codes not to match")
+	}
+
+	if !errors.Is(ensure.Pointer[string](ensure.String()).Validate(nil), ensure.RequiredPointerMissingErr) {
+		t.Errorf("expected error to match RequiredPointerMissingErr")
+	}
+
+	if errors.Is(err, ensure.RequiredPointerMissingErr) {
+		t.Errorf("expected errors with different codes not to match")
+	}
+}
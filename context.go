@@ -0,0 +1,37 @@
+package ensure
+
+import (
+	"context"
+	"github.com/chriscasto/go-ensure/with"
+)
+
+// CanceledError is returned when validation stops early because its context
+// was canceled or its deadline passed.  It is never collected into
+// ValidationErrors, since it says nothing about whether the value is valid
+type CanceledError struct {
+	cause error
+}
+
+// Error is the implementation of the "error" interface
+func (e *CanceledError) Error() string {
+	return "validation stopped: " + e.cause.Error()
+}
+
+// Unwrap returns the context's error, so errors.Is can match context.Canceled
+// or context.DeadlineExceeded
+func (e *CanceledError) Unwrap() error {
+	return e.cause
+}
+
+// contextError returns a CanceledError if the context in opts is done
+func contextError(opts *with.ValidationOptions) error {
+	if err := opts.Context().Err(); err != nil {
+		return &CanceledError{cause: err}
+	}
+	return nil
+}
+
+// contextOptions returns a copy of the passed options (or the defaults) that uses ctx
+func contextOptions(ctx context.Context, options []*with.ValidationOptions) *with.ValidationOptions {
+	return withOption(getValidationOptions(options), with.OptionContext(ctx))
+}
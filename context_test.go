@@ -0,0 +1,330 @@
+package ensure_test
+
+import (
+	"context"
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+	"time"
+)
+
+// usernameStore is an in-memory stand-in for a database lookup that honors its context
+type usernameStore struct {
+	taken   map[string]bool
+	latency time.Duration
+	lookups int
+}
+
+func (s *usernameStore) exists(ctx context.Context, name string) (bool, error) {
+	s.lookups++
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(s.latency):
+		return s.taken[name], nil
+	}
+}
+
+// isAvailable is a check for use with IsCtx that fails if the username is already taken
+func (s *usernameStore) isAvailable(ctx context.Context, name string) error {
+	taken, err := s.exists(ctx, name)
+
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return errors.New("username is already taken")
+	}
+
+	return nil
+}
+
+// cancelingCheck returns a check that cancels its context and fails
+func cancelingCheck[T any](cancel context.CancelFunc) func(context.Context, T) error {
+	return func(_ context.Context, _ T) error {
+		cancel()
+		return errors.New("failed")
+	}
+}
+
+// expectCanceled fails the test if err is not a CanceledError wrapping target
+func expectCanceled(t *testing.T, err error, target error) {
+	t.Helper()
+
+	cErr := &ensure.CanceledError{}
+
+	if !errors.As(err, &cErr) {
+		t.Fatalf(`expected a canceled error; got "%v"`, err)
+	}
+
+	if !errors.Is(err, target) {
+		t.Errorf(`expected error to match "%v"; got "%v"`, target, err)
+	}
+
+	if ensure.ErrorAsValidationErrors(err) != nil {
+		t.Errorf(`expected canceled error to not be collected with validation errors; got "%v"`, err)
+	}
+}
+
+func TestIsCtx(t *testing.T) {
+	store := &usernameStore{taken: map[string]bool{"admin": true}}
+	v := ensure.String().IsNotEmpty().IsCtx(store.isAvailable)
+
+	if err := v.ValidateContext(context.Background(), "guest"); err != nil {
+		t.Errorf(`expected no error; got "%v"`, err)
+	}
+
+	if err := v.ValidateContext(context.Background(), "admin"); err == nil || err.Error() != "username is already taken" {
+		t.Errorf(`expected username to be taken; got "%v"`, err)
+	}
+
+	// Validate uses the background context
+	if err := v.Validate("admin"); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestIsCtx_Context(t *testing.T) {
+	type ctxKey struct{}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	now := time.Now()
+
+	testCases := map[string]func(func(context.Context) error) error{
+		"string": func(fn func(context.Context) error) error {
+			return ensure.String().IsCtx(func(ctx context.Context, _ string) error { return fn(ctx) }).ValidateContext(ctx, "")
+		},
+		"number": func(fn func(context.Context) error) error {
+			return ensure.Number[int]().IsCtx(func(ctx context.Context, _ int) error { return fn(ctx) }).ValidateContext(ctx, 0)
+		},
+		"array": func(fn func(context.Context) error) error {
+			return ensure.Array[int]().IsCtx(func(ctx context.Context, _ []int) error { return fn(ctx) }).ValidateContext(ctx, nil)
+		},
+		"map": func(fn func(context.Context) error) error {
+			return ensure.Map[string, int]().IsCtx(func(ctx context.Context, _ map[string]int) error { return fn(ctx) }).ValidateContext(ctx, nil)
+		},
+		"struct": func(fn func(context.Context) error) error {
+			return ensure.Struct[pathPet]().IsCtx(func(ctx context.Context, _ pathPet) error { return fn(ctx) }).ValidateContext(ctx, pathPet{})
+		},
+		"time": func(fn func(context.Context) error) error {
+			return ensure.Time().IsCtx(func(ctx context.Context, _ time.Time) error { return fn(ctx) }).ValidateContext(ctx, now)
+		},
+		"duration": func(fn func(context.Context) error) error {
+			return ensure.Duration().IsCtx(func(ctx context.Context, _ time.Duration) error { return fn(ctx) }).ValidateContext(ctx, 0)
+		},
+		"duration string": func(fn func(context.Context) error) error {
+			parent := ensure.Duration().IsCtx(func(ctx context.Context, _ time.Duration) error { return fn(ctx) })
+			return ensure.DurationString(parent).ValidateContext(ctx, "1s")
+		},
+		"pointer": func(fn func(context.Context) error) error {
+			str := ""
+			parent := ensure.String().IsCtx(func(ctx context.Context, _ string) error { return fn(ctx) })
+			return ensure.Pointer[string](parent).ValidateContext(ctx, &str)
+		},
+		"any": func(fn func(context.Context) error) error {
+			parent := ensure.String().IsCtx(func(ctx context.Context, _ string) error { return fn(ctx) })
+			return ensure.Any[string](parent).ValidateContext(ctx, "")
+		},
+	}
+
+	for name, validate := range testCases {
+		t.Run(name, func(t *testing.T) {
+			called := false
+
+			err := validate(func(got context.Context) error {
+				called = true
+
+				if got.Value(ctxKey{}) != "value" {
+					t.Errorf("expected check to receive the context passed to ValidateContext")
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				t.Errorf(`expected no error; got "%v"`, err)
+			}
+
+			if !called {
+				t.Errorf("expected check to be called")
+			}
+		})
+	}
+
+	if err := ensure.Bool().IsTrue().ValidateContext(ctx, false); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestValidateContext_Canceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, opts := range map[string]*with.ValidationOptions{
+		"fail fast":   with.Options(),
+		"collect all": with.Options(with.OptionCollectAllErrors()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			v := ensure.String().Is(func(string) error {
+				called = true
+				return nil
+			})
+
+			expectCanceled(t, v.ValidateContext(canceled, "", opts), context.Canceled)
+
+			if called {
+				t.Errorf("expected no checks to be called once the context is done")
+			}
+
+			// options passed to ValidateContext are not modified
+			if opts.Context() != context.Background() {
+				t.Errorf("expected options to be unchanged")
+			}
+		})
+	}
+}
+
+func TestValidateContext_StopsEarly(t *testing.T) {
+	for name, opts := range map[string]*with.ValidationOptions{
+		"fail fast":   with.Options(),
+		"collect all": with.Options(with.OptionCollectAllErrors()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			testCases := map[string]func(context.Context, context.CancelFunc) error{
+				"string": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.String().IsNotEmpty().IsCtx(cancelingCheck[string](cancel)).
+						ValidateContext(ctx, "a", opts)
+				},
+				"message": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.String().IsCtx(cancelingCheck[string](cancel)).WithMessage("custom").
+						ValidateContext(ctx, "", opts)
+				},
+				"array values": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Array[string]().Each(ensure.String().IsCtx(cancelingCheck[string](cancel))).
+						ValidateContext(ctx, []string{"a", "b"}, opts)
+				},
+				"map keys": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Map[string, int]().EachKey(ensure.String().IsCtx(cancelingCheck[string](cancel))).
+						ValidateContext(ctx, map[string]int{"a": 1}, opts)
+				},
+				"struct checks": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Struct[pathPet]().IsCtx(cancelingCheck[pathPet](cancel)).
+						ValidateContext(ctx, pathPet{}, opts)
+				},
+				"struct fields": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Struct[pathPerson]().HasFields(with.Validators{
+						"Name": ensure.String().IsCtx(cancelingCheck[string](cancel)),
+					}).ValidateContext(ctx, pathPerson{}, opts)
+				},
+				"struct getters": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Struct[pathPerson]().HasGetters(with.Validators{
+						"GetName": ensure.String().IsCtx(cancelingCheck[string](cancel)),
+					}).ValidateContext(ctx, pathPerson{}, opts)
+				},
+				"any": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Any[string](ensure.String().IsCtx(cancelingCheck[string](cancel))).
+						ValidateContext(ctx, "", opts)
+				},
+				"any untyped": func(ctx context.Context, cancel context.CancelFunc) error {
+					return ensure.Any[string](ensure.String().IsCtx(cancelingCheck[string](cancel))).
+						ValidateUntyped("", with.Options(with.OptionContext(ctx)))
+				},
+			}
+
+			for name, validate := range testCases {
+				t.Run(name, func(t *testing.T) {
+					ctx, cancel := context.WithCancel(context.Background())
+					defer cancel()
+
+					expectCanceled(t, validate(ctx, cancel), context.Canceled)
+				})
+			}
+		})
+	}
+}
+
+func TestValidateContext_SkipsRemaining(t *testing.T) {
+	for name, opts := range map[string]*with.ValidationOptions{
+		"fail fast":   with.Options(),
+		"collect all": with.Options(with.OptionCollectAllErrors()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			count := 0
+
+			item := ensure.String().IsCtx(func(_ context.Context, str string) error {
+				count++
+
+				if str == "b" {
+					cancel()
+				}
+
+				return nil
+			})
+
+			err := ensure.Array[string]().Each(item).ValidateContext(ctx, []string{"a", "b", "c", "d"}, opts)
+
+			expectCanceled(t, err, context.Canceled)
+
+			if count != 2 {
+				t.Errorf("expected checks to stop after the context was canceled; %d were called", count)
+			}
+
+			// struct fields and getters are skipped as well
+			called := false
+			mark := ensure.String().Is(func(string) error {
+				called = true
+				return nil
+			})
+
+			sv := ensure.Struct[pathPerson]().HasFields(with.Validators{"Name": mark})
+
+			expectCanceled(t, sv.ValidateContext(ctx, pathPerson{}, opts), context.Canceled)
+
+			// a field validator that cancels without failing still stops the getters
+			fieldCtx, fieldCancel := context.WithCancel(context.Background())
+			defer fieldCancel()
+
+			sv = ensure.Struct[pathPerson]().HasFields(with.Validators{
+				"Name": ensure.String().IsCtx(func(context.Context, string) error {
+					fieldCancel()
+					return nil
+				}),
+			}).HasGetters(with.Validators{"GetName": mark})
+
+			expectCanceled(t, sv.ValidateContext(fieldCtx, pathPerson{}, opts), context.Canceled)
+
+			if called {
+				t.Errorf("expected no field or getter validators to be called once the context is done")
+			}
+		})
+	}
+}
+
+func TestValidateContext_Deadline(t *testing.T) {
+	store := &usernameStore{latency: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := ensure.Array[string]().Each(ensure.String().IsCtx(store.isAvailable)).
+		ValidateContext(ctx, []string{"a", "b", "c"}, with.Options(with.OptionCollectAllErrors()))
+
+	expectCanceled(t, err, context.DeadlineExceeded)
+
+	if store.lookups != 1 {
+		t.Errorf("expected lookups to stop after the deadline passed; got %d", store.lookups)
+	}
+
+	expect := "validation stopped: " + context.DeadlineExceeded.Error()
+
+	if err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%s"`, expect, err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package ensure
+
+import (
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
+
+// structDefault contains a value to assign to a field that has its zero value
+type structDefault struct {
+	name  string
+	index []int
+	value reflect.Value
+}
+
+// defaulter is implemented by validators that can apply defaults to a struct a field points to
+type defaulter interface {
+	// applyDefaultsRef applies defaults to the value that ref points to in place
+	applyDefaultsRef(ref any, opts *with.ValidationOptions)
+}
+
+// Default sets a value to assign to the named field when it has its zero value
+// The value must have the same type as the field (eg Default("PageSize", 20) for an int field)
+// Slices, maps, and pointers are assigned as-is, so every struct they're applied to shares them
+func (sv *StructValidator[T]) Default(name string, value any) *StructValidator[T] {
+	refType := sv.refVal.Type()
+	field, ok := refType.FieldByName(name)
+
+	if !ok {
+		panic(fmt.Sprintf("field %s does not exist in struct %s", name, refType.String()))
+	}
+
+	if !field.IsExported() {
+		panic(fmt.Sprintf("field %s is not exported in struct %s", name, refType.String()))
+	}
+
+	if value == nil || reflect.TypeOf(value) != field.Type {
+		panic(fmt.Sprintf("field %s is type [%s] but default is [%T]", name, field.Type.String(), value))
+	}
+
+	if _, ok := sv.defaultFor(name); ok {
+		panic(fmt.Sprintf("field %s already has a default", name))
+	}
+
+	sv.defaults = append(sv.defaults, &structDefault{
+		name:  name,
+		index: field.Index,
+		value: reflect.ValueOf(value),
+	})
+
+	sv.setLastGroups = nil
+	sv.setLastMasked = nil
+	return sv
+}
+
+// defaultFor returns the default for the named field, if it has one
+func (sv *StructValidator[T]) defaultFor(name string) (*structDefault, bool) {
+	for _, d := range sv.defaults {
+		if d.name == name {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+// ApplyDefaults assigns the defaults set with Default to the fields of the struct that have
+// their zero value, including the fields of nested structs validated by a StructValidator
+// It does nothing if s is nil
+func (sv *StructValidator[T]) ApplyDefaults(s *T) {
+	if s != nil {
+		sv.applyDefaults(s, defaultOptions)
+	}
+}
+
+// Prepare normalizes the struct and applies its defaults, as ValidateAndNormalize and
+// ApplyDefaults do, then applies all checks against it and returns an error if any fail
+// Fields outside the field mask are left unchanged
+func (sv *StructValidator[T]) Prepare(s *T, options ...*with.ValidationOptions) error {
+	if s == nil {
+		return NewTypeError("struct pointer expected")
+	}
+
+	opts := getValidationOptions(options)
+	sv.normalizeStruct(s, opts)
+	sv.applyDefaults(s, opts)
+	return sv.validate(*s, opts)
+}
+
+// applyDefaults assigns defaults to the zero-valued fields in the field mask, then applies
+// the defaults of nested structs, so a default for a whole struct can be filled in further
+func (sv *StructValidator[T]) applyDefaults(s *T, opts *with.ValidationOptions) {
+	ptr := reflect.ValueOf(s)
+
+	for _, d := range sv.defaults {
+		if _, inMask := fieldMaskOptions(d.name, opts); !inMask {
+			continue
+		}
+
+		if field := ptr.Elem().FieldByIndex(d.index); field.IsZero() {
+			field.Set(d.value)
+		}
+	}
+
+	for _, field := range sv.fields {
+		dv, ok := field.validator.(defaulter)
+
+		if !ok {
+			continue
+		}
+
+		fieldOpts, inMask := fieldMaskOptions(field.name, opts)
+
+		if !inMask {
+			continue
+		}
+
+		switch {
+		case field.pointer != nil:
+			dv.applyDefaultsRef(field.pointer.(func(*T) any)(s), fieldOpts)
+		case field.check == nil:
+			dv.applyDefaultsRef(ptr.Elem().FieldByIndex(field.index).Addr().Interface(), fieldOpts)
+		}
+	}
+}
+
+// applyDefaultsRef applies defaults to the struct that a field of another struct points to
+func (sv *StructValidator[T]) applyDefaultsRef(ref any, opts *with.ValidationOptions) {
+	sv.applyDefaults(ref.(*T), opts)
+}
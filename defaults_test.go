@@ -0,0 +1,162 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+)
+
+type defaultsPaging struct {
+	Size  int
+	Order string
+}
+
+type defaultsQuery struct {
+	Search   string `json:"search"`
+	PageSize int    `json:"pageSize"`
+	Sort     string `json:"sort"`
+	Paging   defaultsPaging
+	Next     *defaultsPaging
+	Prev     *defaultsPaging
+	paging   defaultsPaging
+}
+
+var defaultsPagingValidator = ensure.Struct[defaultsPaging]().HasFields(with.Validators{
+	"Size": ensure.Number[int]().IsGreaterThan(0),
+}).Default("Size", 10).Default("Order", "asc")
+
+func defaultsQueryValidator() *ensure.StructValidator[defaultsQuery] {
+	sv := ensure.Struct[defaultsQuery]().HasFields(with.Validators{
+		"Search":   ensure.String().Trim(),
+		"PageSize": ensure.Number[int]().IsLessThanOrEqualTo(100),
+		"Sort":     ensure.String().Trim().IsOneOf([]string{"name", "date"}),
+		"Paging":   defaultsPagingValidator,
+		"Next":     ensure.OptionalPointer[defaultsPaging](defaultsPagingValidator),
+		"Prev":     ensure.OptionalPointer[defaultsPaging](defaultsPagingValidator),
+	}).Default("PageSize", 20).Default("Sort", "name")
+
+	return ensure.FieldAt(sv, func(q *defaultsQuery) *defaultsPaging { return &q.paging }, defaultsPagingValidator)
+}
+
+func TestStructValidator_ApplyDefaults(t *testing.T) {
+	sv := defaultsQueryValidator()
+
+	value := defaultsQuery{
+		Search: "gophers",
+		Sort:   "date",
+		Paging: defaultsPaging{Size: 5},
+		Next:   &defaultsPaging{},
+	}
+
+	sv.ApplyDefaults(&value)
+
+	expected := defaultsQuery{
+		Search:   "gophers",
+		PageSize: 20,
+		Sort:     "date",
+		Paging:   defaultsPaging{Size: 5, Order: "asc"},
+		Next:     &defaultsPaging{Size: 10, Order: "asc"},
+		paging:   defaultsPaging{Size: 10, Order: "asc"},
+	}
+
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected %+v; got %+v", expected, value)
+	}
+
+	// a nil struct is ignored
+	sv.ApplyDefaults(nil)
+}
+
+func TestStructValidator_Prepare(t *testing.T) {
+	sv := defaultsQueryValidator()
+
+	// values are normalized before defaults are applied, so blank strings get defaults too
+	value := defaultsQuery{Search: " gophers ", Sort: "  "}
+
+	if err := sv.Prepare(&value); err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	if value.Search != "gophers" || value.Sort != "name" || value.PageSize != 20 || value.Paging.Size != 10 {
+		t.Errorf("expected the struct to be normalized and defaulted; got %+v", value)
+	}
+
+	// fields outside the field mask are left unchanged
+	masked := defaultsQuery{Sort: " date "}
+
+	if err := sv.Prepare(&masked, with.Options(with.OptionFieldMask("Sort", "Paging.Size"))); err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	expected := defaultsQuery{Sort: "date", Paging: defaultsPaging{Size: 10}}
+
+	if !reflect.DeepEqual(masked, expected) {
+		t.Errorf("expected %+v; got %+v", expected, masked)
+	}
+
+	invalid := defaultsQuery{PageSize: 500, Sort: "size"}
+	paths := validationErrorPaths(t, sv.Prepare(&invalid, with.Options(with.OptionCollectAllErrors())))
+
+	if _, ok := paths["/PageSize"]; !ok || len(paths) != 2 {
+		t.Errorf("expected errors for PageSize and Sort; got %v", paths)
+	}
+
+	var typeErr *ensure.TypeError
+
+	if err := sv.Prepare(nil); !errors.As(err, &typeErr) {
+		t.Errorf("expected a type error for a nil struct pointer; got %v", err)
+	}
+}
+
+func TestStructValidator_Default_JSONSchema(t *testing.T) {
+	sv := ensure.Struct[defaultsQuery]().HasFields(with.Validators{
+		"PageSize": ensure.Number[int](),
+		"Next":     ensure.Pointer[defaultsPaging](ensure.Struct[defaultsPaging]()),
+	}).Default("PageSize", 20).Default("Next", &defaultsPaging{Size: 10})
+
+	// fields with defaults aren't required
+	expectSchema(t, sv, `{
+		"type": "object",
+		"properties": {
+			"pageSize": {"type": "integer", "default": 20},
+			"Next": {"type": "object", "default": {"Size": 10, "Order": ""}}
+		}
+	}`)
+}
+
+func TestStructValidator_Default_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"missing field": func() {
+			ensure.Struct[defaultsQuery]().Default("Limit", 20)
+		},
+		"unexported field": func() {
+			ensure.Struct[defaultsQuery]().Default("paging", defaultsPaging{})
+		},
+		"wrong type": func() {
+			ensure.Struct[defaultsQuery]().Default("PageSize", int64(20))
+		},
+		"nil": func() {
+			ensure.Struct[defaultsQuery]().Default("Next", nil)
+		},
+		"duplicate": func() {
+			ensure.Struct[defaultsQuery]().Default("PageSize", 20).Default("PageSize", 30)
+		},
+		"in groups": func() {
+			ensure.Struct[defaultsQuery]().Default("PageSize", 20).InGroups("admin")
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package ensure
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefinitionError is returned when a definition can't be turned into a validator
+type DefinitionError struct {
+	path Path
+	msg  string
+}
+
+// Error returns the problem along with its location in the definition (eg "definition at each.hasLength: ...")
+func (e *DefinitionError) Error() string {
+	if len(e.path) == 0 {
+		return "definition: " + e.msg
+	}
+
+	return fmt.Sprintf("definition at %s: %s", e.path, e.msg)
+}
+
+// Path returns the location of the key or value in the definition that caused the error
+func (e *DefinitionError) Path() Path {
+	return e.path
+}
+
+// defErr returns a DefinitionError for the value at the provided path
+func defErr(path Path, format string, args ...any) *DefinitionError {
+	return &DefinitionError{path: path, msg: fmt.Sprintf(format, args...)}
+}
+
+// defKey returns the path to a key in the definition at path
+func defKey(path Path, key string) Path {
+	return append(slices.Clip(path), keySegment(key))
+}
+
+// defIndex returns the path to an item in the list at path
+func defIndex(path Path, idx int) Path {
+	return append(slices.Clip(path), indexSegment(idx))
+}
+
+// defRule applies a key in a definition to a validator of type V
+type defRule[V any] struct {
+	key   string
+	apply func(v V, value any, path Path) error
+}
+
+// defFlag returns a rule for a key that is either true or false, such as "isNotEmpty"
+func defFlag[V any, R any](key string, fn func(V) R) defRule[V] {
+	return defRule[V]{key, func(v V, value any, path Path) error {
+		if value != true {
+			return defErr(path, "must be true or false")
+		}
+		fn(v)
+		return nil
+	}}
+}
+
+// defArg returns a rule for a key with a single argument, parsed with parse
+func defArg[V any, A any, R any](key string, parse func(any, Path) (A, error), fn func(V, A) R) defRule[V] {
+	return defRule[V]{key, func(v V, value any, path Path) error {
+		arg, err := parse(value, path)
+		if err != nil {
+			return err
+		}
+		fn(v, arg)
+		return nil
+	}}
+}
+
+// defType describes how to build validators for values of a type T, along with slices and maps of T
+type defType struct {
+	build func(def map[string]any, path Path) (with.UntypedValidator, error)
+	slice func(def map[string]any, path Path) (with.UntypedValidator, error)
+	mapOf func(def map[string]any, path Path) (with.UntypedValidator, error)
+}
+
+// newDefType creates the set of constructors FromDefinition uses for values of type T
+// build creates a validator from a definition, and parse converts a value in the definition (eg for "contains") to a T
+func newDefType[T comparable, V with.Validator[T]](build func(map[string]any, Path) (V, error), parse func(any, Path) (T, error)) *defType {
+	return &defType{
+		build: func(def map[string]any, path Path) (with.UntypedValidator, error) {
+			v, err := build(def, path)
+			if err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+		slice: func(def map[string]any, path Path) (with.UntypedValidator, error) {
+			return arrayFromDef(def, path, build, parse)
+		},
+		mapOf: func(def map[string]any, path Path) (with.UntypedValidator, error) {
+			return mapFromDef(def, path, build)
+		},
+	}
+}
+
+// defTypes lists the types FromDefinition knows how to build validators for, keyed by type name
+var defTypes = map[string]*defType{
+	"string":  newDefType(stringFromDef, defString),
+	"bool":    newDefType(boolFromDef, defBool),
+	"int":     newDefType(numberFromDef[int], defNumber[int]),
+	"int8":    newDefType(numberFromDef[int8], defNumber[int8]),
+	"int16":   newDefType(numberFromDef[int16], defNumber[int16]),
+	"int32":   newDefType(numberFromDef[int32], defNumber[int32]),
+	"int64":   newDefType(numberFromDef[int64], defNumber[int64]),
+	"uint":    newDefType(numberFromDef[uint], defNumber[uint]),
+	"uint8":   newDefType(numberFromDef[uint8], defNumber[uint8]),
+	"uint16":  newDefType(numberFromDef[uint16], defNumber[uint16]),
+	"uint32":  newDefType(numberFromDef[uint32], defNumber[uint32]),
+	"uint64":  newDefType(numberFromDef[uint64], defNumber[uint64]),
+	"float32": newDefType(numberFromDef[float32], defNumber[float32]),
+	"float64": newDefType(numberFromDef[float64], defNumber[float64]),
+}
+
+// FromDefinition builds a validator from a JSON definition, such as
+// {"type": "string", "hasLength": 8, "doesNotContain": "@"}
+// The definition's keys are the names of the validator's methods, starting with a lowercase letter
+// An error is returned if the definition can't be decoded; any other problem
+// is returned as a *DefinitionError with the location of the offending key
+func FromDefinition(r io.Reader) (with.UntypedValidator, error) {
+	var def any
+
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	if err := d.Decode(&def); err != nil {
+		return nil, fmt.Errorf("could not decode definition: %w", err)
+	}
+
+	return FromDefinitionValue(def)
+}
+
+// FromDefinitionValue builds a validator from a definition that has already been decoded,
+// such as one read from a YAML file, with the same rules as FromDefinition
+func FromDefinitionValue(def any) (with.UntypedValidator, error) {
+	obj, err := defObject(def, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := defTypeName(obj, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// types are written the same way Type() reports them (eg "[]string" or "map[string]int")
+	elemName, isSlice := strings.CutPrefix(name, "[]")
+	if !isSlice {
+		elemName, _ = strings.CutPrefix(name, "map[string]")
+	}
+
+	dt, ok := defTypes[elemName]
+	if !ok {
+		return nil, defErr(defKey(nil, "type"), `type "%s" is not supported`, name)
+	}
+
+	switch {
+	case isSlice:
+		return dt.slice(obj, nil)
+	case elemName != name:
+		return dt.mapOf(obj, nil)
+	default:
+		return dt.build(obj, nil)
+	}
+}
+
+// defTypeName returns the type set by the "type" key in a definition
+// Nested definitions (eg for "each") have a type implied by their parent, in which case the key is optional
+func defTypeName(def map[string]any, path Path, implied string) (string, error) {
+	value, ok := def["type"]
+
+	if !ok {
+		if implied == "" {
+			return "", defErr(path, `"type" is required`)
+		}
+		return implied, nil
+	}
+
+	name, ok := value.(string)
+
+	if !ok {
+		return "", defErr(defKey(path, "type"), "must be a string")
+	}
+
+	if implied != "" && name != implied {
+		return "", defErr(defKey(path, "type"), `must be "%s"`, implied)
+	}
+
+	return name, nil
+}
+
+// nestedDef builds a validator from a definition nested in another one, such as the value of "each"
+// The type of the nested validator is implied by its parent
+func nestedDef[V any](value any, path Path, typeName string, build func(map[string]any, Path) (V, error)) (V, error) {
+	var zero V
+
+	def, err := defObject(value, path)
+	if err != nil {
+		return zero, err
+	}
+
+	if _, err := defTypeName(def, path, typeName); err != nil {
+		return zero, err
+	}
+
+	return build(def, path)
+}
+
+// applyDefRules applies the rules for each key set in a definition, in the order the rules are listed
+// Unknown keys are an error, so a misspelled rule can't silently go unchecked
+// Keys set to false are skipped, and messages are set with setMessage right after their rule is applied
+func applyDefRules[V with.UntypedValidator](v V, def map[string]any, path Path, rules []defRule[V], setMessage func(V, string)) error {
+	known := map[string]bool{"type": true, "messages": true}
+
+	for _, r := range rules {
+		known[r.key] = true
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(def)) {
+		if !known[key] {
+			return defErr(defKey(path, key), `unknown key "%s" for type %s`, key, v.Type())
+		}
+	}
+
+	messages := map[string]string{}
+
+	if value, ok := def["messages"]; ok {
+		msgPath := defKey(path, "messages")
+
+		obj, err := defObject(value, msgPath)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			keyPath := defKey(msgPath, key)
+
+			if set, ok := def[key]; !ok || key == "type" || key == "messages" || set == false {
+				return defErr(keyPath, `there is no rule "%s" to set the message for`, key)
+			}
+
+			if messages[key], err = defString(obj[key], keyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, r := range rules {
+		value, ok := def[r.key]
+
+		if !ok || value == false {
+			continue
+		}
+
+		if err := applyDefRule(v, r, value, defKey(path, r.key)); err != nil {
+			return err
+		}
+
+		if msg, ok := messages[r.key]; ok {
+			setMessage(v, msg)
+		}
+	}
+
+	return nil
+}
+
+// applyDefRule applies a single rule, converting any panic from the validator's
+// method (eg IsInRange with max less than min) into a DefinitionError
+func applyDefRule[V any](v V, r defRule[V], value any, path Path) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = defErr(path, "%v", rec)
+		}
+	}()
+
+	return r.apply(v, value, path)
+}
+
+// stringFromDef builds a StringValidator from a definition
+func stringFromDef(def map[string]any, path Path) (*StringValidator, error) {
+	v := String()
+
+	rules := []defRule[*StringValidator]{
+		defArg("equals", defString, (*StringValidator).Equals),
+		defArg("doesNotEqual", defString, (*StringValidator).DoesNotEqual),
+		defArg("startsWith", defString, (*StringValidator).StartsWith),
+		defArg("doesNotStartWith", defString, (*StringValidator).DoesNotStartWith),
+		defArg("endsWith", defString, (*StringValidator).EndsWith),
+		defArg("doesNotEndWith", defString, (*StringValidator).DoesNotEndWith),
+		defArg("contains", defString, (*StringValidator).Contains),
+		defArg("doesNotContain", defString, (*StringValidator).DoesNotContain),
+		defFlag("isEmpty", (*StringValidator).IsEmpty),
+		defFlag("isNotEmpty", (*StringValidator).IsNotEmpty),
+		defArg("isOneOf", defStrings, (*StringValidator).IsOneOf),
+		defArg("isNotOneOf", defStrings, (*StringValidator).IsNotOneOf),
+		defArg("isLongerThan", defLength, (*StringValidator).IsLongerThan),
+		defArg("isShorterThan", defLength, (*StringValidator).IsShorterThan),
+		defArg("hasLength", defLength, (*StringValidator).HasLength),
+		defArg("hasLengthWhere", defLengthValidator, (*StringValidator).HasLengthWhere),
+		defArg("matches", defPattern, (*StringValidator).Matches),
+	}
+
+	if err := applyDefRules(v, def, path, rules, func(v *StringValidator, msg string) { v.WithMessage(msg) }); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// numberFromDef builds a NumberValidator of type T from a definition
+func numberFromDef[T NumberType](def map[string]any, path Path) (*NumberValidator[T], error) {
+	v := Number[T]()
+
+	rules := []defRule[*NumberValidator[T]]{
+		defArg("isInRange", defRange[T], func(v *NumberValidator[T], r [2]T) *NumberValidator[T] { return v.IsInRange(r[0], r[1]) }),
+		defArg("equals", defNumber[T], (*NumberValidator[T]).Equals),
+		defArg("doesNotEqual", defNumber[T], (*NumberValidator[T]).DoesNotEqual),
+		defArg("isLessThan", defNumber[T], (*NumberValidator[T]).IsLessThan),
+		defArg("isLessThanOrEqualTo", defNumber[T], (*NumberValidator[T]).IsLessThanOrEqualTo),
+		defArg("isGreaterThan", defNumber[T], (*NumberValidator[T]).IsGreaterThan),
+		defArg("isGreaterThanOrEqualTo", defNumber[T], (*NumberValidator[T]).IsGreaterThanOrEqualTo),
+		defFlag("isEven", (*NumberValidator[T]).IsEven),
+		defFlag("isOdd", (*NumberValidator[T]).IsOdd),
+		defFlag("isPositive", (*NumberValidator[T]).IsPositive),
+		defFlag("isNegative", (*NumberValidator[T]).IsNegative),
+		defFlag("isZero", (*NumberValidator[T]).IsZero),
+		defFlag("isNotZero", (*NumberValidator[T]).IsNotZero),
+		defArg("isOneOf", defValues(defNumber[T]), (*NumberValidator[T]).IsOneOf),
+		defArg("isNotOneOf", defValues(defNumber[T]), (*NumberValidator[T]).IsNotOneOf),
+	}
+
+	if err := applyDefRules(v, def, path, rules, func(v *NumberValidator[T], msg string) { v.WithMessage(msg) }); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// boolFromDef builds a BooleanValidator from a definition
+func boolFromDef(def map[string]any, path Path) (*BooleanValidator, error) {
+	v := Bool()
+
+	rules := []defRule[*BooleanValidator]{
+		defFlag("isTrue", (*BooleanValidator).IsTrue),
+		defFlag("isFalse", (*BooleanValidator).IsFalse),
+	}
+
+	if err := applyDefRules(v, def, path, rules, func(v *BooleanValidator, msg string) { v.WithMessage(msg) }); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// arrayFromDef builds a ComparableArrayValidator of type T from a definition, using build for the definition in "each"
+func arrayFromDef[T comparable, V with.Validator[T]](def map[string]any, path Path, build func(map[string]any, Path) (V, error), parse func(any, Path) (T, error)) (*ComparableArrayValidator[T], error) {
+	v := ComparableArray[T]()
+
+	rules := []defRule[*ComparableArrayValidator[T]]{
+		defArg("hasLengthWhere", defLengthValidator, (*ComparableArrayValidator[T]).HasLengthWhere),
+		defFlag("isEmpty", (*ComparableArrayValidator[T]).IsEmpty),
+		defFlag("isNotEmpty", (*ComparableArrayValidator[T]).IsNotEmpty),
+		defArg("hasCount", defLength, (*ComparableArrayValidator[T]).HasCount),
+		defArg("hasMoreThan", defLength, (*ComparableArrayValidator[T]).HasMoreThan),
+		defArg("hasFewerThan", defLength, (*ComparableArrayValidator[T]).HasFewerThan),
+		defArg("contains", parse, (*ComparableArrayValidator[T]).Contains),
+		defArg("doesNotContain", parse, (*ComparableArrayValidator[T]).DoesNotContain),
+		defArg("containsOnly", defValues(parse), func(v *ComparableArrayValidator[T], items []T) *ComparableArrayValidator[T] {
+			return v.ContainsOnly(items...)
+		}),
+		defFlag("containsNoDuplicates", (*ComparableArrayValidator[T]).ContainsNoDuplicates),
+		defArg("containsAnyOf", defValues(parse), func(v *ComparableArrayValidator[T], items []T) *ComparableArrayValidator[T] {
+			return v.ContainsAnyOf(items...)
+		}),
+		defArg("doesNotContainAnyOf", defValues(parse), func(v *ComparableArrayValidator[T], items []T) *ComparableArrayValidator[T] {
+			return v.DoesNotContainAnyOf(items...)
+		}),
+		{"each", func(v *ComparableArrayValidator[T], value any, path Path) error {
+			ev, err := nestedDef(value, path, reflect.TypeFor[T]().String(), build)
+			if err != nil {
+				return err
+			}
+			v.Each(ev)
+			return nil
+		}},
+	}
+
+	if err := applyDefRules(v, def, path, rules, func(v *ComparableArrayValidator[T], msg string) { v.WithMessage(msg) }); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// mapFromDef builds a MapValidator with string keys and values of type T from a definition,
+// using build for the definition in "eachValue"
+func mapFromDef[T comparable, V with.Validator[T]](def map[string]any, path Path, build func(map[string]any, Path) (V, error)) (*MapValidator[string, T], error) {
+	v := Map[string, T]()
+
+	rules := []defRule[*MapValidator[string, T]]{
+		defArg("hasLengthWhere", defLengthValidator, (*MapValidator[string, T]).HasLengthWhere),
+		{"eachKey", func(v *MapValidator[string, T], value any, path Path) error {
+			kv, err := nestedDef(value, path, "string", stringFromDef)
+			if err != nil {
+				return err
+			}
+			v.EachKey(kv)
+			return nil
+		}},
+		{"eachValue", func(v *MapValidator[string, T], value any, path Path) error {
+			vv, err := nestedDef(value, path, reflect.TypeFor[T]().String(), build)
+			if err != nil {
+				return err
+			}
+			v.EachValue(vv)
+			return nil
+		}},
+		defFlag("isEmpty", (*MapValidator[string, T]).IsEmpty),
+		defFlag("isNotEmpty", (*MapValidator[string, T]).IsNotEmpty),
+		defArg("hasCount", defLength, (*MapValidator[string, T]).HasCount),
+		defArg("hasMoreThan", defLength, (*MapValidator[string, T]).HasMoreThan),
+		defArg("hasFewerThan", defLength, (*MapValidator[string, T]).HasFewerThan),
+	}
+
+	if err := applyDefRules(v, def, path, rules, func(v *MapValidator[string, T], msg string) { v.WithMessage(msg) }); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// defObject converts a value in a definition to an object
+// Objects decoded from YAML may have keys of type any, which are accepted as long as they are strings
+func defObject(value any, path Path) (map[string]any, error) {
+	switch obj := value.(type) {
+	case map[string]any:
+		return obj, nil
+	case map[any]any:
+		converted := make(map[string]any, len(obj))
+
+		for key, val := range obj {
+			str, ok := key.(string)
+			if !ok {
+				return nil, defErr(path, "must be an object with string keys")
+			}
+			converted[str] = val
+		}
+
+		return converted, nil
+	default:
+		return nil, defErr(path, "must be an object")
+	}
+}
+
+// defString converts a value in a definition to a string
+func defString(value any, path Path) (string, error) {
+	str, ok := value.(string)
+
+	if !ok {
+		return "", defErr(path, "must be a string")
+	}
+
+	return str, nil
+}
+
+// defBool converts a value in a definition to a bool
+func defBool(value any, path Path) (bool, error) {
+	b, ok := value.(bool)
+
+	if !ok {
+		return false, defErr(path, "must be true or false")
+	}
+
+	return b, nil
+}
+
+// defNumber converts a value in a definition to a number of type T
+// Numbers may be decoded as json.Number, or as any int, uint, or float type (eg from YAML)
+func defNumber[T NumberType](value any, path Path) (T, error) {
+	var arg string
+
+	if num, ok := value.(json.Number); ok {
+		arg = num.String()
+	} else {
+		ref := reflect.ValueOf(value)
+
+		switch ref.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			arg = strconv.FormatInt(ref.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			arg = strconv.FormatUint(ref.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			arg = strconv.FormatFloat(ref.Float(), 'f', -1, ref.Type().Bits())
+		default:
+			return 0, defErr(path, "must be a number")
+		}
+	}
+
+	n, ok := parseNumber[T](arg)
+
+	if !ok {
+		return n, defErr(path, "must be a value of type %s", reflect.TypeFor[T]())
+	}
+
+	return n, nil
+}
+
+// defLength converts a value in a definition to a length, which can't be negative
+func defLength(value any, path Path) (int, error) {
+	l, err := defNumber[int](value, path)
+
+	if err != nil || l < 0 {
+		return 0, defErr(path, "must be a non-negative integer")
+	}
+
+	return l, nil
+}
+
+// defRange converts a list of two numbers in a definition to a min and max
+func defRange[T NumberType](value any, path Path) ([2]T, error) {
+	values, err := defValues(defNumber[T])(value, path)
+
+	if err != nil {
+		return [2]T{}, err
+	}
+
+	if len(values) != 2 {
+		return [2]T{}, defErr(path, "must be a list of two numbers: min and max")
+	}
+
+	return [2]T{values[0], values[1]}, nil
+}
+
+// defValues returns a function that converts a list in a definition to a slice, using parse for each item
+func defValues[T any](parse func(any, Path) (T, error)) func(any, Path) ([]T, error) {
+	return func(value any, path Path) ([]T, error) {
+		list, ok := value.([]any)
+
+		if !ok {
+			return nil, defErr(path, "must be a list")
+		}
+
+		values := make([]T, 0, len(list))
+
+		for i, item := range list {
+			v, err := parse(item, defIndex(path, i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+
+		return values, nil
+	}
+}
+
+// defStrings converts a list in a definition to a slice of strings
+func defStrings(value any, path Path) ([]string, error) {
+	return defValues(defString)(value, path)
+}
+
+// defLengthValidator builds the validator for a length from a definition nested in "hasLengthWhere"
+func defLengthValidator(value any, path Path) (*NumberValidator[int], error) {
+	return nestedDef(value, path, "int", numberFromDef[int])
+}
+
+// defPattern converts a value in a definition to a regular expression
+// A predefined pattern can be used by writing its name after an "@" (eg "@email" or "@Email")
+func defPattern(value any, path Path) (string, error) {
+	pattern, err := defString(value, path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if name, ok := strings.CutPrefix(pattern, "@"); ok {
+		named, ok := namedPatterns[strings.ToLower(name)]
+
+		if !ok {
+			return "", defErr(path, `pattern "%s" is not a predefined pattern`, name)
+		}
+
+		return named, nil
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", defErr(path, "invalid pattern: %s", err)
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,282 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mustFromDefinition loads a validator from a JSON definition, panicking if it is invalid
+func mustFromDefinition(def string) with.UntypedValidator {
+	v, err := ensure.FromDefinition(strings.NewReader(def))
+
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return v
+}
+
+func TestFromDefinition(t *testing.T) {
+	testCases := map[string]struct {
+		def   string
+		value any
+		valid bool
+	}{
+		"string":                 {`{"type": "string", "hasLength": 8, "doesNotContain": "@"}`, "abcdefgh", true},
+		"string with @":          {`{"type": "string", "hasLength": 8, "doesNotContain": "@"}`, "abc@efgh", false},
+		"string too short":       {`{"type": "string", "hasLength": 8, "doesNotContain": "@"}`, "abc", false},
+		"named pattern":          {`{"type": "string", "matches": "@Email"}`, "a@b.co", true},
+		"named pattern mismatch": {`{"type": "string", "matches": "@email"}`, "a@", false},
+		"name as pattern":        {`{"type": "string", "matches": "email"}`, "my email", true},
+		"name as pattern miss":   {`{"type": "string", "matches": "email"}`, "a@b.co", false},
+		"escaped at":             {`{"type": "string", "matches": "^\\@"}`, "@home", true},
+		"pattern":                {`{"type": "string", "matches": "^[a-z]+$"}`, "abc", true},
+		"pattern mismatch":       {`{"type": "string", "matches": "^[a-z]+$"}`, "ABC", false},
+		"length where":           {`{"type": "string", "hasLengthWhere": {"isInRange": [2, 4]}}`, "abcd", false},
+		"flag off":               {`{"type": "string", "isNotEmpty": false}`, "", true},
+		"flag on":                {`{"type": "string", "isNotEmpty": true}`, "", false},
+		"int":                    {`{"type": "int", "isGreaterThan": 1, "isOdd": true}`, 3, true},
+		"int even":               {`{"type": "int", "isGreaterThan": 1, "isOdd": true}`, 4, false},
+		"uint":                   {`{"type": "uint8", "isOneOf": [1, 2]}`, uint8(3), false},
+		"float":                  {`{"type": "float64", "isLessThanOrEqualTo": 1.5}`, 1.5, true},
+		"wrong type":             {`{"type": "float64"}`, 1, false},
+		"bool":                   {`{"type": "bool", "isTrue": true}`, false, false},
+		"array":                  {`{"type": "[]string", "isNotEmpty": true, "each": {"isNotEmpty": true}}`, []string{"a", "b"}, true},
+		"array empty item":       {`{"type": "[]string", "isNotEmpty": true, "each": {"isNotEmpty": true}}`, []string{"a", ""}, false},
+		"array contains":         {`{"type": "[]int", "containsOnly": [1, 2], "containsNoDuplicates": true}`, []int{1, 2, 2}, false},
+		"map":                    {`{"type": "map[string]int", "eachKey": {"startsWith": "x"}, "eachValue": {"isPositive": true}}`, map[string]int{"x1": 1}, true},
+		"map bad key":            {`{"type": "map[string]int", "eachKey": {"startsWith": "x"}, "eachValue": {"isPositive": true}}`, map[string]int{"y1": 1}, false},
+		"map bad value":          {`{"type": "map[string]int", "eachKey": {"startsWith": "x"}, "eachValue": {"isPositive": true}}`, map[string]int{"x1": -1}, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := mustFromDefinition(tc.def).ValidateUntyped(tc.value)
+
+			if tc.valid && err != nil {
+				t.Errorf(`expected no error; got "%s"`, err)
+			} else if !tc.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestFromDefinition_Rules(t *testing.T) {
+	// definitions should build the same validators as the equivalent chains, with the checks in the same order
+	testCases := map[string]struct {
+		def    string
+		expect describer
+	}{
+		"string": {
+			`{
+				"type": "string", "equals": "a", "doesNotEqual": "b", "startsWith": "a", "doesNotStartWith": "b",
+				"endsWith": "a", "doesNotEndWith": "b", "contains": "a", "doesNotContain": "b", "isEmpty": true,
+				"isNotEmpty": true, "isOneOf": ["a"], "isNotOneOf": ["b"], "isLongerThan": 0, "isShorterThan": 2,
+				"hasLength": 1, "hasLengthWhere": {"type": "int", "isOdd": true}, "matches": "@Alpha"
+			}`,
+			ensure.String().Equals("a").DoesNotEqual("b").StartsWith("a").DoesNotStartWith("b").
+				EndsWith("a").DoesNotEndWith("b").Contains("a").DoesNotContain("b").IsEmpty().
+				IsNotEmpty().IsOneOf([]string{"a"}).IsNotOneOf([]string{"b"}).IsLongerThan(0).IsShorterThan(2).
+				HasLength(1).HasLengthWhere(ensure.Length().IsOdd()).Matches(ensure.Alpha),
+		},
+		"number": {
+			`{
+				"type": "int", "isInRange": [1, 10], "equals": 2, "doesNotEqual": 3, "isLessThan": 9,
+				"isLessThanOrEqualTo": 8, "isGreaterThan": 1, "isGreaterThanOrEqualTo": 2, "isEven": true,
+				"isOdd": true, "isPositive": true, "isNegative": true, "isZero": true, "isNotZero": true,
+				"isOneOf": [2, 4], "isNotOneOf": [6]
+			}`,
+			ensure.Number[int]().IsInRange(1, 10).Equals(2).DoesNotEqual(3).IsLessThan(9).
+				IsLessThanOrEqualTo(8).IsGreaterThan(1).IsGreaterThanOrEqualTo(2).IsEven().
+				IsOdd().IsPositive().IsNegative().IsZero().IsNotZero().
+				IsOneOf([]int{2, 4}).IsNotOneOf([]int{6}),
+		},
+		"bool": {
+			`{"type": "bool", "isTrue": true, "isFalse": true}`,
+			ensure.Bool().IsTrue().IsFalse(),
+		},
+		"array": {
+			`{
+				"type": "[]string", "hasLengthWhere": {"isEven": true}, "isEmpty": true, "isNotEmpty": true,
+				"hasCount": 2, "hasMoreThan": 1, "hasFewerThan": 3, "contains": "a", "doesNotContain": "b",
+				"containsOnly": ["a", "c"], "containsNoDuplicates": true, "containsAnyOf": ["a"],
+				"doesNotContainAnyOf": ["b"], "each": {"type": "string", "hasLength": 1}
+			}`,
+			func() describer {
+				v := ensure.ComparableArray[string]()
+				v.HasLengthWhere(ensure.Length().IsEven()).IsEmpty().IsNotEmpty().HasCount(2).HasMoreThan(1).HasFewerThan(3)
+				v.Contains("a").DoesNotContain("b").ContainsOnly("a", "c").ContainsNoDuplicates().
+					ContainsAnyOf("a").DoesNotContainAnyOf("b").Each(ensure.String().HasLength(1))
+				return v
+			}(),
+		},
+		"map": {
+			`{
+				"type": "map[string]float64", "hasLengthWhere": {"isOdd": true}, "eachKey": {"type": "string", "isNotEmpty": true},
+				"eachValue": {"isPositive": true}, "isEmpty": true, "isNotEmpty": true, "hasCount": 1,
+				"hasMoreThan": 0, "hasFewerThan": 2
+			}`,
+			ensure.Map[string, float64]().HasLengthWhere(ensure.Length().IsOdd()).EachKey(ensure.String().IsNotEmpty()).
+				EachValue(ensure.Number[float64]().IsPositive()).IsEmpty().IsNotEmpty().HasCount(1).
+				HasMoreThan(0).HasFewerThan(2),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := mustFromDefinition(tc.def).(describer).Describe()
+
+			if expect := tc.expect.Describe(); !reflect.DeepEqual(got, expect) {
+				t.Errorf("expected %+v; got %+v", expect, got)
+			}
+		})
+	}
+}
+
+func TestFromDefinition_Messages(t *testing.T) {
+	str := `{
+		"type": "string",
+		"isNotEmpty": true,
+		"hasLength": 8,
+		"messages": {"hasLength": "must be exactly {expected} characters"}
+	}`
+
+	testCases := map[string]struct {
+		def    string
+		value  any
+		expect string
+	}{
+		"string":      {str, "abc", "must be exactly 8 characters"},
+		"not applied": {str, "", "must not be empty"},
+		"number":      {`{"type": "int", "isOdd": true, "messages": {"isOdd": "must be odd"}}`, 2, "must be odd"},
+		"bool":        {`{"type": "bool", "isTrue": true, "messages": {"isTrue": "must be accepted"}}`, false, "must be accepted"},
+		"array":       {`{"type": "[]bool", "contains": true, "messages": {"contains": "must have a yes"}}`, []bool{false}, "must have a yes"},
+		"map":         {`{"type": "map[string]bool", "isNotEmpty": true, "messages": {"isNotEmpty": "must have entries"}}`, map[string]bool{}, "must have entries"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := mustFromDefinition(tc.def).ValidateUntyped(tc.value)
+
+			if err == nil || err.Error() != tc.expect {
+				t.Errorf(`expected error "%s"; got "%v"`, tc.expect, err)
+			}
+		})
+	}
+}
+
+func TestFromDefinitionValue(t *testing.T) {
+	// YAML decoders produce maps with keys of type any, and numbers of any int or float type
+	v, err := ensure.FromDefinitionValue(map[any]any{
+		"type":        "[]float32",
+		"hasMoreThan": uint(1),
+		"contains":    float32(0.5),
+		"each": map[string]any{
+			"isGreaterThan": int64(0),
+			"isLessThan":    1,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	if err := v.ValidateUntyped([]float32{0.5, 0.75}); err != nil {
+		t.Errorf(`expected no error; got "%s"`, err)
+	}
+
+	if err := v.ValidateUntyped([]float32{0.5, 1.5}); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestFromDefinition_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		def    string
+		expect string
+	}{
+		"not an object":         {`"string"`, "definition: must be an object"},
+		"no type":               {`{"hasLength": 8}`, `definition: "type" is required`},
+		"type not a string":     {`{"type": 1}`, "definition at type: must be a string"},
+		"unsupported type":      {`{"type": "complex64"}`, `definition at type: type "complex64" is not supported`},
+		"unsupported slice":     {`{"type": "[][]string"}`, `definition at type: type "[][]string" is not supported`},
+		"unknown key":           {`{"type": "string", "hasLenght": 8}`, `definition at hasLenght: unknown key "hasLenght" for type string`},
+		"not a flag":            {`{"type": "string", "isEmpty": "yes"}`, "definition at isEmpty: must be true or false"},
+		"not a string":          {`{"type": "string", "contains": 1}`, "definition at contains: must be a string"},
+		"not a length":          {`{"type": "string", "hasLength": -1}`, "definition at hasLength: must be a non-negative integer"},
+		"fractional length":     {`{"type": "string", "hasLength": 1.5}`, "definition at hasLength: must be a non-negative integer"},
+		"not a list":            {`{"type": "string", "isOneOf": "a"}`, "definition at isOneOf: must be a list"},
+		"bad list item":         {`{"type": "string", "isOneOf": ["a", 2]}`, "definition at isOneOf.1: must be a string"},
+		"pattern not a string":  {`{"type": "string", "matches": 1}`, "definition at matches: must be a string"},
+		"unknown named pattern": {`{"type": "string", "matches": "@emial"}`, `definition at matches: pattern "emial" is not a predefined pattern`},
+		"invalid pattern":       {`{"type": "string", "matches": "("}`, "definition at matches: invalid pattern: error parsing regexp: missing closing ): `(`"},
+		"not a number":          {`{"type": "int", "equals": "1"}`, "definition at equals: must be a number"},
+		"out of range":          {`{"type": "uint8", "equals": 256}`, "definition at equals: must be a value of type uint8"},
+		"negative unsigned":     {`{"type": "uint", "isOneOf": [1, -1]}`, "definition at isOneOf.1: must be a value of type uint"},
+		"bad range":             {`{"type": "int", "isInRange": [1]}`, "definition at isInRange: must be a list of two numbers: min and max"},
+		"bad range item":        {`{"type": "int", "isInRange": [1, "a"]}`, "definition at isInRange.1: must be a number"},
+		"range panic":           {`{"type": "int", "isInRange": [2, 1]}`, "definition at isInRange: max cannot be less than min"},
+		"not a bool":            {`{"type": "[]bool", "contains": 1}`, "definition at contains: must be true or false"},
+		"nested error":          {`{"type": "[]string", "each": {"hasLength": "a"}}`, "definition at each.hasLength: must be a non-negative integer"},
+		"nested not object":     {`{"type": "[]string", "each": "a"}`, "definition at each: must be an object"},
+		"nested wrong type":     {`{"type": "[]string", "each": {"type": "int"}}`, `definition at each.type: must be "string"`},
+		"nested type string":    {`{"type": "[]string", "each": {"type": 1}}`, "definition at each.type: must be a string"},
+		"length where":          {`{"type": "string", "hasLengthWhere": {"type": "uint"}}`, `definition at hasLengthWhere.type: must be "int"`},
+		"map key error":         {`{"type": "map[string]int", "eachKey": {"hasLength": true}}`, "definition at eachKey.hasLength: must be a non-negative integer"},
+		"map value error":       {`{"type": "map[string]int", "eachValue": {"isOdd": 1}}`, "definition at eachValue.isOdd: must be true or false"},
+		"bool error":            {`{"type": "bool", "isTrue": 1}`, "definition at isTrue: must be true or false"},
+		"messages object":       {`{"type": "bool", "messages": []}`, "definition at messages: must be an object"},
+		"message no rule":       {`{"type": "bool", "messages": {"isTrue": "x"}}`, `definition at messages.isTrue: there is no rule "isTrue" to set the message for`},
+		"message flag off":      {`{"type": "bool", "isTrue": false, "messages": {"isTrue": "x"}}`, `definition at messages.isTrue: there is no rule "isTrue" to set the message for`},
+		"message for type":      {`{"type": "bool", "messages": {"type": "x"}}`, `definition at messages.type: there is no rule "type" to set the message for`},
+		"message string":        {`{"type": "bool", "isTrue": true, "messages": {"isTrue": 1}}`, "definition at messages.isTrue: must be a string"},
+		"array message":         {`{"type": "[]int", "each": {"isOdd": true, "messages": {"isEven": "x"}}}`, `definition at each.messages.isEven: there is no rule "isEven" to set the message for`},
+		"map message":           {`{"type": "map[string]int", "isEmpty": true, "messages": {"isEmpty": 1}}`, "definition at messages.isEmpty: must be a string"},
+		"number message":        {`{"type": "int", "isOdd": true, "messages": {"isOdd": 1}}`, "definition at messages.isOdd: must be a string"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ensure.FromDefinition(strings.NewReader(tc.def))
+
+			var defErr *ensure.DefinitionError
+
+			if !errors.As(err, &defErr) {
+				t.Fatalf("expected a DefinitionError; got %v", err)
+			}
+
+			if err.Error() != tc.expect {
+				t.Errorf(`expected error "%s"; got "%s"`, tc.expect, err)
+			}
+		})
+	}
+}
+
+func TestFromDefinition_ErrorPath(t *testing.T) {
+	_, err := ensure.FromDefinition(strings.NewReader(`{"type": "[]string", "each": {"isOneOf": ["a", 1]}}`))
+
+	var defErr *ensure.DefinitionError
+
+	if !errors.As(err, &defErr) {
+		t.Fatalf("expected a DefinitionError; got %v", err)
+	}
+
+	if got := defErr.Path().JSONPointer(); got != "/each/isOneOf/1" {
+		t.Errorf(`expected path "/each/isOneOf/1"; got "%s"`, got)
+	}
+}
+
+func TestFromDefinition_Decode(t *testing.T) {
+	if _, err := ensure.FromDefinition(strings.NewReader(`{"type":`)); err == nil {
+		t.Errorf("expected an error")
+	}
+
+	if _, err := ensure.FromDefinitionValue(map[any]any{1: "string"}); err == nil || err.Error() != "definition: must be an object with string keys" {
+		t.Errorf(`expected an error for a key that isn't a string; got "%v"`, err)
+	}
+}
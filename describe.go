@@ -0,0 +1,331 @@
+package ensure
+
+import (
+	"github.com/chriscasto/go-ensure/with"
+	"maps"
+	"slices"
+)
+
+// Kinds of validator reported by Describe
+const (
+	KindString         = "string"
+	KindNumber         = "number"
+	KindBool           = "bool"
+	KindTime           = "time"
+	KindDuration       = "duration"
+	KindDurationString = "duration_string"
+	KindArray          = "array"
+	KindMap            = "map"
+	KindStruct         = "struct"
+	KindObject         = "object"
+	KindPointer        = "pointer"
+	KindAny            = "any"
+	KindSchema         = "schema"
+
+	// KindCustom is used for validators from outside this package, which can only report their type
+	KindCustom = "custom"
+)
+
+// Relations between a validator and the validators nested in it
+const (
+	RelationField      = "field"
+	RelationGetter     = "getter"
+	RelationElement    = "element"
+	RelationKey        = "key"
+	RelationValue      = "value"
+	RelationLength     = "length"
+	RelationPointer    = "pointer"
+	RelationBranch     = "branch"
+	RelationDuration   = "duration"
+	RelationProperty   = "property"
+	RelationAdditional = "additional"
+
+	// RelationCheck is used for validators nested in any other check, such as allOf in an imported JSON Schema
+	RelationCheck = "check"
+)
+
+// ruleRelations maps the names of rules that apply a nested validator to the relation it has with the value
+var ruleRelations = map[string]string{
+	"Each":           RelationElement,
+	"EachKey":        RelationKey,
+	"EachValue":      RelationValue,
+	"HasLengthWhere": RelationLength,
+	"RequiredKey":    RelationProperty,
+	"OptionalKey":    RelationProperty,
+	"AdditionalKeys": RelationAdditional,
+}
+
+// Description is a tree describing a validator, the checks it applies, and the validators nested in it
+type Description struct {
+	Kind     string             `json:"kind"`
+	Type     string             `json:"type"`
+	Checks   []CheckDescription `json:"checks,omitempty"`
+	Children []ChildDescription `json:"children,omitempty"`
+
+	// Recursive is true if the validator is already being described by one of its
+	// ancestors (eg a linked list node), in which case its checks and children are left out
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// CheckDescription describes a single check by the name of the method that added it and the params it was given
+type CheckDescription struct {
+	Name   string         `json:"name"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// ChildDescription describes a validator nested in another one
+type ChildDescription struct {
+	// Relation describes the part of the value the child validates, such as RelationField or RelationElement
+	Relation string `json:"relation"`
+
+	// Name is the name of the struct field, getter, or object key, or the check
+	// that applies the child for RelationCheck
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Groups lists the validation groups a struct field or getter belongs to
+	Groups []string `json:"groups,omitempty"`
+
+	// Conditional is true for struct fields added with When or Unless
+	Conditional bool `json:"conditional,omitempty"`
+
+	Validator Description `json:"validator"`
+}
+
+// describer is implemented by validators that can describe themselves
+type describer interface {
+	describe(seen describeState) Description
+}
+
+// describeState tracks the validators being described, so recursive validators are only described once
+type describeState map[any]bool
+
+// validator returns the description of any validator
+// Nothing is known about validators from outside this package other than their type
+func (s describeState) validator(v with.UntypedValidator) Description {
+	if d, ok := v.(describer); ok {
+		return d.describe(s)
+	}
+
+	return Description{Kind: KindCustom, Type: v.Type()}
+}
+
+// node returns a description of v, calling fill to add its checks and children
+// unless v is already being described by an ancestor
+func (s describeState) node(v any, kind string, typ string, fill func(d *Description)) Description {
+	d := Description{Kind: kind, Type: typ}
+
+	if s[v] {
+		d.Recursive = true
+		return d
+	}
+
+	s[v] = true
+	fill(&d)
+	delete(s, v)
+
+	return d
+}
+
+// addRules adds a check for each rule, along with a child for any validator nested in it
+func (s describeState) addRules(d *Description, rules []rule) {
+	for _, r := range rules {
+		d.Checks = append(d.Checks, CheckDescription{Name: r.name, Params: maps.Clone(r.params)})
+
+		if r.nested == nil {
+			continue
+		}
+
+		child := ChildDescription{Validator: s.validator(r.nested)}
+
+		if relation, ok := ruleRelations[r.name]; ok {
+			child.Relation = relation
+		} else {
+			child.Relation = RelationCheck
+			child.Name = r.name
+		}
+
+		if key, ok := r.params["key"].(string); ok {
+			child.Name = key
+		}
+
+		d.Children = append(d.Children, child)
+	}
+}
+
+// Describe returns a tree describing the validator and its checks
+func (v *StringValidator) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *StringValidator) describe(s describeState) Description {
+	return s.node(v, KindString, v.Type(), func(d *Description) {
+		s.addRules(d, v.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator and its checks
+func (v *NumberValidator[T]) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *NumberValidator[T]) describe(s describeState) Description {
+	return s.node(v, KindNumber, v.Type(), func(d *Description) {
+		s.addRules(d, v.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator and its checks
+func (bv *BooleanValidator) Describe() Description {
+	return bv.describe(describeState{})
+}
+
+func (bv *BooleanValidator) describe(s describeState) Description {
+	return s.node(bv, KindBool, bv.Type(), func(d *Description) {
+		if bv.expectTrue {
+			d.Checks = append(d.Checks, CheckDescription{Name: "IsTrue"})
+		}
+
+		if bv.expectFalse {
+			d.Checks = append(d.Checks, CheckDescription{Name: "IsFalse"})
+		}
+	})
+}
+
+// Describe returns a tree describing the validator and its checks
+func (v *TimeValidator) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *TimeValidator) describe(s describeState) Description {
+	return s.node(v, KindTime, v.Type(), func(d *Description) {
+		s.addRules(d, v.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator and its checks
+func (v *DurationValidator) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *DurationValidator) describe(s describeState) Description {
+	return s.node(v, KindDuration, v.Type(), func(d *Description) {
+		s.addRules(d, v.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator and the duration validator applied once the string is parsed
+func (v *DurationStringValidator) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *DurationStringValidator) describe(s describeState) Description {
+	return s.node(v, KindDurationString, v.Type(), func(d *Description) {
+		d.Children = append(d.Children, ChildDescription{Relation: RelationDuration, Validator: s.validator(v.parent)})
+	})
+}
+
+// Describe returns a tree describing the validator, its checks, and the validators used for its elements
+func (av *ArrayValidator[T]) Describe() Description {
+	return av.describe(describeState{})
+}
+
+func (av *ArrayValidator[T]) describe(s describeState) Description {
+	return s.node(av, KindArray, av.Type(), func(d *Description) {
+		s.addRules(d, av.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator, its checks, and the validators used for its keys and values
+func (mv *MapValidator[K, V]) Describe() Description {
+	return mv.describe(describeState{})
+}
+
+func (mv *MapValidator[K, V]) describe(s describeState) Description {
+	return s.node(mv, KindMap, mv.Type(), func(d *Description) {
+		s.addRules(d, mv.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator, its checks, and the validators used for its keys
+func (ov *ObjectValidator) Describe() Description {
+	return ov.describe(describeState{})
+}
+
+func (ov *ObjectValidator) describe(s describeState) Description {
+	return s.node(ov, KindObject, ov.Type(), func(d *Description) {
+		s.addRules(d, ov.checks.rules)
+	})
+}
+
+// Describe returns a tree describing the validator and the validator applied to the value it points to
+// Pointers that can't be nil are described with a Required check
+func (v *PointerValidator[T]) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *PointerValidator[T]) describe(s describeState) Description {
+	return s.node(v, KindPointer, v.Type(), func(d *Description) {
+		if !v.optional {
+			d.Checks = append(d.Checks, CheckDescription{Name: "Required"})
+		}
+
+		d.Children = append(d.Children, ChildDescription{Relation: RelationPointer, Validator: s.validator(v.parent)})
+	})
+}
+
+// Describe returns a tree describing the validator, with a child for each of the validators it tries
+func (av *AnyValidator[T]) Describe() Description {
+	return av.describe(describeState{})
+}
+
+func (av *AnyValidator[T]) describe(s describeState) Description {
+	return s.node(av, KindAny, av.Type(), func(d *Description) {
+		for _, v := range av.validators {
+			d.Children = append(d.Children, ChildDescription{Relation: RelationBranch, Validator: s.validator(v)})
+		}
+	})
+}
+
+// Describe returns a tree describing the validator, its checks, and the validators used for its fields and getters
+func (sv *StructValidator[T]) Describe() Description {
+	return sv.describe(describeState{})
+}
+
+func (sv *StructValidator[T]) describe(s describeState) Description {
+	return s.node(sv, KindStruct, sv.Type(), func(d *Description) {
+		s.addRules(d, sv.checks.rules)
+
+		for _, field := range sv.fields {
+			d.Children = append(d.Children, ChildDescription{
+				Relation:    RelationField,
+				Name:        field.name,
+				DisplayName: field.displayName,
+				Groups:      slices.Clone(field.groups),
+				Conditional: field.condition > 0,
+				Validator:   s.validator(field.validator),
+			})
+		}
+
+		for _, getter := range sv.getters {
+			d.Children = append(d.Children, ChildDescription{
+				Relation:    RelationGetter,
+				Name:        getter.name,
+				DisplayName: getter.displayName,
+				Groups:      slices.Clone(getter.groups),
+				Validator:   s.validator(getter.validator),
+			})
+		}
+	})
+}
+
+// Describe returns a tree describing the checks compiled from a JSON Schema
+func (v *jsonValidator) Describe() Description {
+	return v.describe(describeState{})
+}
+
+func (v *jsonValidator) describe(s describeState) Description {
+	return s.node(v, KindSchema, v.Type(), func(d *Description) {
+		s.addRules(d, v.checks.rules)
+	})
+}
@@ -0,0 +1,322 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describer is implemented by every validator in the package
+type describer interface {
+	Describe() ensure.Description
+}
+
+// customFloatValidator is a validator from outside the package, which accepts any float64
+type customFloatValidator struct{}
+
+func (customFloatValidator) Type() string {
+	return "float64"
+}
+
+func (customFloatValidator) ValidateUntyped(_ any, _ ...*with.ValidationOptions) error {
+	return nil
+}
+
+func TestDescribe_Scalars(t *testing.T) {
+	testCases := map[string]struct {
+		validator describer
+		expect    ensure.Description
+	}{
+		"string": {
+			ensure.String().IsNotEmpty().HasLengthWhere(ensure.Length().IsLessThan(5)),
+			ensure.Description{
+				Kind: ensure.KindString,
+				Type: "string",
+				Checks: []ensure.CheckDescription{
+					{Name: "IsNotEmpty"},
+					{Name: "HasLengthWhere"},
+				},
+				Children: []ensure.ChildDescription{{
+					Relation: ensure.RelationLength,
+					Validator: ensure.Description{
+						Kind:   ensure.KindNumber,
+						Type:   "int",
+						Checks: []ensure.CheckDescription{{Name: "IsLessThan", Params: map[string]any{"expected": 5}}},
+					},
+				}},
+			},
+		},
+		"string normalized": {
+			ensure.String().Trim().ToLower().IsNotEmpty().CollapseSpaces().NormalizeNFC(),
+			ensure.Description{
+				Kind: ensure.KindString,
+				Type: "string",
+				Checks: []ensure.CheckDescription{
+					{Name: "Trim"},
+					{Name: "ToLower"},
+					{Name: "IsNotEmpty"},
+					{Name: "CollapseSpaces"},
+					{Name: "NormalizeNFC"},
+				},
+			},
+		},
+		"number": {
+			ensure.Number[float64]().IsInRange(1, 2),
+			ensure.Description{
+				Kind:   ensure.KindNumber,
+				Type:   "float64",
+				Checks: []ensure.CheckDescription{{Name: "IsInRange", Params: map[string]any{"min": 1.0, "max": 2.0}}},
+			},
+		},
+		"bool": {
+			ensure.Bool().IsTrue(),
+			ensure.Description{Kind: ensure.KindBool, Type: "bool", Checks: []ensure.CheckDescription{{Name: "IsTrue"}}},
+		},
+		"bool false": {
+			ensure.Bool().IsFalse(),
+			ensure.Description{Kind: ensure.KindBool, Type: "bool", Checks: []ensure.CheckDescription{{Name: "IsFalse"}}},
+		},
+		"time": {
+			ensure.Time().IsWeekday(),
+			ensure.Description{Kind: ensure.KindTime, Type: "time.Time", Checks: []ensure.CheckDescription{{Name: "IsWeekday"}}},
+		},
+		"duration": {
+			ensure.Duration().IsMultipleOf(time.Second),
+			ensure.Description{
+				Kind:   ensure.KindDuration,
+				Type:   "time.Duration",
+				Checks: []ensure.CheckDescription{{Name: "IsMultipleOf", Params: map[string]any{"expected": time.Second}}},
+			},
+		},
+		"duration string": {
+			ensure.DurationString(ensure.Duration().IsPositive()),
+			ensure.Description{
+				Kind: ensure.KindDurationString,
+				Type: "string",
+				Children: []ensure.ChildDescription{{
+					Relation: ensure.RelationDuration,
+					Validator: ensure.Description{
+						Kind:   ensure.KindDuration,
+						Type:   "time.Duration",
+						Checks: []ensure.CheckDescription{{Name: "IsGreaterThan", Params: map[string]any{"expected": time.Duration(0)}}},
+					},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.validator.Describe(); !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("expected %+v; got %+v", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestDescribe_Collections(t *testing.T) {
+	str := ensure.Description{Kind: ensure.KindString, Type: "string"}
+
+	testCases := map[string]struct {
+		validator describer
+		expect    ensure.Description
+	}{
+		"array": {
+			ensure.ComparableArray[string]().IsNotEmpty().Each(ensure.String()),
+			ensure.Description{
+				Kind:     ensure.KindArray,
+				Type:     "[]string",
+				Checks:   []ensure.CheckDescription{{Name: "IsNotEmpty"}, {Name: "Each"}},
+				Children: []ensure.ChildDescription{{Relation: ensure.RelationElement, Validator: str}},
+			},
+		},
+		"map": {
+			ensure.Map[string, string]().EachKey(ensure.String()).EachValue(ensure.String()),
+			ensure.Description{
+				Kind:   ensure.KindMap,
+				Type:   "map[string]string",
+				Checks: []ensure.CheckDescription{{Name: "EachKey"}, {Name: "EachValue"}},
+				Children: []ensure.ChildDescription{
+					{Relation: ensure.RelationKey, Validator: str},
+					{Relation: ensure.RelationValue, Validator: str},
+				},
+			},
+		},
+		"object": {
+			ensure.Object().RequiredKey("id", ensure.String()).AdditionalKeys(ensure.String()),
+			ensure.Description{
+				Kind:   ensure.KindObject,
+				Type:   "map[string]interface {}",
+				Checks: []ensure.CheckDescription{{Name: "RequiredKey", Params: map[string]any{"key": "id"}}, {Name: "AdditionalKeys"}},
+				Children: []ensure.ChildDescription{
+					{Relation: ensure.RelationProperty, Name: "id", Validator: str},
+					{Relation: ensure.RelationAdditional, Validator: str},
+				},
+			},
+		},
+		"pointer": {
+			ensure.Pointer[string](ensure.String()),
+			ensure.Description{
+				Kind:     ensure.KindPointer,
+				Type:     "*string",
+				Checks:   []ensure.CheckDescription{{Name: "Required"}},
+				Children: []ensure.ChildDescription{{Relation: ensure.RelationPointer, Validator: str}},
+			},
+		},
+		"optional pointer": {
+			ensure.OptionalPointer[string](ensure.String()),
+			ensure.Description{
+				Kind:     ensure.KindPointer,
+				Type:     "*string",
+				Children: []ensure.ChildDescription{{Relation: ensure.RelationPointer, Validator: str}},
+			},
+		},
+		"any": {
+			ensure.Any[string](ensure.String(), ensure.String()),
+			ensure.Description{
+				Kind: ensure.KindAny,
+				Type: "string",
+				Children: []ensure.ChildDescription{
+					{Relation: ensure.RelationBranch, Validator: str},
+					{Relation: ensure.RelationBranch, Validator: str},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.validator.Describe(); !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("expected %+v; got %+v", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestDescribe_Struct(t *testing.T) {
+	v := ensure.Struct[testStruct]().HasFields(with.Validators{
+		"Str": ensure.String(),
+	}, with.DisplayNames{
+		"Str": "String",
+	}).When(func(testStruct) bool { return true }, with.Validators{
+		"Int": ensure.Number[int](),
+	}).HasGetters(with.Validators{
+		"GetFloat": ensure.Number[float64](),
+	}).InGroups("create").HasFields(with.Validators{
+		"Float": customFloatValidator{},
+	}).FieldLessThan("Float", "Float")
+
+	expect := ensure.Description{
+		Kind: ensure.KindStruct,
+		Type: "ensure_test.testStruct",
+		Checks: []ensure.CheckDescription{
+			{Name: "FieldLessThan", Params: map[string]any{"field": "Float", "other": "Float"}},
+		},
+		Children: []ensure.ChildDescription{
+			{
+				Relation:    ensure.RelationField,
+				Name:        "Str",
+				DisplayName: "String",
+				Validator:   ensure.Description{Kind: ensure.KindString, Type: "string"},
+			},
+			{
+				Relation:    ensure.RelationField,
+				Name:        "Int",
+				DisplayName: "Int",
+				Conditional: true,
+				Validator:   ensure.Description{Kind: ensure.KindNumber, Type: "int"},
+			},
+			{
+				Relation:    ensure.RelationField,
+				Name:        "Float",
+				DisplayName: "Float",
+				Validator:   ensure.Description{Kind: ensure.KindCustom, Type: "float64"},
+			},
+			{
+				Relation:    ensure.RelationGetter,
+				Name:        "GetFloat",
+				DisplayName: "GetFloat",
+				Groups:      []string{"create"},
+				Validator:   ensure.Description{Kind: ensure.KindNumber, Type: "float64"},
+			},
+		},
+	}
+
+	if got := v.Describe(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %+v; got %+v", expect, got)
+	}
+}
+
+func TestDescribe_Recursive(t *testing.T) {
+	v := ensure.Struct[schemaNode]()
+	v.HasFields(with.Validators{
+		"Next": ensure.OptionalPointer[schemaNode](v),
+	})
+
+	got := v.Describe()
+	next := got.Children[0].Validator.Children[0].Validator
+
+	if next.Kind != ensure.KindStruct || !next.Recursive || next.Children != nil {
+		t.Errorf("expected a recursive reference to the struct; got %+v", next)
+	}
+
+	// the same validator can be used more than once without being recursive
+	shared := ensure.Struct[schemaNode]()
+	tree := ensure.Struct[schemaTree]().HasFields(with.Validators{
+		"Left":  ensure.OptionalPointer[schemaNode](shared),
+		"Right": ensure.OptionalPointer[schemaNode](shared),
+	})
+
+	for _, child := range tree.Describe().Children {
+		if child.Validator.Children[0].Validator.Recursive {
+			t.Errorf("expected %s not to be recursive", child.Name)
+		}
+	}
+}
+
+func TestDescribe_JSONSchema(t *testing.T) {
+	v, err := ensure.FromJSONSchema(strings.NewReader(`{
+		"$defs": {"name": {"type": "string", "minLength": 1}},
+		"type": "object",
+		"enum": [{}],
+		"const": {},
+		"properties": {"name": {"$ref": "#/$defs/name"}},
+		"allOf": [true],
+		"anyOf": [true],
+		"not": false
+	}`))
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	got := v.(describer).Describe()
+
+	var checks []string
+
+	for _, c := range got.Checks {
+		checks = append(checks, c.Name)
+	}
+
+	expect := []string{"type", "enum", "const", "if object", "allOf", "anyOf", "not"}
+
+	if got.Kind != ensure.KindSchema || !reflect.DeepEqual(checks, expect) {
+		t.Errorf("expected schema checks %v; got %s %v", expect, got.Kind, checks)
+	}
+
+	// {"name": {"$ref": ...}} is described as an object with a name property that refers to a string schema
+	name := got.Children[0].Validator.Children[0]
+
+	if name.Relation != ensure.RelationProperty || name.Name != "name" {
+		t.Errorf("expected a name property; got %+v", name)
+	}
+
+	ref := name.Validator.Children[0]
+
+	if ref.Relation != ensure.RelationCheck || ref.Name != "$ref" || ref.Validator.Checks[1].Name != "if string" {
+		t.Errorf("expected a reference to the name schema; got %+v", ref)
+	}
+}
@@ -0,0 +1,415 @@
+package ensure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationValidator contains information and logic used to validate a time.Duration value
+type DurationValidator struct {
+	checks *valChecks[time.Duration]
+}
+
+// Duration returns an initialized DurationValidator
+func Duration() *DurationValidator {
+	return &DurationValidator{
+		checks: newValChecks[time.Duration](),
+	}
+}
+
+// Type returns the string "time.Duration"
+func (v *DurationValidator) Type() string {
+	return "time.Duration"
+}
+
+// IsInRange adds a check that returns an error if the duration being validated is not between the two durations provided
+// Range is inclusive of the lower bound and exclusive of the upper bound
+func (v *DurationValidator) IsInRange(min time.Duration, max time.Duration) *DurationValidator {
+	if max < min {
+		panic("max cannot be less than min")
+	}
+
+	return v.addRule(rule{name: "IsInRange", params: map[string]any{"min": min, "max": max}}, func(d time.Duration) error {
+		if d < min || d >= max {
+			return newCheckError(CodeDurationRange, map[string]any{"min": min, "max": max, "actual": d})
+		}
+		return nil
+	})
+}
+
+// Equals adds a check that returns an error if the duration being validated is not exactly the duration provided
+func (v *DurationValidator) Equals(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "Equals", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d != target {
+			return newCheckError(CodeDurationEquals, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// DoesNotEqual adds a check that returns an error if the duration being validated is exactly the duration provided
+func (v *DurationValidator) DoesNotEqual(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "DoesNotEqual", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d == target {
+			return newCheckError(CodeDurationNotEquals, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsLessThan adds a check that returns an error if the duration being validated is not less than the duration provided
+func (v *DurationValidator) IsLessThan(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "IsLessThan", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d >= target {
+			return newCheckError(CodeDurationLess, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsLessThanOrEqualTo adds a check that returns an error if the duration being validated is greater than the duration provided
+func (v *DurationValidator) IsLessThanOrEqualTo(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "IsLessThanOrEqualTo", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d > target {
+			return newCheckError(CodeDurationLessOrEqual, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsGreaterThan adds a check that returns an error if the duration being validated is not greater than the duration provided
+func (v *DurationValidator) IsGreaterThan(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "IsGreaterThan", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d <= target {
+			return newCheckError(CodeDurationGreater, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsGreaterThanOrEqualTo adds a check that returns an error if the duration being validated is less than the duration provided
+func (v *DurationValidator) IsGreaterThanOrEqualTo(target time.Duration) *DurationValidator {
+	return v.addRule(rule{name: "IsGreaterThanOrEqualTo", params: map[string]any{"expected": target}}, func(d time.Duration) error {
+		if d < target {
+			return newCheckError(CodeDurationGreaterOrEqual, map[string]any{"expected": target, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsMultipleOf adds a check that returns an error if the duration being validated is not a whole multiple of
+// the unit provided.  For example, IsMultipleOf(time.Second) rejects durations with sub-second components
+func (v *DurationValidator) IsMultipleOf(unit time.Duration) *DurationValidator {
+	if unit <= 0 {
+		panic("unit must be greater than zero")
+	}
+
+	return v.addRule(rule{name: "IsMultipleOf", params: map[string]any{"expected": unit}}, func(d time.Duration) error {
+		if d%unit != 0 {
+			return newCheckError(CodeDurationMultipleOf, map[string]any{"expected": unit, "actual": d})
+		}
+		return nil
+	})
+}
+
+// IsPositive is a shortcut for IsGreaterThan(0)
+func (v *DurationValidator) IsPositive() *DurationValidator {
+	return v.IsGreaterThan(0)
+}
+
+// IsNegative is a shortcut for IsLessThan(0)
+func (v *DurationValidator) IsNegative() *DurationValidator {
+	return v.IsLessThan(0)
+}
+
+// IsZero is a shortcut for Equals(0)
+func (v *DurationValidator) IsZero() *DurationValidator {
+	return v.Equals(0)
+}
+
+// IsNotZero is a shortcut for DoesNotEqual(0)
+func (v *DurationValidator) IsNotZero() *DurationValidator {
+	return v.DoesNotEqual(0)
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a match for the expected type
+func (v *DurationValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	d, ok := value.(time.Duration)
+
+	if !ok {
+		return NewTypeError("time.Duration expected")
+	}
+
+	return v.Validate(d, options...)
+}
+
+// Validate applies all checks against a duration and returns an error if any fail
+func (v *DurationValidator) Validate(d time.Duration, options ...*with.ValidationOptions) error {
+	return v.validate(d, getValidationOptions(options))
+}
+
+// validate applies all checks against a duration with the provided options
+func (v *DurationValidator) validate(d time.Duration, opts *with.ValidationOptions) error {
+	return v.checks.Evaluate(d, opts)
+}
+
+// validateScalar validates a duration read from a struct field
+func (v *DurationValidator) validateScalar(s scalar, opts *with.ValidationOptions) error {
+	return v.validate(time.Duration(s.int), opts)
+}
+
+// validateRef validates the duration that a struct field or getter refers to
+func (v *DurationValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[time.Duration](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *DurationValidator) valueType() reflect.Type {
+	return reflect.TypeFor[time.Duration]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (v *DurationValidator) ValidateContext(ctx context.Context, d time.Duration, options ...*with.ValidationOptions) error {
+	return v.Validate(d, contextOptions(ctx, options))
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (v *DurationValidator) WithMessage(tmpl string) *DurationValidator {
+	v.checks.SetMessage(tmpl)
+	return v
+}
+
+// addRule adds a check along with the rule that describes it
+func (v *DurationValidator) addRule(r rule, fn func(time.Duration) error) *DurationValidator {
+	v.checks.Append(func(val time.Duration, _ *with.ValidationOptions) error {
+		return fn(val)
+	})
+	v.checks.Record(r)
+	return v
+}
+
+// Is adds the provided function as a check against any values to be validated
+func (v *DurationValidator) Is(fn func(time.Duration) error) *DurationValidator {
+	return v.addRule(rule{name: "Is"}, fn)
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (v *DurationValidator) IsCtx(fn func(context.Context, time.Duration) error) *DurationValidator {
+	v.checks.Append(func(val time.Duration, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	v.checks.Record(rule{name: "IsCtx"})
+	return v
+}
+
+// Has adds the provided function as a check against any values to be validated
+// Has is an alias for Is
+func (v *DurationValidator) Has(fn func(time.Duration) error) *DurationValidator {
+	return v.Is(fn)
+}
+
+// DurationStringValidator validates strings by parsing them as durations and
+// passing the result to a duration validator
+type DurationStringValidator struct {
+	parent with.Validator[time.Duration]
+}
+
+// DurationString returns a DurationStringValidator that parses strings using either
+// Go syntax ("1h30m") or ISO 8601 syntax ("PT1H30M") before applying the parent validator
+func DurationString(parent with.Validator[time.Duration]) *DurationStringValidator {
+	return &DurationStringValidator{
+		parent: parent,
+	}
+}
+
+// Type returns the string "string"
+func (v *DurationStringValidator) Type() string {
+	return "string"
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a string
+func (v *DurationStringValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	str, ok := value.(string)
+
+	if !ok {
+		return NewTypeError("string expected")
+	}
+
+	return v.Validate(str, options...)
+}
+
+// Validate parses a string as a duration and returns an error if it can't be
+// parsed or if the resulting duration fails validation
+func (v *DurationStringValidator) Validate(str string, options ...*with.ValidationOptions) error {
+	return v.validate(str, getValidationOptions(options))
+}
+
+// validate parses and validates a duration string with the provided options
+func (v *DurationStringValidator) validate(str string, opts *with.ValidationOptions) error {
+	d, err := ParseDuration(str)
+
+	if err != nil {
+		return translate(newCheckError(CodeStringDuration, map[string]any{"actual": str}), opts)
+	}
+
+	return validateWith(v.parent, d, opts)
+}
+
+// validateScalar validates a duration string read from a struct field
+func (v *DurationStringValidator) validateScalar(s scalar, opts *with.ValidationOptions) error {
+	return v.validate(s.str, opts)
+}
+
+// validateRef validates the duration string that a struct field or getter refers to
+func (v *DurationStringValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[string](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *DurationStringValidator) valueType() reflect.Type {
+	return reflect.TypeFor[string]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (v *DurationStringValidator) ValidateContext(ctx context.Context, str string, options ...*with.ValidationOptions) error {
+	return v.Validate(str, contextOptions(ctx, options))
+}
+
+// ParseDuration parses a duration written in either Go syntax ("1h30m") or
+// ISO 8601 syntax ("PT1H30M").  ISO 8601 years and months are rejected since
+// their length depends on the calendar; days are treated as 24 hours
+func ParseDuration(str string) (time.Duration, error) {
+	unsigned := str
+
+	// ISO 8601 durations may have a single leading sign
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		unsigned = str[1:]
+	}
+
+	if strings.HasPrefix(unsigned, "P") {
+		return parseIsoDuration(str)
+	}
+
+	return time.ParseDuration(str)
+}
+
+// isoDateUnits maps ISO 8601 date designators to their length
+var isoDateUnits = map[byte]time.Duration{
+	'W': 7 * 24 * time.Hour,
+	'D': 24 * time.Hour,
+}
+
+// isoTimeUnits maps ISO 8601 time designators to their length
+var isoTimeUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+}
+
+// isoDateOrder and isoTimeOrder list the designators in the order they must appear
+const (
+	isoDateOrder = "WD"
+	isoTimeOrder = "HMS"
+)
+
+// parseIsoDuration parses an ISO 8601 duration string such as "P1DT2H30M"
+func parseIsoDuration(str string) (time.Duration, error) {
+	orig := str
+	neg := false
+
+	if str[0] == '-' || str[0] == '+' {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	// skip the leading "P"
+	str = str[1:]
+
+	if str == "" || str == "T" {
+		return 0, fmt.Errorf(`invalid ISO 8601 duration "%s"`, orig)
+	}
+
+	var total float64
+	units := isoDateUnits
+	order := isoDateOrder
+	inTime := false
+
+	// last is the position in order of the previous designator, since each can appear only once
+	last := -1
+
+	for str != "" {
+		if str[0] == 'T' {
+			if inTime || len(str) == 1 {
+				return 0, fmt.Errorf(`invalid ISO 8601 duration "%s"`, orig)
+			}
+
+			inTime = true
+			units = isoTimeUnits
+			order = isoTimeOrder
+			last = -1
+			str = str[1:]
+			continue
+		}
+
+		// find the end of the numeric component
+		i := strings.IndexFunc(str, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+
+		if i <= 0 {
+			return 0, fmt.Errorf(`invalid ISO 8601 duration "%s"`, orig)
+		}
+
+		num, err := strconv.ParseFloat(strings.Replace(str[:i], ",", ".", 1), 64)
+
+		if err != nil {
+			return 0, fmt.Errorf(`invalid ISO 8601 duration "%s"`, orig)
+		}
+
+		designator := str[i]
+
+		if !inTime && (designator == 'Y' || designator == 'M') {
+			return 0, fmt.Errorf(`ISO 8601 duration "%s" uses years or months, which have no fixed length`, orig)
+		}
+
+		unit, ok := units[designator]
+
+		if !ok {
+			return 0, fmt.Errorf(`invalid ISO 8601 duration "%s"`, orig)
+		}
+
+		pos := strings.IndexByte(order, designator)
+
+		if pos <= last {
+			return 0, fmt.Errorf(`ISO 8601 duration "%s" repeats a designator or has them out of order`, orig)
+		}
+
+		last = pos
+
+		total += num * float64(unit)
+		str = str[i+1:]
+	}
+
+	if total >= math.MaxInt64 {
+		return 0, errors.New("ISO 8601 duration overflows time.Duration")
+	}
+
+	d := time.Duration(math.Round(total))
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
@@ -0,0 +1,279 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"strings"
+	"testing"
+	"time"
+)
+
+type durationTestCases map[string]struct {
+	value    time.Duration
+	willPass bool
+}
+
+func (tcs durationTestCases) run(t *testing.T, dv *ensure.DurationValidator, method string) {
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := dv.Validate(tc.value)
+			if err != nil && tc.willPass {
+				t.Errorf(`Duration().%s.Validate(%s); expected no error, got "%s"`, method, tc.value, err)
+			} else if err == nil && !tc.willPass {
+				t.Errorf(`Duration().%s.Validate(%s); expected error but got none`, method, tc.value)
+			}
+		})
+	}
+}
+
+// TestDurationValidator_IsValidator checks to make sure the DurationValidator implements the Validator interfaces
+func TestDurationValidator_IsValidator(t *testing.T) {
+	var _ with.UntypedValidator = ensure.Duration()
+	var _ with.Validator[time.Duration] = ensure.Duration()
+	var _ with.UntypedValidator = ensure.DurationString(ensure.Duration())
+	var _ with.Validator[string] = ensure.DurationString(ensure.Duration())
+}
+
+func TestDurationValidator_Type(t *testing.T) {
+	if ensure.Duration().Type() != "time.Duration" {
+		t.Errorf(`unexpected type: expected "%s", got "%s"`, "time.Duration", ensure.Duration().Type())
+	}
+
+	if ensure.DurationString(ensure.Duration()).Type() != "string" {
+		t.Errorf(`unexpected type: expected "%s", got "%s"`, "string", ensure.DurationString(ensure.Duration()).Type())
+	}
+}
+
+func TestDurationValidator_IsInRange(t *testing.T) {
+	t.Run("panic if max < min", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		bad := ensure.Duration().IsInRange(time.Hour, time.Minute)
+		if err := bad.Validate(time.Minute); err != nil {
+			t.Errorf("validation occured and generated an error: %s", err.Error())
+		}
+	})
+
+	testCases := durationTestCases{
+		"less than":       {time.Second, false},
+		"bottom of range": {time.Minute, true},
+		"top of range":    {time.Hour, false},
+		"greater than":    {2 * time.Hour, false},
+	}
+
+	testCases.run(t, ensure.Duration().IsInRange(time.Minute, time.Hour), "IsInRange()")
+}
+
+func TestDurationValidator_Comparisons(t *testing.T) {
+	target := time.Minute
+
+	testCases := map[string]struct {
+		validator *ensure.DurationValidator
+		tests     durationTestCases
+	}{
+		"Equals()": {
+			ensure.Duration().Equals(target),
+			durationTestCases{"less": {target - 1, false}, "equal": {target, true}, "more": {target + 1, false}},
+		},
+		"DoesNotEqual()": {
+			ensure.Duration().DoesNotEqual(target),
+			durationTestCases{"less": {target - 1, true}, "equal": {target, false}, "more": {target + 1, true}},
+		},
+		"IsLessThan()": {
+			ensure.Duration().IsLessThan(target),
+			durationTestCases{"less": {target - 1, true}, "equal": {target, false}, "more": {target + 1, false}},
+		},
+		"IsLessThanOrEqualTo()": {
+			ensure.Duration().IsLessThanOrEqualTo(target),
+			durationTestCases{"less": {target - 1, true}, "equal": {target, true}, "more": {target + 1, false}},
+		},
+		"IsGreaterThan()": {
+			ensure.Duration().IsGreaterThan(target),
+			durationTestCases{"less": {target - 1, false}, "equal": {target, false}, "more": {target + 1, true}},
+		},
+		"IsGreaterThanOrEqualTo()": {
+			ensure.Duration().IsGreaterThanOrEqualTo(target),
+			durationTestCases{"less": {target - 1, false}, "equal": {target, true}, "more": {target + 1, true}},
+		},
+		"IsPositive()": {
+			ensure.Duration().IsPositive(),
+			durationTestCases{"negative": {-1, false}, "zero": {0, false}, "positive": {1, true}},
+		},
+		"IsNegative()": {
+			ensure.Duration().IsNegative(),
+			durationTestCases{"negative": {-1, true}, "zero": {0, false}, "positive": {1, false}},
+		},
+		"IsZero()": {
+			ensure.Duration().IsZero(),
+			durationTestCases{"negative": {-1, false}, "zero": {0, true}, "positive": {1, false}},
+		},
+		"IsNotZero()": {
+			ensure.Duration().IsNotZero(),
+			durationTestCases{"negative": {-1, true}, "zero": {0, false}, "positive": {1, true}},
+		},
+	}
+
+	for method, tc := range testCases {
+		t.Run(method, func(t *testing.T) {
+			tc.tests.run(t, tc.validator, method)
+		})
+	}
+}
+
+func TestDurationValidator_IsMultipleOf(t *testing.T) {
+	t.Run("panic if unit is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		bad := ensure.Duration().IsMultipleOf(0)
+		if err := bad.Validate(time.Minute); err != nil {
+			t.Errorf("validation occured and generated an error: %s", err.Error())
+		}
+	})
+
+	testCases := durationTestCases{
+		"zero":          {0, true},
+		"whole seconds": {90 * time.Second, true},
+		"sub-second":    {1500 * time.Millisecond, false},
+		"negative":      {-2 * time.Second, true},
+	}
+
+	testCases.run(t, ensure.Duration().IsMultipleOf(time.Second), "IsMultipleOf()")
+}
+
+func TestDurationValidator_ErrorMessage(t *testing.T) {
+	err := ensure.Duration().IsLessThan(time.Hour).Validate(90 * time.Minute)
+
+	if err == nil {
+		t.Fatalf("expected error but got none")
+	}
+
+	expect := "duration must be less than 1h0m0s; got 1h30m0s"
+
+	if err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%s"`, expect, err.Error())
+	}
+}
+
+func TestDurationValidator_Has(t *testing.T) {
+	wholeMinutes := func(d time.Duration) error {
+		if d.Truncate(time.Minute) != d {
+			return errors.New("duration must be in whole minutes")
+		}
+		return nil
+	}
+
+	testCases := durationTestCases{
+		"minute":  {time.Minute, true},
+		"seconds": {90 * time.Second, false},
+	}
+
+	testCases.run(t, ensure.Duration().Has(wholeMinutes), "Has()")
+}
+
+func TestDurationValidator_MultiError(t *testing.T) {
+	durTestCases := multiErrTestCases[time.Duration]{
+		"negative fraction": {-1500 * time.Millisecond, 2}, // fails positive, multiple
+		"negative whole":    {-time.Second, 1},             // fails positive
+		"positive fraction": {1500 * time.Millisecond, 1},  // fails multiple
+		"positive whole":    {time.Second, 0},              // fails none
+	}
+
+	durTestCases.run(t,
+		ensure.Duration().IsPositive().IsMultipleOf(time.Second),
+	)
+}
+
+func TestDurationValidator_Validate(t *testing.T) {
+	// see util_test.go
+	runDefaultValidatorTestCases(t, ensure.Duration())
+
+	if err := ensure.Duration().ValidateUntyped(time.Second); err != nil {
+		t.Errorf(`expected no error, got "%s"`, err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		expect    time.Duration
+		expectErr bool
+	}{
+		"go syntax":            {"1h30m", 90 * time.Minute, false},
+		"go negative":          {"-1.5s", -1500 * time.Millisecond, false},
+		"go invalid":           {"1 hour", 0, true},
+		"iso time":             {"PT1H30M", 90 * time.Minute, false},
+		"iso seconds":          {"PT0.5S", 500 * time.Millisecond, false},
+		"iso comma decimal":    {"PT0,5S", 500 * time.Millisecond, false},
+		"iso days":             {"P1DT2H", 26 * time.Hour, false},
+		"iso weeks":            {"P2W", 14 * 24 * time.Hour, false},
+		"iso negative":         {"-PT10M", -10 * time.Minute, false},
+		"iso explicit plus":    {"+PT10M", 10 * time.Minute, false},
+		"iso double sign":      {"--PT10M", 0, true},
+		"iso empty":            {"P", 0, true},
+		"iso empty time":       {"PT", 0, true},
+		"iso trailing t":       {"P1DT", 0, true},
+		"iso repeated t":       {"PT1HT1M", 0, true},
+		"iso years":            {"P1Y", 0, true},
+		"iso months":           {"P1M", 0, true},
+		"iso missing number":   {"PTH", 0, true},
+		"iso missing unit":     {"PT10", 0, true},
+		"iso unknown unit":     {"PT10X", 0, true},
+		"iso time unit in day": {"P10H", 0, true},
+		"iso bad number":       {"PT1..5S", 0, true},
+		"iso overflow":         {"P100000000W", 0, true},
+		"iso all designators":  {"P1W1DT1H1M1S", 8*24*time.Hour + time.Hour + time.Minute + time.Second, false},
+		"iso repeated hours":   {"PT1H1H", 0, true},
+		"iso repeated days":    {"P1D2D", 0, true},
+		"iso seconds first":    {"PT1S1H", 0, true},
+		"iso minutes first":    {"PT1M1H", 0, true},
+		"iso days first":       {"P1D1W", 0, true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d, err := ensure.ParseDuration(tc.input)
+
+			if err != nil && !tc.expectErr {
+				t.Errorf(`ParseDuration("%s"); expected no error, got "%s"`, tc.input, err)
+			} else if err == nil && tc.expectErr {
+				t.Errorf(`ParseDuration("%s"); expected error but got none`, tc.input)
+			} else if d != tc.expect {
+				t.Errorf(`ParseDuration("%s"); expected %s, got %s`, tc.input, tc.expect, d)
+			}
+		})
+	}
+}
+
+func TestDurationStringValidator_Validate(t *testing.T) {
+	validTimeout := ensure.DurationString(
+		ensure.Duration().IsPositive().IsMultipleOf(time.Second).IsLessThanOrEqualTo(time.Hour),
+	)
+
+	testCases := validatorTestCases{
+		"go syntax":     {"1m30s", true},
+		"iso syntax":    {"PT1M30S", true},
+		"fractional":    {"PT1.5S", false},
+		"too long":      {"PT2H", false},
+		"negative":      {"-1m", false},
+		"not duration":  {"soon", false},
+		"not string":    {90 * time.Second, false},
+		"iso with days": {"P1D", false},
+	}
+
+	testCases.run(t, validTimeout)
+
+	err := validTimeout.Validate("soon")
+
+	if err == nil || !strings.Contains(err.Error(), "valid duration") {
+		t.Errorf(`expected a parse error; got "%v"`, err)
+	}
+}
@@ -1,19 +1,56 @@
 package ensure
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
+const (
+	// CodeInvalid is the error code used for validation errors that don't have a more specific code
+	CodeInvalid = "invalid"
+
+	// CodeType is the error code used when a TypeError is encoded as JSON
+	CodeType = "type"
+
+	// redactedTypeErrorMsg replaces the message of a TypeError when it is encoded as JSON
+	redactedTypeErrorMsg = "value has an unexpected type"
+)
+
+// errorJSON is the JSON representation of a single TypeError or ValidationError
+type errorJSON struct {
+	Path    string         `json:"path"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
 // TypeError indicates a mismatch between the type expected by a validator and
 // the type of the value passed to the validator.  This should generally not
 // be passed back to the user.
 type TypeError struct {
-	err string
+	err  string
+	path Path
 }
 
 func (e *TypeError) Error() string {
-	return e.err
+	return e.path.displayPrefix() + e.err
+}
+
+// Path returns the location of the value that caused the error
+func (e *TypeError) Path() Path {
+	return e.path
+}
+
+// MarshalJSON encodes the error as {"path", "code", "message"}
+// The message is redacted since type errors may expose implementation details
+func (e *TypeError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{
+		Path:    e.path.JSONPointer(),
+		Code:    CodeType,
+		Message: redactedTypeErrorMsg,
+	})
 }
 
 func NewTypeError(err string) *TypeError {
@@ -24,16 +61,96 @@ func NewTypeError(err string) *TypeError {
 // checks.  These are intended to be safe to return to the user so they can
 // correct their input(s)
 type ValidationError struct {
-	err string
+	err    string
+	code   string
+	params map[string]any
+	custom bool
+	path   Path
+	cause  error
 }
 
+// Error returns the error message, prefixed by the display names of any fields in its path
 func (e *ValidationError) Error() string {
+	return e.path.displayPrefix() + e.err
+}
+
+// Message returns the error message without any field names
+func (e *ValidationError) Message() string {
 	return e.err
 }
 
+// Code returns a machine-readable code identifying the kind of failure
+// Errors from custom checks added with Is() or Has() use CodeInvalid
+func (e *ValidationError) Code() string {
+	if e.code == "" {
+		return CodeInvalid
+	}
+	return e.code
+}
+
+// Params returns the values used by the check that failed, such as the
+// expected and actual length for a length check
+func (e *ValidationError) Params() map[string]any {
+	return e.params
+}
+
+// Path returns the location of the value that failed validation
+func (e *ValidationError) Path() Path {
+	return e.path
+}
+
+// MarshalJSON encodes the error as {"path", "code", "message", "params"}, where path is a JSON Pointer
+// Durations in params are encoded as strings (eg "1h30m0s") rather than nanoseconds
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	var params map[string]any
+
+	if len(e.params) > 0 {
+		params = make(map[string]any, len(e.params))
+
+		for name, val := range e.params {
+			if d, ok := val.(time.Duration); ok {
+				params[name] = d.String()
+			} else {
+				params[name] = val
+			}
+		}
+	}
+
+	return json.Marshal(errorJSON{
+		Path:    e.path.JSONPointer(),
+		Code:    e.Code(),
+		Message: e.err,
+		Params:  params,
+	})
+}
+
+// Unwrap returns the original error, if this ValidationError was created from another error
+func (e *ValidationError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is a ValidationError with the same code
+// This allows errors.Is to match coded errors such as RequiredPointerMissingErr
+// after a path has been added to them
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && e.code != "" && e.code == t.code
+}
+
 // NewValidationError returns a ValidationError with the error message passed to it
 func NewValidationError(err string) *ValidationError {
-	return &ValidationError{err}
+	return &ValidationError{err: err}
+}
+
+// newCheckError returns a ValidationError for a failed built-in check, with its
+// message rendered from the default (English) catalog
+// Messages in other locales are rendered by translate() once validation options are available
+func newCheckError(code string, params map[string]any) *ValidationError {
+	return &ValidationError{
+		err:    defaultMessage(code).render(params, nil),
+		code:   code,
+		params: params,
+	}
 }
 
 // ValidationErrors is a collection of multiple TypeError and ValidationError structs
@@ -51,6 +168,25 @@ func newValidationErrors() *ValidationErrors {
 	}
 }
 
+// appendError adds an error to the ones collected so far, creating the collection the
+// first time it's needed so nothing is allocated while every check passes
+func appendError(vErrs **ValidationErrors, err error) {
+	if *vErrs == nil {
+		*vErrs = newValidationErrors()
+	}
+
+	(*vErrs).Append(err)
+}
+
+// collectedErrors returns the errors collected with appendError, or nil if there aren't any
+func collectedErrors(vErrs *ValidationErrors) error {
+	if vErrs != nil && vErrs.HasErrors() {
+		return vErrs
+	}
+
+	return nil
+}
+
 // ErrorAsValidationErrors is a helper function for checking if an error is an instance of ValidationErrors
 func ErrorAsValidationErrors(err error) *ValidationErrors {
 	vErrs := &ValidationErrors{}
@@ -87,8 +223,14 @@ func (v *ValidationErrors) Append(err error) {
 		return
 	}
 
+	// If it's already a ValidationError, keep it as-is so its path is retained
+	if vErr, ok := err.(*ValidationError); ok {
+		v.vErrs = append(v.vErrs, vErr)
+		return
+	}
+
 	// Otherwise default to adding it as a ValidationError
-	v.vErrs = append(v.vErrs, &ValidationError{err: err.Error()})
+	v.vErrs = append(v.vErrs, &ValidationError{err: err.Error(), cause: err})
 }
 
 // Extend adds all the errors collected in one ValidationErrors instance into another
@@ -134,6 +276,31 @@ func (v *ValidationErrors) ValidationErrors() []*ValidationError {
 	return v.vErrs
 }
 
+// MarshalJSON encodes the collected validation errors as a JSON array
+// Type errors are left out, since they should not be passed back to the user
+func (v *ValidationErrors) MarshalJSON() ([]byte, error) {
+	if v.vErrs == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(v.vErrs)
+}
+
+// Unwrap returns all collected errors so they can be inspected with errors.Is and errors.As
+func (v *ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(v.vErrs)+len(v.tErrs))
+
+	for _, err := range v.vErrs {
+		errs = append(errs, err)
+	}
+
+	for _, err := range v.tErrs {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
 // Error is the implementation of the "error" interface
 func (v *ValidationErrors) Error() string {
 	// Return the first validation error by default
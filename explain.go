@@ -0,0 +1,588 @@
+package ensure
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// lengthUnits are the words used for the length of each kind of value
+var lengthUnits = map[string]string{
+	KindString: "characters",
+	KindArray:  "items",
+	KindMap:    "entries",
+	KindObject: "keys",
+}
+
+// summary is a plain English description of a value, such as "a string of length 8 that does not contain "@""
+type summary struct {
+	// noun names the value, such as "a string"
+	noun string
+
+	// preps follow the noun directly, such as "of length 8"
+	preps []string
+
+	// rels follow the noun after "that", such as "does not contain "@""
+	rels []string
+
+	// normalizers end the summary, such as "after trimming whitespace"
+	normalizers []string
+}
+
+// String joins the parts of the summary into a phrase
+func (s summary) String() string {
+	out := s.noun
+
+	if len(s.preps) > 0 {
+		out += " " + strings.Join(s.preps, " and ")
+	}
+
+	if len(s.rels) > 0 {
+		out += " that " + joinList(s.rels, "and")
+	}
+
+	if len(s.normalizers) > 0 {
+		out += ", after " + joinList(s.normalizers, "and")
+	}
+
+	return out
+}
+
+// String returns a plain English summary of the values the validator accepts,
+// such as "a string of length 8 that does not contain "@""
+func (d Description) String() string {
+	switch {
+	case d.Kind == KindPointer:
+		return d.pointerSummary(d.Children[0].Validator.String())
+	case d.Kind == KindAny:
+		branches := make([]string, 0, len(d.Children))
+
+		for _, child := range d.Children {
+			branches = append(branches, child.Validator.String())
+		}
+
+		return joinList(branches, "or")
+	case d.Kind == KindDurationString:
+		return "a string containing " + d.Children[0].Validator.String()
+	}
+
+	return d.summarize().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+// Structs are described with an outline, with a line for each field and getter
+func (d Description) Explain() string {
+	return strings.Join(d.outline(), "\n")
+}
+
+// outline returns the lines explaining a validator, which only differ from its summary for structs
+func (d Description) outline() []string {
+	if d.Kind == KindPointer {
+		lines := d.Children[0].Validator.outline()
+		lines[0] = d.pointerSummary(lines[0])
+		return lines
+	}
+
+	if d.Kind != KindStruct || d.Recursive || len(d.Children) == 0 {
+		return []string{d.String()}
+	}
+
+	header := summary{noun: d.noun(), rels: d.summarize().rels}
+	lines := []string{header.String() + " with:"}
+
+	for _, child := range d.Children {
+		label := child.DisplayName
+
+		if child.Relation == RelationGetter {
+			label += "()"
+		}
+
+		if child.Conditional {
+			label += " (conditional)"
+		}
+
+		if len(child.Groups) > 0 {
+			label += fmt.Sprintf(" (%s only)", joinList(child.Groups, "or"))
+		}
+
+		nested := child.Validator.outline()
+		lines = append(lines, "- "+label+": "+nested[0])
+
+		for _, line := range nested[1:] {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	return lines
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *StringValidator) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *StringValidator) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *NumberValidator[T]) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *NumberValidator[T]) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (bv *BooleanValidator) String() string {
+	return bv.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (bv *BooleanValidator) Explain() string {
+	return bv.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *TimeValidator) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *TimeValidator) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *DurationValidator) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *DurationValidator) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *DurationStringValidator) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *DurationStringValidator) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (av *ArrayValidator[T]) String() string {
+	return av.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (av *ArrayValidator[T]) Explain() string {
+	return av.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (mv *MapValidator[K, V]) String() string {
+	return mv.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (mv *MapValidator[K, V]) Explain() string {
+	return mv.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (ov *ObjectValidator) String() string {
+	return ov.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (ov *ObjectValidator) Explain() string {
+	return ov.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (v *PointerValidator[T]) String() string {
+	return v.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (v *PointerValidator[T]) Explain() string {
+	return v.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (av *AnyValidator[T]) String() string {
+	return av.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (av *AnyValidator[T]) Explain() string {
+	return av.Describe().Explain()
+}
+
+// String returns a plain English summary of the values the validator accepts
+func (sv *StructValidator[T]) String() string {
+	return sv.Describe().String()
+}
+
+// Explain returns a plain English summary of the values the validator accepts
+func (sv *StructValidator[T]) Explain() string {
+	return sv.Describe().Explain()
+}
+
+// pointerSummary adds to the summary of the value a pointer points to if the pointer can be nil
+func (d Description) pointerSummary(child string) string {
+	if len(d.Checks) == 0 {
+		return "nothing or " + child
+	}
+
+	return child
+}
+
+// noun returns the name of the kind of value described, with an article
+func (d Description) noun() string {
+	switch d.Kind {
+	case KindString:
+		return "a string"
+	case KindNumber:
+		if strings.HasPrefix(d.Type, "int") || strings.HasPrefix(d.Type, "uint") {
+			return "an integer"
+		}
+		return "a number"
+	case KindBool:
+		return "a boolean"
+	case KindTime:
+		return "a time"
+	case KindDuration:
+		return "a duration"
+	case KindArray:
+		return "a list"
+	case KindMap:
+		return "a map"
+	case KindObject:
+		return "an object"
+	case KindSchema:
+		return "a JSON value"
+	case KindStruct:
+		// leave out the package name
+		return withArticle(d.Type[strings.LastIndex(d.Type, ".")+1:])
+	default:
+		return withArticle(d.Type) + " value"
+	}
+}
+
+// summarize returns the summary of a validator from its checks
+func (d Description) summarize() summary {
+	s := summary{noun: d.noun()}
+
+	if d.Recursive {
+		return s
+	}
+
+	children := d.Children
+
+	for _, check := range d.Checks {
+		var child *Description
+
+		// checks that apply a nested validator are followed by a child in the same order
+		if len(children) > 0 && children[0].appliedBy(check.Name) {
+			child = &children[0].Validator
+			children = children[1:]
+		}
+
+		d.addCheck(&s, check, child)
+	}
+
+	if d.Kind == KindStruct {
+		var fields []string
+
+		for _, child := range children {
+			fields = append(fields, child.DisplayName)
+		}
+
+		if len(fields) > 0 {
+			s.preps = append(s.preps, "with fields "+joinList(fields, "and"))
+		}
+	}
+
+	return s
+}
+
+// appliedBy returns true if the child was added by the named check
+func (c ChildDescription) appliedBy(check string) bool {
+	if c.Relation == RelationCheck {
+		return c.Name == check
+	}
+
+	return c.Relation == ruleRelations[check]
+}
+
+// addCheck adds the phrase for a check to a summary
+// Checks without a phrase of their own (eg Is) are described as custom checks
+func (d Description) addCheck(s *summary, check CheckDescription, child *Description) {
+	expected := formatValue(check.Params["expected"])
+	unit := lengthUnits[d.Kind]
+
+	if prep, ok := lengthPhrase(check.Name, expected, unit); ok {
+		s.preps = append(s.preps, prep)
+		return
+	}
+
+	if pred, ok := comparisonPhrase(check); ok {
+		s.rels = append(s.rels, "is "+pred)
+		return
+	}
+
+	switch check.Name {
+	case "IsEmpty":
+		s.rels = append(s.rels, "is empty")
+	case "IsNotEmpty":
+		s.rels = append(s.rels, "is not empty")
+	case "HasLengthWhere":
+		s.preps = append(s.preps, "whose length is "+joinList(child.predicates(), "and"))
+	case "StartsWith":
+		s.rels = append(s.rels, "starts with "+expected)
+	case "DoesNotStartWith":
+		s.rels = append(s.rels, "does not start with "+expected)
+	case "EndsWith":
+		s.rels = append(s.rels, "ends with "+expected)
+	case "DoesNotEndWith":
+		s.rels = append(s.rels, "does not end with "+expected)
+	case "Contains":
+		s.rels = append(s.rels, "contains "+expected)
+	case "DoesNotContain":
+		s.rels = append(s.rels, "does not contain "+expected)
+	case "ContainsOnly":
+		s.rels = append(s.rels, "contains only "+formatValues(check.Params["values"], "or"))
+	case "ContainsAnyOf":
+		s.rels = append(s.rels, "contains any of "+formatValues(check.Params["values"], "or"))
+	case "DoesNotContainAnyOf":
+		s.rels = append(s.rels, "contains none of "+formatValues(check.Params["values"], "or"))
+	case "ContainsNoDuplicates":
+		s.rels = append(s.rels, "has no duplicates")
+	case "Matches":
+		s.rels = append(s.rels, "matches the pattern "+formatValue(check.Params["pattern"]))
+	case "Trim":
+		s.normalizers = append(s.normalizers, "trimming whitespace")
+	case "ToLower":
+		s.normalizers = append(s.normalizers, "converting to lowercase")
+	case "CollapseSpaces":
+		s.normalizers = append(s.normalizers, "collapsing spaces")
+	case "NormalizeNFC":
+		s.normalizers = append(s.normalizers, "normalizing to NFC")
+	case "IsTrue":
+		s.rels = append(s.rels, "is true")
+	case "IsFalse":
+		s.rels = append(s.rels, "is false")
+	case "IsInPast":
+		s.rels = append(s.rels, "is in the past")
+	case "IsInFuture":
+		s.rels = append(s.rels, "is in the future")
+	case "IsWithin":
+		s.rels = append(s.rels, fmt.Sprintf("is within %s of now", expected))
+	case "IsWeekday":
+		s.rels = append(s.rels, "is a weekday")
+	case "IsInLocation":
+		s.rels = append(s.rels, "is in the time zone "+expected)
+	case "IsBetween":
+		s.rels = append(s.rels, fmt.Sprintf("is between %s and %s", formatValue(check.Params["min"]), formatValue(check.Params["max"])))
+	case "Each":
+		s.preps = append(s.preps, "where each item is "+child.String())
+	case "EachKey":
+		s.preps = append(s.preps, "where each key is "+child.String())
+	case "EachValue":
+		s.preps = append(s.preps, "where each value is "+child.String())
+	case "RequiredKey":
+		s.preps = append(s.preps, fmt.Sprintf("with a required key %s that is %s", formatValue(check.Params["key"]), child))
+	case "OptionalKey":
+		s.preps = append(s.preps, fmt.Sprintf("with an optional key %s that is %s", formatValue(check.Params["key"]), child))
+	case "AdditionalKeys":
+		s.preps = append(s.preps, "where every other key is "+child.String())
+	case "NoAdditionalKeys":
+		s.rels = append(s.rels, "has no other keys")
+	case "FieldEquals":
+		s.rels = append(s.rels, fmt.Sprintf("has %s equal to %s", check.Params["field"], check.Params["other"]))
+	case "FieldLessThan":
+		s.rels = append(s.rels, fmt.Sprintf("has %s less than %s", check.Params["field"], check.Params["other"]))
+	case "FieldRequiredIf":
+		s.rels = append(s.rels, fmt.Sprintf("has %s when %s is %s", check.Params["field"], check.Params["other"], expected))
+	case "type":
+		s.rels = append(s.rels, "is of type "+joinList(check.Params["expected"].([]string), "or"))
+	case "enum":
+		s.rels = append(s.rels, "is one of "+formatValues(check.Params["values"], "or"))
+	case "const":
+		s.rels = append(s.rels, "equals "+expected)
+	case "$ref", "allOf", "anyOf":
+		s.rels = append(s.rels, "is "+child.String())
+	case "not":
+		s.rels = append(s.rels, "is not "+child.String())
+	case "contains":
+		s.rels = append(s.rels, "contains an item that is "+child.String())
+	default:
+		if typ, ok := strings.CutPrefix(check.Name, "if "); ok {
+			s.rels = append(s.rels, fmt.Sprintf("is %s when it is %s", child, withArticle(typ)))
+			return
+		}
+
+		s.rels = append(s.rels, "passes a custom check")
+	}
+}
+
+// lengthPhrase returns the phrase for a check on the length of a value
+func lengthPhrase(name string, expected string, unit string) (string, bool) {
+	switch name {
+	case "HasLength":
+		return "of length " + expected, true
+	case "HasCount":
+		return fmt.Sprintf("with exactly %s %s", expected, unit), true
+	case "IsLongerThan":
+		return fmt.Sprintf("longer than %s %s", expected, unit), true
+	case "IsShorterThan":
+		return fmt.Sprintf("shorter than %s %s", expected, unit), true
+	case "HasMoreThan":
+		return fmt.Sprintf("with more than %s %s", expected, unit), true
+	case "HasFewerThan":
+		return fmt.Sprintf("with fewer than %s %s", expected, unit), true
+	}
+
+	return "", false
+}
+
+// comparisonPhrase returns the phrase for a check that compares a value, such as "greater than 3"
+func comparisonPhrase(check CheckDescription) (string, bool) {
+	expected := formatValue(check.Params["expected"])
+
+	switch check.Name {
+	case "Equals":
+		return "equal to " + expected, true
+	case "DoesNotEqual":
+		return "not equal to " + expected, true
+	case "IsGreaterThan":
+		return "greater than " + expected, true
+	case "IsAfter":
+		return "after " + expected, true
+	case "IsGreaterThanOrEqualTo":
+		return "at least " + expected, true
+	case "IsLessThan":
+		return "less than " + expected, true
+	case "IsBefore":
+		return "before " + expected, true
+	case "IsLessThanOrEqualTo":
+		return "at most " + expected, true
+	case "IsInRange":
+		return fmt.Sprintf("at least %s and less than %s", formatValue(check.Params["min"]), formatValue(check.Params["max"])), true
+	case "IsEven":
+		return "even", true
+	case "IsOdd":
+		return "odd", true
+	case "IsMultipleOf":
+		return "a multiple of " + expected, true
+	case "IsOneOf":
+		return "one of " + formatValues(check.Params["values"], "or"), true
+	case "IsNotOneOf":
+		return "not one of " + formatValues(check.Params["values"], "or"), true
+	}
+
+	return "", false
+}
+
+// predicates returns the phrases for a number validator's checks without a noun, such as "greater than 3"
+func (d *Description) predicates() []string {
+	var preds []string
+
+	for _, check := range d.Checks {
+		if pred, ok := comparisonPhrase(check); ok {
+			preds = append(preds, pred)
+		} else {
+			preds = append(preds, "checked by a custom function")
+		}
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, "anything")
+	}
+
+	return preds
+}
+
+// vowelLetters holds the letters whose names start with a vowel sound (eg "ef" and "aitch")
+const vowelLetters = "AEFHILMNORSX"
+
+// consonantPrefixes holds word beginnings where the first vowel sounds like a consonant
+// (eg "uint" is read "you-int")
+var consonantPrefixes = []string{"eu", "one", "uint", "uni", "uri", "url", "use", "usu", "utf", "uuid"}
+
+// withArticle adds "a" or "an" before a word, depending on how it's read aloud
+// Words that start with an acronym (eg "UUID" or "HTTPClient") are read one letter at a time
+func withArticle(word string) string {
+	if word == "" {
+		return "a "
+	}
+
+	// a capital letter that isn't followed by a lowercase one is read as a letter
+	if first := word[0]; first >= 'A' && first <= 'Z' && (len(word) == 1 || word[1] < 'a' || word[1] > 'z') {
+		if strings.IndexByte(vowelLetters, first) >= 0 {
+			return "an " + word
+		}
+		return "a " + word
+	}
+
+	lower := strings.ToLower(word)
+
+	for _, prefix := range consonantPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return "a " + word
+		}
+	}
+
+	if strings.IndexByte("aeiou", lower[0]) >= 0 {
+		return "an " + word
+	}
+
+	return "a " + word
+}
+
+// joinList joins items into a list such as "a, b, or c"
+func joinList(items []string, conj string) string {
+	switch len(items) {
+	case 0:
+		return "nothing"
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + conj + " " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", " + conj + " " + items[len(items)-1]
+	}
+}
+
+// formatValue returns a value as it should appear in a summary, with strings quoted
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatValues returns each value in a slice as it should appear in a summary, joined into a list
+func formatValues(values any, conj string) string {
+	ref := reflect.ValueOf(values)
+	items := make([]string, 0, ref.Len())
+
+	for i := range ref.Len() {
+		items = append(items, formatValue(ref.Index(i).Interface()))
+	}
+
+	return joinList(items, conj)
+}
@@ -0,0 +1,335 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"strings"
+	"testing"
+	"time"
+)
+
+// explainer is implemented by every exported validator in the package
+type explainer interface {
+	String() string
+	Explain() string
+}
+
+type explainAddress struct {
+	Street string
+	Zip    string
+}
+
+type explainUser struct {
+	Name    string
+	Age     int
+	Email   string
+	Address *explainAddress
+}
+
+func (u explainUser) GetName() string {
+	return u.Name
+}
+
+func TestString(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		validator explainer
+		expect    string
+	}{
+		"string": {
+			ensure.String().HasLength(8).DoesNotContain("@"),
+			`a string of length 8 that does not contain "@"`,
+		},
+		"string lengths": {
+			ensure.String().IsLongerThan(2).IsShorterThan(10).IsNotEmpty(),
+			`a string longer than 2 characters and shorter than 10 characters that is not empty`,
+		},
+		"string length where": {
+			ensure.String().HasLengthWhere(ensure.Length().IsGreaterThan(2).IsOdd()),
+			`a string whose length is greater than 2 and odd`,
+		},
+		"string length where custom": {
+			ensure.String().HasLengthWhere(ensure.Length().Is(func(int) error { return nil })),
+			`a string whose length is checked by a custom function`,
+		},
+		"string length where anything": {
+			ensure.String().HasLengthWhere(ensure.Length()),
+			`a string whose length is anything`,
+		},
+		"string affixes": {
+			ensure.String().StartsWith("a").DoesNotStartWith("ab").EndsWith("z").DoesNotEndWith("yz"),
+			`a string that starts with "a", does not start with "ab", ends with "z", and does not end with "yz"`,
+		},
+		"string contents": {
+			ensure.String().Contains("-").Matches(`^\w+$`).IsEmpty(),
+			`a string that contains "-", matches the pattern "^\\w+$", and is empty`,
+		},
+		"string values": {
+			ensure.String().Equals("a").DoesNotEqual("b").IsOneOf([]string{"a", "c"}).IsNotOneOf([]string{"d"}),
+			`a string that is equal to "a", is not equal to "b", is one of "a" or "c", and is not one of "d"`,
+		},
+		"string normalized": {
+			ensure.String().Trim().HasLength(3).ToLower().Equals("abc"),
+			`a string of length 3 that is equal to "abc", after trimming whitespace and converting to lowercase`,
+		},
+		"string normalizers": {
+			ensure.String().CollapseSpaces().NormalizeNFC(),
+			`a string, after collapsing spaces and normalizing to NFC`,
+		},
+		"string no values": {
+			ensure.String().IsNotOneOf([]string{}),
+			`a string that is not one of nothing`,
+		},
+		"string custom": {
+			ensure.String().Is(func(string) error { return nil }),
+			`a string that passes a custom check`,
+		},
+		"integer": {
+			ensure.Number[int]().IsInRange(1, 10).IsEven(),
+			`an integer that is at least 1 and less than 10 and is even`,
+		},
+		"unsigned integer": {
+			ensure.Number[uint8]().IsGreaterThanOrEqualTo(1).IsLessThanOrEqualTo(5),
+			`an integer that is at least 1 and is at most 5`,
+		},
+		"number": {
+			ensure.Number[float64]().IsLessThan(2.5).DoesNotEqual(0),
+			`a number that is less than 2.5 and is not equal to 0`,
+		},
+		"bool": {
+			ensure.Bool().IsTrue(),
+			`a boolean that is true`,
+		},
+		"bool false": {
+			ensure.Bool().IsFalse(),
+			`a boolean that is false`,
+		},
+		"time": {
+			ensure.Time().IsAfter(start).IsBefore(end).IsWeekday(),
+			`a time that is after 2024-01-01T00:00:00Z, is before 2025-01-01T00:00:00Z, and is a weekday`,
+		},
+		"time range": {
+			ensure.Time().IsBetween(start, end).IsInLocation(time.UTC),
+			`a time that is between 2024-01-01T00:00:00Z and 2025-01-01T00:00:00Z and is in the time zone "UTC"`,
+		},
+		"time relative": {
+			ensure.Time().IsInPast().IsWithin(time.Hour),
+			`a time that is in the past and is within 1h0m0s of now`,
+		},
+		"time future": {
+			ensure.Time().IsInFuture(),
+			`a time that is in the future`,
+		},
+		"duration": {
+			ensure.Duration().IsPositive().IsLessThan(time.Minute),
+			`a duration that is greater than 0s and is less than 1m0s`,
+		},
+		"duration string": {
+			ensure.DurationString(ensure.Duration().IsMultipleOf(time.Second)),
+			`a string containing a duration that is a multiple of 1s`,
+		},
+		"array": {
+			ensure.Array[string]().HasMoreThan(1).HasFewerThan(5).Each(ensure.String().IsNotEmpty()),
+			`a list with more than 1 items and with fewer than 5 items and where each item is a string that is not empty`,
+		},
+		"array count": {
+			ensure.Array[string]().HasCount(2),
+			`a list with exactly 2 items`,
+		},
+		"comparable array": {
+			ensure.ComparableArray[int]().Contains(1).DoesNotContain(2).ContainsNoDuplicates(),
+			`a list that contains 1, does not contain 2, and has no duplicates`,
+		},
+		"comparable array values": {
+			ensure.ComparableArray[string]().ContainsOnly("a", "b", "c").ContainsAnyOf("a").DoesNotContainAnyOf("x", "y"),
+			`a list that contains only "a", "b", or "c", contains any of "a", and contains none of "x" or "y"`,
+		},
+		"map": {
+			ensure.Map[string, int]().HasCount(3).EachKey(ensure.String().IsNotEmpty()).EachValue(ensure.Number[int]().IsOdd()),
+			`a map with exactly 3 entries and where each key is a string that is not empty and where each value is an integer that is odd`,
+		},
+		"object": {
+			ensure.Object().RequiredKey("id", ensure.String()).OptionalKey("age", ensure.Number[float64]()).NoAdditionalKeys(),
+			`an object with a required key "id" that is a string and with an optional key "age" that is a number that has no other keys`,
+		},
+		"object additional keys": {
+			ensure.Object().AdditionalKeys(ensure.Bool()),
+			`an object where every other key is a boolean`,
+		},
+		"pointer": {
+			ensure.Pointer[string](ensure.String().IsNotEmpty()),
+			`a string that is not empty`,
+		},
+		"optional pointer": {
+			ensure.OptionalPointer[string](ensure.String().IsNotEmpty()),
+			`nothing or a string that is not empty`,
+		},
+		"any": {
+			ensure.Any[string](ensure.String().IsEmpty(), ensure.String().HasLength(5)),
+			`a string that is empty or a string of length 5`,
+		},
+		"struct": {
+			ensure.Struct[explainUser]().HasFields(with.Validators{
+				"Name":  ensure.String(),
+				"Email": ensure.String(),
+			}, with.DisplayNames{
+				"Email": "Email Address",
+			}).FieldEquals("Name", "Email"),
+			`an explainUser with fields Name and Email Address that has Name equal to Email`,
+		},
+		"struct rules": {
+			ensure.Struct[explainUser]().HasFields(with.Validators{
+				"Age": ensure.Number[int](),
+			}).FieldLessThan("Age", "Age").FieldRequiredIf("Email", "Name", "x"),
+			`an explainUser with fields Age that has Age less than Age and has Email when Name is "x"`,
+		},
+		"custom": {
+			ensure.Object().RequiredKey("score", customFloatValidator{}),
+			`an object with a required key "score" that is a float64 value`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.validator.String(); got != tc.expect {
+				t.Errorf("expected %q; got %q", tc.expect, got)
+			}
+
+			// validators other than structs are explained with the same summary
+			if _, ok := tc.validator.(*ensure.StructValidator[explainUser]); !ok {
+				if got := tc.validator.Explain(); got != tc.expect {
+					t.Errorf("expected %q; got %q", tc.expect, got)
+				}
+			}
+		})
+	}
+}
+
+func TestExplain_Struct(t *testing.T) {
+	validAddress := ensure.Struct[explainAddress]().HasFields(with.Validators{
+		"Street": ensure.String().IsNotEmpty(),
+		"Zip":    ensure.String().HasLength(5),
+	})
+
+	v := ensure.Struct[explainUser]().HasFields(with.Validators{
+		"Name":    ensure.String().IsNotEmpty(),
+		"Address": ensure.OptionalPointer[explainAddress](validAddress),
+	}, with.DisplayNames{
+		"Name": "Full Name",
+	}).When(func(explainUser) bool { return true }, with.Validators{
+		"Age": ensure.Number[int]().IsGreaterThanOrEqualTo(18),
+	}).HasGetters(with.Validators{
+		"GetName": ensure.String().DoesNotContain("@"),
+	}).InGroups("create", "update").FieldRequiredIf("Email", "Name", "x")
+
+	expect := strings.Join([]string{
+		`an explainUser that has Email when Name is "x" with:`,
+		`- Full Name: a string that is not empty`,
+		`- Address: nothing or an explainAddress with:`,
+		`  - Street: a string that is not empty`,
+		`  - Zip: a string of length 5`,
+		`- Age (conditional): an integer that is at least 18`,
+		`- GetName() (create or update only): a string that does not contain "@"`,
+	}, "\n")
+
+	if got := v.Explain(); got != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, got)
+	}
+
+	// a struct validator without any fields is explained with its summary
+	if got := ensure.Struct[explainAddress]().Explain(); got != "an explainAddress" {
+		t.Errorf(`expected "an explainAddress"; got %q`, got)
+	}
+}
+
+func TestWithArticle(t *testing.T) {
+	testCases := map[string]string{
+		"int":            "an int",
+		"uint8":          "a uint8",
+		"float64":        "a float64",
+		"explainAddress": "an explainAddress",
+		"Order":          "an Order",
+		"UserID":         "a UserID",
+		"Unknown":        "an Unknown",
+		"UUID":           "a UUID",
+		"URL":            "a URL",
+		"HTTPHeader":     "an HTTPHeader",
+		"SQLQuery":       "an SQLQuery",
+		"RGB":            "an RGB",
+		"X":              "an X",
+		"T1":             "a T1",
+		"Europe":         "a Europe",
+		"[]string":       "a []string",
+		"":               "a ",
+	}
+
+	for word, expect := range testCases {
+		t.Run(word, func(t *testing.T) {
+			if got := ensure.WithArticle(word); got != expect {
+				t.Errorf(`expected "%s"; got "%s"`, expect, got)
+			}
+		})
+	}
+}
+
+func TestExplain_Recursive(t *testing.T) {
+	v := ensure.Struct[schemaNode]()
+	v.HasFields(with.Validators{
+		"Value": ensure.String().IsNotEmpty(),
+		"Next":  ensure.OptionalPointer[schemaNode](v),
+	})
+
+	expect := strings.Join([]string{
+		`a schemaNode with:`,
+		`- Value: a string that is not empty`,
+		`- Next: nothing or a schemaNode`,
+	}, "\n")
+
+	if got := v.Explain(); got != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, got)
+	}
+
+	if got := v.String(); got != "a schemaNode with fields Value and Next" {
+		t.Errorf(`expected "a schemaNode with fields Value and Next"; got %q`, got)
+	}
+}
+
+func TestString_JSONSchema(t *testing.T) {
+	testCases := map[string]struct {
+		schema string
+		expect string
+	}{
+		"types": {
+			`{"type": ["string", "null"], "minLength": 1}`,
+			`a JSON value that is of type string or null and is a string that passes a custom check when it is a string`,
+		},
+		"enum and const": {
+			`{"enum": ["a", 1], "const": "a"}`,
+			`a JSON value that is one of "a" or 1 and equals "a"`,
+		},
+		"combinators": {
+			`{"$defs": {"s": {"type": "string"}}, "allOf": [{"$ref": "#/$defs/s"}], "anyOf": [true], "not": {"type": "null"}}`,
+			`a JSON value that is a JSON value that is a JSON value that is of type string, is a JSON value, and is not a JSON value that is of type null`,
+		},
+		"numbers": {
+			`{"type": "integer", "minimum": 2}`,
+			`a JSON value that is of type integer and is a number that is at least 2 when it is a number`,
+		},
+		"arrays": {
+			`{"uniqueItems": true, "contains": {"type": "string"}}`,
+			`a JSON value that is a list that has no duplicates and contains an item that is a JSON value that is of type string when it is an array`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v := mustFromJSONSchema(tc.schema)
+
+			if got := v.(describer).Describe().String(); got != tc.expect {
+				t.Errorf("expected %q; got %q", tc.expect, got)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package ensure
 
+import (
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
+
 // Export these internal functions so they can be accessed by the test suite
 
 func IsEven(typeStr string, i any) bool {
@@ -13,3 +18,16 @@ func IsOdd(typeStr string, i any) bool {
 func NewValidationErrors() *ValidationErrors {
 	return newValidationErrors()
 }
+
+func ToCount(val any) (int, bool) {
+	return toCount(val)
+}
+
+func WithArticle(word string) string {
+	return withArticle(word)
+}
+
+func TaggedStruct[T any]() with.UntypedValidator {
+	fields, _ := tagFields(reflect.TypeFor[T](), "")
+	return &taggedStructValidator{refType: reflect.TypeFor[T](), fields: fields}
+}
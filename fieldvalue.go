@@ -0,0 +1,84 @@
+package ensure
+
+import (
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
+
+// scalar holds a string, bool, or number read from a struct field with reflection, so it can
+// be passed to a validator without being converted to an interface, which would allocate
+type scalar struct {
+	kind  reflect.Kind
+	str   string
+	int   int64
+	uint  uint64
+	float float64
+	bool  bool
+}
+
+// readScalar reads a string, bool, or number
+func readScalar(v reflect.Value) scalar {
+	switch kind := v.Kind(); kind {
+	case reflect.String:
+		return scalar{kind: kind, str: v.String()}
+	case reflect.Bool:
+		return scalar{kind: kind, bool: v.Bool()}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return scalar{kind: kind, int: v.Int()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return scalar{kind: kind, uint: v.Uint()}
+	default:
+		return scalar{kind: kind, float: v.Float()}
+	}
+}
+
+// scalarNumber converts the number held by a scalar to type T
+func scalarNumber[T NumberType](s scalar) T {
+	switch s.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return T(s.int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return T(s.uint)
+	default:
+		return T(s.float)
+	}
+}
+
+// scalarValidator is implemented by the validators for strings, bools, and numbers so struct
+// fields of those types can be validated without copying the struct
+type scalarValidator interface {
+	validateScalar(s scalar, opts *with.ValidationOptions) error
+}
+
+// refValidator is implemented by the validators in this package so struct fields and getters
+// can be validated without copying their values into an interface
+type refValidator interface {
+	// validateRef validates the value that ref refers to, which is either a pointer to a
+	// value of the validator's type or a getter that returns one
+	validateRef(ref any, opts *with.ValidationOptions) error
+
+	// valueType returns the type of value the validator accepts
+	valueType() reflect.Type
+}
+
+// refFor returns the validator as a refValidator if it accepts values of exactly type t
+// Types are compared directly, since types from different packages can have the same name;
+// other validators are passed values as an interface, so they can reject them with a TypeError
+func refFor(v with.UntypedValidator, t reflect.Type) (refValidator, bool) {
+	ref, ok := v.(refValidator)
+
+	if !ok || ref.valueType() != t {
+		return nil, false
+	}
+
+	return ref, true
+}
+
+// deref returns the value that a ref passed to validateRef refers to
+func deref[T any](ref any) T {
+	if getter, ok := ref.(func() T); ok {
+		return getter()
+	}
+
+	return *ref.(*T)
+}
@@ -2,4 +2,13 @@ module github.com/chriscasto/go-ensure
 
 go 1.23.6
 
-require golang.org/x/exp v0.0.0-20250215185904-eff6e970281f // indirect
+require (
+	golang.org/x/exp v0.0.0-20250215185904-eff6e970281f
+	golang.org/x/text v0.28.0
+	golang.org/x/tools v0.35.0
+)
+
+require (
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
@@ -0,0 +1,661 @@
+package ensure
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// jsonSchemaDialect identifies the version of JSON Schema produced by JSONSchema
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaRulesKeyword is the annotation that lists rules with no JSON Schema equivalent
+const schemaRulesKeyword = "x-ensure-rules"
+
+// schemaTypeKeyword is the annotation that records the type of validators from outside this package
+const schemaTypeKeyword = "x-ensure-type"
+
+// schemaGoPatternKeyword is the annotation that holds patterns using Go syntax with no ECMA-262 equivalent
+const schemaGoPatternKeyword = "x-go-pattern"
+
+// JSONSchema walks a validator and any validators nested in it and returns a
+// JSON Schema (2020-12) document describing the values it accepts
+// Rules with no JSON Schema equivalent, such as functions passed to Is, are
+// listed in an "x-ensure-rules" annotation on the schema they apply to
+func JSONSchema(v with.UntypedValidator) ([]byte, error) {
+	g := newSchemaGenerator("#/$defs/")
+	schema := g.schema(v)
+
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	schema["$schema"] = jsonSchemaDialect
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaObject is a JSON Schema object under construction
+type schemaObject map[string]any
+
+// set adds a keyword to the schema
+// If the keyword is already set (eg by a second call to Matches), it is added as
+// another entry in allOf instead so both constraints are kept
+func (s schemaObject) set(keyword string, value any) {
+	if _, ok := s[keyword]; !ok {
+		s[keyword] = value
+		return
+	}
+
+	allOf, _ := s["allOf"].([]any)
+	s["allOf"] = append(allOf, schemaObject{keyword: value})
+}
+
+// annotate adds an entry to the list of rules with no JSON Schema equivalent
+func (s schemaObject) annotate(note map[string]any) {
+	notes, _ := s[schemaRulesKeyword].([]any)
+	s[schemaRulesKeyword] = append(notes, note)
+}
+
+// schemaExporter is implemented by validators that can describe themselves as JSON Schema
+type schemaExporter interface {
+	jsonSchema(g *schemaGenerator) schemaObject
+}
+
+// schemaRequirer is implemented by validators that reject a missing value, so
+// struct fields using them are listed as required
+type schemaRequirer interface {
+	schemaRequired() bool
+}
+
+// schemaGenerator converts validators to JSON Schema, keeping track of the
+// definitions needed for validators that are referenced by name
+type schemaGenerator struct {
+	refPrefix  string
+	refStructs bool
+	refs       map[any]string
+	names      map[string]bool
+	defs       map[string]schemaObject
+	building   map[any]bool
+}
+
+// newSchemaGenerator returns a schemaGenerator that prefixes references with refPrefix (eg "#/$defs/")
+func newSchemaGenerator(refPrefix string) *schemaGenerator {
+	return &schemaGenerator{
+		refPrefix: refPrefix,
+		refs:      map[any]string{},
+		names:     map[string]bool{},
+		defs:      map[string]schemaObject{},
+		building:  map[any]bool{},
+	}
+}
+
+// schema returns the JSON Schema for a validator
+// Nothing is known about validators from outside this package other than their type
+func (g *schemaGenerator) schema(v with.UntypedValidator) schemaObject {
+	if exporter, ok := v.(schemaExporter); ok {
+		return exporter.jsonSchema(g)
+	}
+
+	return schemaObject{schemaTypeKeyword: v.Type()}
+}
+
+// ref returns a reference to the definition of v if it has one, or the schema built for it if not
+// A validator that contains itself (eg a struct validator for a linked list) is
+// given a definition named after its type the first time the cycle is found
+func (g *schemaGenerator) ref(v any, typeName string, build func() schemaObject) schemaObject {
+	if name, ok := g.refs[v]; ok {
+		return schemaObject{"$ref": g.refPrefix + name}
+	}
+
+	if g.building[v] {
+		name := g.uniqueName(typeName)
+		g.refs[v] = name
+		return schemaObject{"$ref": g.refPrefix + name}
+	}
+
+	g.building[v] = true
+	schema := build()
+	delete(g.building, v)
+
+	if name, ok := g.refs[v]; ok {
+		g.defs[name] = schema
+		return schemaObject{"$ref": g.refPrefix + name}
+	}
+
+	return schema
+}
+
+// define returns a reference to the definition of v, adding the definition the first time v is seen
+// It's used when every struct should be referenced by name, rather than only those that contain themselves
+func (g *schemaGenerator) define(v any, typeName string, build func() schemaObject) schemaObject {
+	name, ok := g.refs[v]
+
+	if !ok {
+		name = g.uniqueName(typeName)
+		g.refs[v] = name
+		g.defs[name] = build()
+	}
+
+	return schemaObject{"$ref": g.refPrefix + name}
+}
+
+// uniqueName returns a definition name based on name that hasn't been used yet
+func (g *schemaGenerator) uniqueName(name string) string {
+	unique := name
+
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+
+	g.names[unique] = true
+	return unique
+}
+
+// annotation returns the entry describing a rule with no JSON Schema equivalent,
+// including the schema of any validator nested in it
+func (g *schemaGenerator) annotation(r rule) map[string]any {
+	note := map[string]any{"rule": r.name}
+
+	if len(r.params) > 0 {
+		note["params"] = r.params
+	}
+
+	if r.nested != nil {
+		note["schema"] = g.schema(r.nested)
+	}
+
+	return note
+}
+
+// lengthKeywords holds the keywords that bound the length of a kind of value
+type lengthKeywords struct {
+	min string
+	max string
+}
+
+var (
+	stringLengthKeywords = lengthKeywords{min: "minLength", max: "maxLength"}
+	arrayLengthKeywords  = lengthKeywords{min: "minItems", max: "maxItems"}
+	mapLengthKeywords    = lengthKeywords{min: "minProperties", max: "maxProperties"}
+)
+
+// setMinLength sets the lower bound of a length, ignoring bounds that every length meets
+func (s schemaObject) setMinLength(kw lengthKeywords, l int) {
+	if l > 0 {
+		s.set(kw.min, l)
+	}
+}
+
+// setMaxLength sets the upper bound of a length
+// A negative bound can't be met by any value, so nothing is accepted
+func (s schemaObject) setMaxLength(kw lengthKeywords, l int) {
+	if l < 0 {
+		s.set("not", schemaObject{})
+		return
+	}
+
+	s.set(kw.max, l)
+}
+
+// setLengthRule adds the keywords for a length rule and returns false if the rule isn't one
+func (s schemaObject) setLengthRule(g *schemaGenerator, r rule, kw lengthKeywords) bool {
+	switch r.name {
+	case "IsEmpty":
+		s.setMaxLength(kw, 0)
+	case "IsNotEmpty":
+		s.setMinLength(kw, 1)
+	case "HasLength", "HasCount":
+		s.setMinLength(kw, r.params["expected"].(int))
+		s.setMaxLength(kw, r.params["expected"].(int))
+	case "IsLongerThan", "HasMoreThan":
+		s.setMinLength(kw, r.params["expected"].(int)+1)
+	case "IsShorterThan", "HasFewerThan":
+		s.setMaxLength(kw, r.params["expected"].(int)-1)
+	case "HasLengthWhere":
+		s.setLengthWhere(g, r, kw)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// setLengthWhere adds the keywords for the rules of a length validator passed to HasLengthWhere
+// If any of its rules can't be expressed as a bound, the validator is also added as an annotation
+func (s schemaObject) setLengthWhere(g *schemaGenerator, r rule, kw lengthKeywords) {
+	exact := true
+
+	for _, lr := range r.nested.(*NumberValidator[int]).checks.rules {
+		switch lr.name {
+		case "Equals":
+			s.setMinLength(kw, lr.params["expected"].(int))
+			s.setMaxLength(kw, lr.params["expected"].(int))
+		case "IsGreaterThan":
+			s.setMinLength(kw, lr.params["expected"].(int)+1)
+		case "IsGreaterThanOrEqualTo":
+			s.setMinLength(kw, lr.params["expected"].(int))
+		case "IsLessThan":
+			s.setMaxLength(kw, lr.params["expected"].(int)-1)
+		case "IsLessThanOrEqualTo":
+			s.setMaxLength(kw, lr.params["expected"].(int))
+		case "IsInRange":
+			s.setMinLength(kw, lr.params["min"].(int))
+			s.setMaxLength(kw, lr.params["max"].(int)-1)
+		default:
+			exact = false
+		}
+	}
+
+	if !exact {
+		s.annotate(g.annotation(r))
+	}
+}
+
+// setNumberRule adds the keywords for a rule on a number or duration and returns false if there aren't any
+func (s schemaObject) setNumberRule(r rule) bool {
+	switch r.name {
+	case "Equals":
+		s.set("const", r.params["expected"])
+	case "DoesNotEqual":
+		s.set("not", schemaObject{"const": r.params["expected"]})
+	case "IsLessThan":
+		s.set("exclusiveMaximum", r.params["expected"])
+	case "IsLessThanOrEqualTo":
+		s.set("maximum", r.params["expected"])
+	case "IsGreaterThan":
+		s.set("exclusiveMinimum", r.params["expected"])
+	case "IsGreaterThanOrEqualTo":
+		s.set("minimum", r.params["expected"])
+	case "IsInRange":
+		s.set("minimum", r.params["min"])
+		s.set("exclusiveMaximum", r.params["max"])
+	case "IsOneOf":
+		s.set("enum", r.params["values"])
+	case "IsNotOneOf":
+		s.set("not", schemaObject{"enum": r.params["values"]})
+	case "IsEven":
+		s.set("multipleOf", 2)
+	case "IsOdd":
+		s.set("multipleOf", 1)
+		s.set("not", schemaObject{"multipleOf": 2})
+	case "IsMultipleOf":
+		s.set("multipleOf", r.params["expected"])
+	default:
+		return false
+	}
+
+	return true
+}
+
+// jsonSchema returns a string schema, using patterns for rules that check substrings
+func (v *StringValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "string"}
+
+	// Normalizers run before every check, but keywords would be checked against the value
+	// before it's normalized (eg maxLength 3 rejects " abc " even after Trim), so the rules
+	// of a normalized string, including the normalizers, are only annotations
+	if len(v.normalizers) > 0 {
+		for _, r := range v.checks.rules {
+			s.annotate(g.annotation(r))
+		}
+
+		return s
+	}
+
+	for _, r := range v.checks.rules {
+		switch r.name {
+		case "Equals":
+			s.set("const", r.params["expected"])
+		case "DoesNotEqual":
+			s.set("not", schemaObject{"const": r.params["expected"]})
+		case "StartsWith":
+			s.set("pattern", "^"+regexp.QuoteMeta(r.params["expected"].(string)))
+		case "DoesNotStartWith":
+			s.set("not", schemaObject{"pattern": "^" + regexp.QuoteMeta(r.params["expected"].(string))})
+		case "EndsWith":
+			s.set("pattern", regexp.QuoteMeta(r.params["expected"].(string))+"$")
+		case "DoesNotEndWith":
+			s.set("not", schemaObject{"pattern": regexp.QuoteMeta(r.params["expected"].(string)) + "$"})
+		case "Contains":
+			s.set("pattern", regexp.QuoteMeta(r.params["expected"].(string)))
+		case "DoesNotContain":
+			s.set("not", schemaObject{"pattern": regexp.QuoteMeta(r.params["expected"].(string))})
+		case "IsOneOf":
+			s.set("enum", r.params["values"])
+		case "IsNotOneOf":
+			s.set("not", schemaObject{"enum": r.params["values"]})
+		case "Matches":
+			s.setPattern(r.params["pattern"].(string))
+		default:
+			if !s.setLengthRule(g, r, stringLengthKeywords) {
+				s.annotate(g.annotation(r))
+			}
+		}
+	}
+
+	return s
+}
+
+// setPattern adds a regular expression to the schema
+// JSON Schema patterns use ECMA-262 syntax, so patterns that rely on Go syntax it doesn't
+// have (eg flags like "(?i)") are added as an "x-go-pattern" annotation instead
+func (s schemaObject) setPattern(pattern string) {
+	if isECMAPattern(pattern) {
+		s.set("pattern", pattern)
+		return
+	}
+
+	s.set(schemaGoPatternKeyword, pattern)
+}
+
+// isECMAPattern returns false if a Go regular expression uses syntax that ECMA-262
+// doesn't have or reads differently, such as flags, \A and \z, \Q...\E, Unicode
+// classes, POSIX classes, and octal escapes
+// When in doubt it returns false, since JSON Schema tools can't misread an annotation
+func isECMAPattern(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+
+			if strings.IndexByte("AzQECpP0123456789", pattern[i]) >= 0 ||
+				strings.HasPrefix(pattern[i:], "x{") {
+				return false
+			}
+		case strings.HasPrefix(pattern[i:], "(?"):
+			// Only non-capturing groups and named groups (without the P) are shared
+			if !strings.HasPrefix(pattern[i:], "(?:") && !strings.HasPrefix(pattern[i:], "(?<") {
+				return false
+			}
+		case strings.HasPrefix(pattern[i:], "[:"):
+			return false
+		}
+	}
+
+	return true
+}
+
+// jsonSchema returns an integer schema, or a number schema for floats
+func (v *NumberValidator[T]) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "integer"}
+
+	if v.isFloat {
+		s["type"] = "number"
+	}
+
+	for _, r := range v.checks.rules {
+		if !s.setNumberRule(r) {
+			s.annotate(g.annotation(r))
+		}
+	}
+
+	return s
+}
+
+// jsonSchema returns a boolean schema
+func (bv *BooleanValidator) jsonSchema(_ *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "boolean"}
+
+	if bv.expectTrue {
+		s.set("const", true)
+	}
+
+	if bv.expectFalse {
+		s.set("const", false)
+	}
+
+	return s
+}
+
+// jsonSchema returns a date-time string schema, since encoding/json writes times as RFC 3339 strings
+// None of the time rules have a JSON Schema equivalent
+func (v *TimeValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "string", "format": "date-time"}
+
+	for _, r := range v.checks.rules {
+		s.annotate(g.annotation(r))
+	}
+
+	return s
+}
+
+// jsonSchema returns an integer schema, since encoding/json writes durations as nanoseconds
+func (v *DurationValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "integer"}
+
+	for _, r := range v.checks.rules {
+		if !s.setNumberRule(r) {
+			s.annotate(g.annotation(r))
+		}
+	}
+
+	return s
+}
+
+// jsonSchema returns a string schema with the duration validator as an annotation
+func (v *DurationStringValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "string"}
+	s.annotate(g.annotation(rule{name: "DurationString", nested: v.parent}))
+	return s
+}
+
+// jsonSchema returns an array schema, using the Each validator for its items
+func (av *ArrayValidator[T]) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "array"}
+
+	for _, r := range av.checks.rules {
+		switch r.name {
+		case "Each":
+			s.set("items", g.schema(r.nested))
+		case "Contains":
+			s.set("contains", schemaObject{"const": r.params["expected"]})
+		case "DoesNotContain":
+			s.set("not", schemaObject{"contains": schemaObject{"const": r.params["expected"]}})
+		case "ContainsOnly":
+			s.set("items", schemaObject{"enum": r.params["values"]})
+		case "ContainsNoDuplicates":
+			s.set("uniqueItems", true)
+		case "ContainsAnyOf":
+			s.set("contains", schemaObject{"enum": r.params["values"]})
+		case "DoesNotContainAnyOf":
+			s.set("not", schemaObject{"contains": schemaObject{"enum": r.params["values"]}})
+		default:
+			if !s.setLengthRule(g, r, arrayLengthKeywords) {
+				s.annotate(g.annotation(r))
+			}
+		}
+	}
+
+	return s
+}
+
+// jsonSchema returns an object schema, using the EachValue validator for its properties
+// JSON object keys are always strings, so EachKey validators for other key types are only annotations
+func (mv *MapValidator[K, V]) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "object"}
+	stringKeys := reflect.TypeFor[K]().Kind() == reflect.String
+
+	for _, r := range mv.checks.rules {
+		switch {
+		case r.name == "EachKey" && stringKeys:
+			s.set("propertyNames", g.schema(r.nested))
+		case r.name == "EachValue":
+			s.set("additionalProperties", g.schema(r.nested))
+		case !s.setLengthRule(g, r, mapLengthKeywords):
+			s.annotate(g.annotation(r))
+		}
+	}
+
+	return s
+}
+
+// jsonSchema returns the parent's schema, which also accepts null for optional pointers
+func (v *PointerValidator[T]) jsonSchema(g *schemaGenerator) schemaObject {
+	s := g.schema(v.parent)
+
+	if v.optional {
+		return schemaObject{"anyOf": []any{s, schemaObject{"type": "null"}}}
+	}
+
+	return s
+}
+
+// schemaRequired returns true if the pointer can't be nil
+func (v *PointerValidator[T]) schemaRequired() bool {
+	return !v.optional
+}
+
+// jsonSchema returns a schema that accepts values matching any of the validators
+func (av *AnyValidator[T]) jsonSchema(g *schemaGenerator) schemaObject {
+	anyOf := make([]any, 0, len(av.validators))
+
+	for _, v := range av.validators {
+		anyOf = append(anyOf, g.schema(v))
+	}
+
+	return schemaObject{"anyOf": anyOf}
+}
+
+// jsonSchema returns an object schema with a property for each key that has a validator
+func (ov *ObjectValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "object"}
+	properties := schemaObject{}
+	var required []string
+
+	for _, r := range ov.checks.rules {
+		switch r.name {
+		case "RequiredKey":
+			key := r.params["key"].(string)
+			properties[key] = g.schema(r.nested)
+			required = append(required, key)
+		case "OptionalKey":
+			properties[r.params["key"].(string)] = g.schema(r.nested)
+		case "AdditionalKeys":
+			s["additionalProperties"] = g.schema(r.nested)
+		case "NoAdditionalKeys":
+			s["additionalProperties"] = false
+		default:
+			s.annotate(g.annotation(r))
+		}
+	}
+
+	if len(properties) > 0 {
+		s["properties"] = properties
+	}
+
+	if len(required) > 0 {
+		s["required"] = required
+	}
+
+	return s
+}
+
+// jsonSchema returns an object schema with a property for each field that has a validator,
+// or a reference to its definition
+func (sv *StructValidator[T]) jsonSchema(g *schemaGenerator) schemaObject {
+	build := func() schemaObject {
+		return sv.schemaDefinition(g)
+	}
+
+	if g.refStructs {
+		return g.define(sv, sv.refVal.Type().Name(), build)
+	}
+
+	return g.ref(sv, sv.refVal.Type().Name(), build)
+}
+
+// schemaDefinition returns an object schema with a property for each field that has a validator
+// Properties are named the way encoding/json names them, and display names become titles
+// Getters aren't part of the JSON encoding, so they're left out, along with accessors for
+// values that aren't exported fields
+func (sv *StructValidator[T]) schemaDefinition(g *schemaGenerator) schemaObject {
+	s := schemaObject{"type": "object"}
+
+	for _, r := range sv.checks.rules {
+		s.annotate(g.annotation(r))
+	}
+
+	addFieldSchemas(g, s, sv.refVal.Type(), sv.fields, sv.defaultFor)
+	return s
+}
+
+// addFieldSchemas adds a property to an object schema for each struct field that has a validator,
+// and lists the fields that must be present as required
+func addFieldSchemas(g *schemaGenerator, s schemaObject, refType reflect.Type, fields []*validField, defaultFor func(string) (*structDefault, bool)) {
+	properties := schemaObject{}
+	var required []string
+
+	for _, field := range fields {
+		structField, ok := refType.FieldByName(field.name)
+
+		// accessors can validate values that aren't part of the JSON encoding
+		if !ok || !structField.IsExported() || structField.Type.String() != field.validator.Type() {
+			continue
+		}
+
+		name, ok := jsonFieldName(structField)
+
+		if !ok {
+			continue
+		}
+
+		prop := g.schema(field.validator)
+
+		if field.displayName != field.name {
+			prop["title"] = field.displayName
+		}
+
+		// fields with defaults can be left out, since the default fills them in
+		d, hasDefault := defaultFor(field.name)
+
+		if hasDefault {
+			prop["default"] = d.value.Interface()
+		}
+
+		if existing, ok := properties[name]; ok {
+			properties[name] = schemaObject{"allOf": []any{existing, prop}}
+		} else {
+			properties[name] = prop
+		}
+
+		// fields that aren't always validated can't be required
+		if field.condition > 0 || len(field.groups) > 0 || hasDefault || slices.Contains(required, name) {
+			continue
+		}
+
+		if requirer, ok := field.validator.(schemaRequirer); ok && requirer.schemaRequired() {
+			required = append(required, name)
+		}
+	}
+
+	if len(properties) > 0 {
+		s["properties"] = properties
+	}
+
+	if len(required) > 0 {
+		slices.Sort(required)
+		s["required"] = required
+	}
+}
+
+// jsonFieldName returns the name encoding/json uses for a struct field, or false if it skips the field
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	if name == "" {
+		return field.Name, true
+	}
+
+	return name, true
+}
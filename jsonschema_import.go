@@ -0,0 +1,864 @@
+package ensure
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"io"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonValueType is the type string for values decoded by encoding/json into an any
+var jsonValueType = reflect.TypeFor[any]().String()
+
+// jsonSchemaTypes lists the values accepted by the "type" keyword
+var jsonSchemaTypes = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+// jsonSchemaKeywords lists the keywords FromJSONSchema compiles into checks
+var jsonSchemaKeywords = map[string]bool{
+	"$ref":                 true,
+	"type":                 true,
+	"enum":                 true,
+	"const":                true,
+	"minLength":            true,
+	"maxLength":            true,
+	"pattern":              true,
+	"minimum":              true,
+	"maximum":              true,
+	"exclusiveMinimum":     true,
+	"exclusiveMaximum":     true,
+	"multipleOf":           true,
+	"properties":           true,
+	"required":             true,
+	"additionalProperties": true,
+	"propertyNames":        true,
+	"minProperties":        true,
+	"maxProperties":        true,
+	"items":                true,
+	"minItems":             true,
+	"maxItems":             true,
+	"uniqueItems":          true,
+	"contains":             true,
+	"allOf":                true,
+	"anyOf":                true,
+	"not":                  true,
+}
+
+// jsonSchemaAnnotations lists keywords that don't affect validation, so FromJSONSchema ignores them
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"$defs":       true,
+	"definitions": true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+	"format":      true,
+}
+
+// jsonValidator validates values decoded by encoding/json against a compiled JSON Schema
+type jsonValidator struct {
+	checks *valChecks[any]
+}
+
+// newJSONValidator returns a jsonValidator that accepts any value
+func newJSONValidator() *jsonValidator {
+	return &jsonValidator{
+		checks: newValChecks[any](),
+	}
+}
+
+// Type returns the string "interface {}"
+func (v *jsonValidator) Type() string {
+	return jsonValueType
+}
+
+// ValidateUntyped validates a decoded JSON value
+func (v *jsonValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	return v.Validate(value, options...)
+}
+
+// Validate applies all checks against a decoded JSON value
+func (v *jsonValidator) Validate(value any, options ...*with.ValidationOptions) error {
+	return v.checks.Evaluate(value, getValidationOptions(options))
+}
+
+// addTyped adds a check that applies a validator to values of a particular JSON type
+func (v *jsonValidator) addTyped(typ string, tv with.UntypedValidator) {
+	v.checks.Append(func(val any, opts *with.ValidationOptions) error {
+		if jsonType(val) != typ {
+			return nil
+		}
+		return tv.ValidateUntyped(val, opts)
+	})
+	v.checks.Record(rule{name: "if " + typ, nested: tv})
+}
+
+// jsonType returns the JSON Schema type of a value decoded by encoding/json, or its Go type if it isn't one
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonTypeMatches returns true if a decoded JSON value has the provided JSON Schema type
+// An integer is any number without a fractional part
+func jsonTypeMatches(typ string, value any) bool {
+	if typ == "integer" {
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	}
+
+	return jsonType(value) == typ
+}
+
+// FromJSONSchema compiles a JSON Schema document into a validator for values
+// decoded by encoding/json into an any (eg map[string]any, []any, float64)
+// Errors have the same types, codes, and paths as other validators, and options
+// such as with.OptionCollectAllErrors() apply as usual
+// An error is returned if the document isn't a valid schema or uses a keyword
+// that isn't supported, rather than silently accepting values the schema would reject
+func FromJSONSchema(r io.Reader) (with.UntypedValidator, error) {
+	var root any
+
+	if err := json.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("could not decode JSON Schema: %w", err)
+	}
+
+	c := &jsonSchemaCompiler{
+		root:     root,
+		compiled: map[string]*jsonValidator{},
+		inPlace:  map[string][]string{},
+	}
+
+	v, err := c.compile(root, "#")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkCycles(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// jsonSchemaCompiler compiles the schemas in a document, reusing the validator
+// for a location each time it's referenced so recursive schemas are supported
+type jsonSchemaCompiler struct {
+	root     any
+	compiled map[string]*jsonValidator
+
+	// inPlace lists the locations of the schemas that each schema applies to the same
+	// value, through $ref, allOf, anyOf, and not, rather than to a property or an item
+	inPlace map[string][]string
+}
+
+// checkCycles returns an error if a schema applies itself to the same value, such as
+// {"$ref": "#"}, since validating it would never finish
+// Recursion is only allowed through keywords such as properties and items that
+// move on to part of the value, since those end when the value does
+func (c *jsonSchemaCompiler) checkCycles() error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	state := map[string]int{}
+
+	var visit func(ptr string) error
+	visit = func(ptr string) error {
+		switch state[ptr] {
+		case visiting:
+			return fmt.Errorf(`schema at "%s" refers to itself without moving on to a property or item`, ptr)
+		case done:
+			return nil
+		}
+
+		state[ptr] = visiting
+
+		for _, next := range c.inPlace[ptr] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+
+		state[ptr] = done
+		return nil
+	}
+
+	for _, ptr := range slices.Sorted(maps.Keys(c.inPlace)) {
+		if err := visit(ptr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compile returns the validator for the schema at the provided location in the document
+func (c *jsonSchemaCompiler) compile(node any, ptr string) (*jsonValidator, error) {
+	if v, ok := c.compiled[ptr]; ok {
+		return v, nil
+	}
+
+	v := newJSONValidator()
+	c.compiled[ptr] = v
+
+	switch schema := node.(type) {
+	case bool:
+		// false rejects every value, just like {"not": {}}
+		if !schema {
+			v.addNot(newJSONValidator())
+		}
+		return v, nil
+	case map[string]any:
+		return v, c.compileKeywords(v, schema, ptr)
+	default:
+		return nil, fmt.Errorf(`schema at "%s" must be an object or a boolean`, ptr)
+	}
+}
+
+// compileKeywords adds a check to the validator for each keyword in the schema
+func (c *jsonSchemaCompiler) compileKeywords(v *jsonValidator, schema map[string]any, ptr string) error {
+	for _, key := range slices.Sorted(maps.Keys(schema)) {
+		if !jsonSchemaKeywords[key] && !jsonSchemaAnnotations[key] && !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf(`keyword "%s" at "%s" is not supported`, key, ptr)
+		}
+	}
+
+	steps := []func(*jsonValidator, map[string]any, string) error{
+		c.compileRef,
+		compileType,
+		compileEnum,
+		compileString,
+		compileNumber,
+		c.compileObject,
+		c.compileArray,
+		c.compileCombinators,
+	}
+
+	for _, step := range steps {
+		if err := step(v, schema, ptr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compileRef adds a check against the schema referenced by $ref, which must be in the same document
+func (c *jsonSchemaCompiler) compileRef(v *jsonValidator, schema map[string]any, ptr string) error {
+	ref, ok := schema["$ref"]
+
+	if !ok {
+		return nil
+	}
+
+	refStr, ok := ref.(string)
+
+	if !ok || !strings.HasPrefix(refStr, "#") {
+		return fmt.Errorf(`$ref at "%s" must refer to a location in the same document (eg "#/$defs/name")`, ptr)
+	}
+
+	target, err := resolvePointer(c.root, refStr)
+
+	if err != nil {
+		return fmt.Errorf(`$ref at "%s": %w`, ptr, err)
+	}
+
+	refValidator, err := c.compile(target, refStr)
+
+	if err != nil {
+		return err
+	}
+
+	c.inPlace[ptr] = append(c.inPlace[ptr], refStr)
+
+	v.checks.Append(func(val any, opts *with.ValidationOptions) error {
+		return refValidator.Validate(val, opts)
+	})
+	v.checks.Record(rule{name: "$ref", params: map[string]any{"ref": refStr}, nested: refValidator})
+
+	return nil
+}
+
+// resolvePointer returns the value in a document at a location such as "#/$defs/name"
+func resolvePointer(root any, ref string) (any, error) {
+	node := root
+	pointer := strings.TrimPrefix(ref, "#")
+
+	if pointer == "" {
+		return root, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf(`"%s" is not a JSON Pointer`, ref)
+	}
+
+	for _, segment := range strings.Split(pointer[1:], "/") {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+
+		switch n := node.(type) {
+		case map[string]any:
+			next, ok := n[segment]
+
+			if !ok {
+				return nil, fmt.Errorf(`"%s" does not exist`, ref)
+			}
+
+			node = next
+		case []any:
+			idx, err := strconv.Atoi(segment)
+
+			if err != nil || idx < 0 || idx >= len(n) {
+				return nil, fmt.Errorf(`"%s" does not exist`, ref)
+			}
+
+			node = n[idx]
+		default:
+			return nil, fmt.Errorf(`"%s" does not exist`, ref)
+		}
+	}
+
+	return node, nil
+}
+
+// escapePointer escapes a property name for use as a JSON Pointer segment
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// compileType adds a check for the type keyword
+// A value with the wrong type is a problem with the document being validated
+// rather than with the validator, so it fails with a ValidationError instead of a TypeError
+func compileType(v *jsonValidator, schema map[string]any, ptr string) error {
+	typ, ok := schema["type"]
+
+	if !ok {
+		return nil
+	}
+
+	var types []string
+
+	switch t := typ.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			name, ok := item.(string)
+
+			if !ok {
+				return fmt.Errorf(`"type" at "%s" must be a string or an array of strings`, ptr)
+			}
+
+			types = append(types, name)
+		}
+	default:
+		return fmt.Errorf(`"type" at "%s" must be a string or an array of strings`, ptr)
+	}
+
+	for _, name := range types {
+		if !slices.Contains(jsonSchemaTypes, name) {
+			return fmt.Errorf(`"type" at "%s" has unknown type "%s"`, ptr, name)
+		}
+	}
+
+	expected := strings.Join(types, " or ")
+
+	v.checks.Append(func(val any, _ *with.ValidationOptions) error {
+		for _, typ := range types {
+			if jsonTypeMatches(typ, val) {
+				return nil
+			}
+		}
+		return newCheckError(CodeSchemaType, map[string]any{"expected": expected, "actual": jsonType(val)})
+	})
+	v.checks.Record(rule{name: "type", params: map[string]any{"expected": types}})
+
+	return nil
+}
+
+// compileEnum adds checks for the enum and const keywords
+func compileEnum(v *jsonValidator, schema map[string]any, ptr string) error {
+	if enum, ok := schema["enum"]; ok {
+		values, ok := enum.([]any)
+
+		if !ok {
+			return fmt.Errorf(`"enum" at "%s" must be an array`, ptr)
+		}
+
+		v.checks.Append(func(val any, _ *with.ValidationOptions) error {
+			for _, allowed := range values {
+				if reflect.DeepEqual(val, allowed) {
+					return nil
+				}
+			}
+			return newCheckError(CodeSchemaEnum, map[string]any{"expected": values})
+		})
+		v.checks.Record(rule{name: "enum", params: map[string]any{"values": values}})
+	}
+
+	if expected, ok := schema["const"]; ok {
+		v.checks.Append(func(val any, _ *with.ValidationOptions) error {
+			if !reflect.DeepEqual(val, expected) {
+				return newCheckError(CodeSchemaConst, map[string]any{"expected": expected})
+			}
+			return nil
+		})
+		v.checks.Record(rule{name: "const", params: map[string]any{"expected": expected}})
+	}
+
+	return nil
+}
+
+// lengthKeyword returns the value of a keyword that must be a non-negative integer, if it's set
+func lengthKeyword(schema map[string]any, key string, ptr string) (int, bool, error) {
+	val, ok := schema[key]
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	f, isNum := val.(float64)
+
+	if !isNum || f < 0 || f != math.Trunc(f) {
+		return 0, false, fmt.Errorf(`"%s" at "%s" must be a non-negative integer`, key, ptr)
+	}
+
+	return int(f), true, nil
+}
+
+// numberKeyword returns the value of a keyword that must be a number, if it's set
+func numberKeyword(schema map[string]any, key string, ptr string) (float64, bool, error) {
+	val, ok := schema[key]
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	f, isNum := val.(float64)
+
+	if !isNum {
+		return 0, false, fmt.Errorf(`"%s" at "%s" must be a number`, key, ptr)
+	}
+
+	return f, true, nil
+}
+
+// compileString adds a StringValidator for the string keywords
+// Lengths are counted in characters rather than bytes, as JSON Schema requires
+func compileString(v *jsonValidator, schema map[string]any, ptr string) error {
+	sv := String()
+	used := false
+
+	if l, ok, err := lengthKeyword(schema, "minLength", ptr); err != nil {
+		return err
+	} else if ok {
+		used = true
+		sv.Is(func(str string) error {
+			if count := utf8.RuneCountInString(str); count < l {
+				return newCheckError(CodeStringLengthGreater, map[string]any{"expected": l - 1, "actual": count})
+			}
+			return nil
+		})
+	}
+
+	if l, ok, err := lengthKeyword(schema, "maxLength", ptr); err != nil {
+		return err
+	} else if ok {
+		used = true
+		sv.Is(func(str string) error {
+			if count := utf8.RuneCountInString(str); count > l {
+				return newCheckError(CodeStringLengthLess, map[string]any{"expected": l + 1, "actual": count})
+			}
+			return nil
+		})
+	}
+
+	// Patterns that JSONSchema exported with Go syntax are checked the same way
+	for _, key := range []string{"pattern", schemaGoPatternKeyword} {
+		pattern, ok := schema[key]
+
+		if !ok {
+			continue
+		}
+
+		str, isStr := pattern.(string)
+
+		if !isStr {
+			return fmt.Errorf(`"%s" at "%s" must be a string`, key, ptr)
+		}
+
+		if _, err := regexp.Compile(str); err != nil {
+			return fmt.Errorf(`"%s" at "%s" is not supported: %w`, key, ptr, err)
+		}
+
+		used = true
+		sv.Matches(str)
+	}
+
+	if used {
+		v.addTyped("string", sv)
+	}
+
+	return nil
+}
+
+// compileNumber adds a NumberValidator for the number keywords
+func compileNumber(v *jsonValidator, schema map[string]any, ptr string) error {
+	nv := Number[float64]()
+	used := false
+
+	bounds := []struct {
+		key string
+		add func(float64) *NumberValidator[float64]
+	}{
+		{"minimum", nv.IsGreaterThanOrEqualTo},
+		{"maximum", nv.IsLessThanOrEqualTo},
+		{"exclusiveMinimum", nv.IsGreaterThan},
+		{"exclusiveMaximum", nv.IsLessThan},
+	}
+
+	for _, bound := range bounds {
+		n, ok, err := numberKeyword(schema, bound.key, ptr)
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			used = true
+			bound.add(n)
+		}
+	}
+
+	if n, ok, err := numberKeyword(schema, "multipleOf", ptr); err != nil {
+		return err
+	} else if ok {
+		if n <= 0 {
+			return fmt.Errorf(`"multipleOf" at "%s" must be greater than 0`, ptr)
+		}
+
+		used = true
+		nv.addRule(rule{name: "IsMultipleOf", params: map[string]any{"expected": n}}, func(f float64) error {
+			if q := f / n; q != math.Trunc(q) {
+				return newCheckError(CodeSchemaMultipleOf, map[string]any{"expected": n, "actual": f})
+			}
+			return nil
+		})
+	}
+
+	if used {
+		v.addTyped("number", nv)
+	}
+
+	return nil
+}
+
+// compileObject adds an ObjectValidator for properties and a MapValidator for the number of properties
+func (c *jsonSchemaCompiler) compileObject(v *jsonValidator, schema map[string]any, ptr string) error {
+	ov := Object()
+	used := false
+	required := map[string]bool{}
+
+	if req, ok := schema["required"]; ok {
+		keys, isArr := req.([]any)
+
+		if !isArr {
+			return fmt.Errorf(`"required" at "%s" must be an array of strings`, ptr)
+		}
+
+		for _, key := range keys {
+			name, isStr := key.(string)
+
+			if !isStr {
+				return fmt.Errorf(`"required" at "%s" must be an array of strings`, ptr)
+			}
+
+			required[name] = true
+		}
+	}
+
+	if props, ok := schema["properties"]; ok {
+		properties, isObj := props.(map[string]any)
+
+		if !isObj {
+			return fmt.Errorf(`"properties" at "%s" must be an object`, ptr)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(properties)) {
+			prop, err := c.compile(properties[name], ptr+"/properties/"+escapePointer(name))
+
+			if err != nil {
+				return err
+			}
+
+			used = true
+
+			if required[name] {
+				ov.RequiredKey(name, prop)
+				delete(required, name)
+			} else {
+				ov.OptionalKey(name, prop)
+			}
+		}
+	}
+
+	// required keys without a schema of their own can have any value
+	for _, name := range slices.Sorted(maps.Keys(required)) {
+		used = true
+		ov.RequiredKey(name, newJSONValidator())
+	}
+
+	if additional, ok := schema["additionalProperties"]; ok {
+		switch a := additional.(type) {
+		case bool:
+			if !a {
+				used = true
+				ov.NoAdditionalKeys()
+			}
+		default:
+			av, err := c.compile(additional, ptr+"/additionalProperties")
+
+			if err != nil {
+				return err
+			}
+
+			used = true
+			ov.AdditionalKeys(av)
+		}
+	}
+
+	if used {
+		v.addTyped("object", ov)
+	}
+
+	mv := Map[string, any]()
+	used = false
+
+	if names, ok := schema["propertyNames"]; ok {
+		nv, err := c.compile(names, ptr+"/propertyNames")
+
+		if err != nil {
+			return err
+		}
+
+		used = true
+		mv.checks.AddIterKeyCheck(func(key string, opts *with.ValidationOptions) error {
+			return nv.Validate(key, opts)
+		})
+		mv.checks.Record(rule{name: "EachKey", nested: nv})
+	}
+
+	if l, ok, err := lengthKeyword(schema, "minProperties", ptr); err != nil {
+		return err
+	} else if ok && l > 0 {
+		used = true
+		mv.HasMoreThan(l - 1)
+	}
+
+	if l, ok, err := lengthKeyword(schema, "maxProperties", ptr); err != nil {
+		return err
+	} else if ok {
+		used = true
+		mv.HasFewerThan(l + 1)
+	}
+
+	if used {
+		v.addTyped("object", mv)
+	}
+
+	return nil
+}
+
+// compileArray adds an ArrayValidator for the array keywords
+func (c *jsonSchemaCompiler) compileArray(v *jsonValidator, schema map[string]any, ptr string) error {
+	av := Array[any]()
+	used := false
+
+	if l, ok, err := lengthKeyword(schema, "minItems", ptr); err != nil {
+		return err
+	} else if ok && l > 0 {
+		used = true
+		av.HasMoreThan(l - 1)
+	}
+
+	if l, ok, err := lengthKeyword(schema, "maxItems", ptr); err != nil {
+		return err
+	} else if ok {
+		used = true
+		av.HasFewerThan(l + 1)
+	}
+
+	if items, ok := schema["items"]; ok {
+		// the array form of items was replaced by prefixItems in 2020-12
+		if _, isArr := items.([]any); isArr {
+			return fmt.Errorf(`"items" at "%s" must be a schema; arrays of schemas are not supported`, ptr)
+		}
+
+		iv, err := c.compile(items, ptr+"/items")
+
+		if err != nil {
+			return err
+		}
+
+		used = true
+		av.Each(iv)
+	}
+
+	if unique, ok := schema["uniqueItems"]; ok {
+		b, isBool := unique.(bool)
+
+		if !isBool {
+			return fmt.Errorf(`"uniqueItems" at "%s" must be a boolean`, ptr)
+		}
+
+		if b {
+			used = true
+			av.checks.Append(func(val []any, _ *with.ValidationOptions) error {
+				for idx, item := range val {
+					if slices.ContainsFunc(val[:idx], func(prev any) bool { return reflect.DeepEqual(prev, item) }) {
+						return newCheckError(CodeArrayUnique, map[string]any{"actual": item})
+					}
+				}
+				return nil
+			})
+			av.checks.Record(rule{name: "ContainsNoDuplicates"})
+		}
+	}
+
+	if contains, ok := schema["contains"]; ok {
+		cv, err := c.compile(contains, ptr+"/contains")
+
+		if err != nil {
+			return err
+		}
+
+		used = true
+		av.checks.Append(func(val []any, opts *with.ValidationOptions) error {
+			for _, item := range val {
+				if cv.Validate(item, opts) == nil {
+					return nil
+				}
+			}
+			return newCheckError(CodeSchemaContains, nil)
+		})
+		av.checks.Record(rule{name: "contains", nested: cv})
+	}
+
+	if used {
+		v.addTyped("array", av)
+	}
+
+	return nil
+}
+
+// compileSchemaList compiles the schemas in a keyword such as allOf, which must be a non-empty array
+// The schemas are applied to the same value as the schema they're in
+func (c *jsonSchemaCompiler) compileSchemaList(schema map[string]any, key string, ptr string) ([]with.Validator[any], error) {
+	list, ok := schema[key].([]any)
+
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf(`"%s" at "%s" must be a non-empty array of schemas`, key, ptr)
+	}
+
+	validators := make([]with.Validator[any], 0, len(list))
+
+	for idx, item := range list {
+		itemPtr := fmt.Sprintf("%s/%s/%d", ptr, key, idx)
+		iv, err := c.compile(item, itemPtr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, iv)
+		c.inPlace[ptr] = append(c.inPlace[ptr], itemPtr)
+	}
+
+	return validators, nil
+}
+
+// compileCombinators adds checks for the allOf, anyOf, and not keywords
+func (c *jsonSchemaCompiler) compileCombinators(v *jsonValidator, schema map[string]any, ptr string) error {
+	if _, ok := schema["allOf"]; ok {
+		validators, err := c.compileSchemaList(schema, "allOf", ptr)
+
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range validators {
+			v.checks.Append(func(val any, opts *with.ValidationOptions) error {
+				return sub.Validate(val, opts)
+			})
+			v.checks.Record(rule{name: "allOf", nested: sub})
+		}
+	}
+
+	if _, ok := schema["anyOf"]; ok {
+		validators, err := c.compileSchemaList(schema, "anyOf", ptr)
+
+		if err != nil {
+			return err
+		}
+
+		anyOf := Any[any](validators...)
+
+		v.checks.Append(func(val any, opts *with.ValidationOptions) error {
+			return anyOf.Validate(val, opts)
+		})
+		v.checks.Record(rule{name: "anyOf", nested: anyOf})
+	}
+
+	if not, ok := schema["not"]; ok {
+		nv, err := c.compile(not, ptr+"/not")
+
+		if err != nil {
+			return err
+		}
+
+		c.inPlace[ptr] = append(c.inPlace[ptr], ptr+"/not")
+		v.addNot(nv)
+	}
+
+	return nil
+}
+
+// addNot adds a check that fails if the value passes the provided validator
+func (v *jsonValidator) addNot(nv *jsonValidator) {
+	v.checks.Append(func(val any, opts *with.ValidationOptions) error {
+		if err := nv.Validate(val, opts); err == nil {
+			return newCheckError(CodeSchemaNot, nil)
+		}
+		return nil
+	})
+	v.checks.Record(rule{name: "not", nested: nv})
+}
@@ -0,0 +1,327 @@
+package ensure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mustFromJSONSchema compiles a schema, panicking if it isn't valid
+func mustFromJSONSchema(schema string) with.UntypedValidator {
+	v, err := ensure.FromJSONSchema(strings.NewReader(schema))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// decodeJSON decodes a JSON document the same way a payload would be
+func decodeJSON(t *testing.T, doc string) any {
+	t.Helper()
+
+	var value any
+
+	if err := json.Unmarshal([]byte(doc), &value); err != nil {
+		t.Fatalf("invalid test value: %s", err)
+	}
+
+	return value
+}
+
+func TestFromJSONSchema_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		schema string
+		value  string
+		code   string
+	}{
+		"true schema":            {`true`, `{"a": 1}`, ""},
+		"false schema":           {`false`, `1`, ensure.CodeSchemaNot},
+		"empty schema":           {`{}`, `null`, ""},
+		"type match":             {`{"type": "string"}`, `"a"`, ""},
+		"type mismatch":          {`{"type": "string"}`, `1`, ensure.CodeSchemaType},
+		"type list":              {`{"type": ["string", "null"]}`, `null`, ""},
+		"type list mismatch":     {`{"type": ["string", "null"]}`, `true`, ensure.CodeSchemaType},
+		"integer":                {`{"type": "integer"}`, `2.0`, ""},
+		"integer fraction":       {`{"type": "integer"}`, `2.5`, ensure.CodeSchemaType},
+		"integer not number":     {`{"type": "integer"}`, `"2"`, ensure.CodeSchemaType},
+		"boolean":                {`{"type": "boolean"}`, `false`, ""},
+		"array":                  {`{"type": "array"}`, `[]`, ""},
+		"object":                 {`{"type": "object"}`, `[]`, ensure.CodeSchemaType},
+		"enum":                   {`{"enum": ["a", 1, {"b": [2]}]}`, `{"b": [2]}`, ""},
+		"enum mismatch":          {`{"enum": ["a", 1]}`, `"b"`, ensure.CodeSchemaEnum},
+		"const":                  {`{"const": [1, 2]}`, `[1, 2]`, ""},
+		"const mismatch":         {`{"const": "a"}`, `"b"`, ensure.CodeSchemaConst},
+		"min length":             {`{"minLength": 2}`, `"ab"`, ""},
+		"min length runes":       {`{"minLength": 2}`, `"é"`, ensure.CodeStringLengthGreater},
+		"max length runes":       {`{"maxLength": 1}`, `"é"`, ""},
+		"max length":             {`{"maxLength": 1}`, `"ab"`, ensure.CodeStringLengthLess},
+		"length on other types":  {`{"minLength": 2}`, `1`, ""},
+		"pattern":                {`{"pattern": "^\\d+$"}`, `"123"`, ""},
+		"pattern mismatch":       {`{"pattern": "^\\d+$"}`, `"12a"`, ensure.CodeStringMatches},
+		"go pattern":             {`{"x-go-pattern": "(?i)^abc$"}`, `"ABC"`, ""},
+		"go pattern mismatch":    {`{"x-go-pattern": "(?i)^abc$"}`, `"abd"`, ensure.CodeStringMatches},
+		"minimum":                {`{"minimum": 2}`, `2`, ""},
+		"minimum mismatch":       {`{"minimum": 2}`, `1.5`, ensure.CodeNumberGreaterOrEqual},
+		"maximum mismatch":       {`{"maximum": 2}`, `3`, ensure.CodeNumberLessOrEqual},
+		"exclusive minimum":      {`{"exclusiveMinimum": 2}`, `2`, ensure.CodeNumberGreater},
+		"exclusive maximum":      {`{"exclusiveMaximum": 2}`, `2`, ensure.CodeNumberLess},
+		"multiple of":            {`{"multipleOf": 2}`, `4`, ""},
+		"multiple of fraction":   {`{"multipleOf": 0.5}`, `1.5`, ""},
+		"multiple of mismatch":   {`{"multipleOf": 2}`, `3`, ensure.CodeSchemaMultipleOf},
+		"minimum on other types": {`{"minimum": 2}`, `"a"`, ""},
+		"required":               {`{"required": ["a"]}`, `{"a": null}`, ""},
+		"required missing":       {`{"required": ["a", "a"]}`, `{}`, ensure.CodeObjectRequiredKey},
+		"required on other":      {`{"required": ["a"]}`, `[]`, ""},
+		"property":               {`{"properties": {"a": {"type": "string"}}}`, `{"a": "b"}`, ""},
+		"property optional":      {`{"properties": {"a": {"type": "string"}}}`, `{"b": 1}`, ""},
+		"property mismatch":      {`{"properties": {"a": {"type": "string"}}}`, `{"a": 1}`, ensure.CodeSchemaType},
+		"property required":      {`{"properties": {"a": true}, "required": ["a"]}`, `{}`, ensure.CodeObjectRequiredKey},
+		"no additional":          {`{"properties": {"a": true}, "additionalProperties": false}`, `{"a": 1, "b": 2}`, ensure.CodeObjectAdditionalKey},
+		"additional allowed":     {`{"additionalProperties": true}`, `{"b": 2}`, ""},
+		"additional schema":      {`{"additionalProperties": {"type": "number"}}`, `{"b": "c"}`, ensure.CodeSchemaType},
+		"min properties":         {`{"minProperties": 1}`, `{}`, ensure.CodeMapLengthGreater},
+		"min properties zero":    {`{"minProperties": 0}`, `{}`, ""},
+		"max properties":         {`{"maxProperties": 1}`, `{"a": 1, "b": 2}`, ensure.CodeMapLengthLess},
+		"property names":         {`{"propertyNames": {"maxLength": 2}}`, `{"ab": 1}`, ""},
+		"property name mismatch": {`{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`, ensure.CodeStringLengthLess},
+		"items":                  {`{"items": {"type": "number"}}`, `[1, 2]`, ""},
+		"items mismatch":         {`{"items": {"type": "number"}}`, `[1, "2"]`, ensure.CodeSchemaType},
+		"items false":            {`{"items": false}`, `[1]`, ensure.CodeSchemaNot},
+		"min items":              {`{"minItems": 1}`, `[]`, ensure.CodeArrayLengthGreater},
+		"min items zero":         {`{"minItems": 0}`, `[]`, ""},
+		"max items":              {`{"maxItems": 1}`, `[1, 2]`, ensure.CodeArrayLengthLess},
+		"unique items":           {`{"uniqueItems": true}`, `[1, "1", {"a": 1}, {"a": 2}]`, ""},
+		"unique items mismatch":  {`{"uniqueItems": true}`, `[{"a": 1}, {"a": 1}]`, ensure.CodeArrayUnique},
+		"unique items false":     {`{"uniqueItems": false}`, `[1, 1]`, ""},
+		"contains":               {`{"contains": {"type": "string"}}`, `[1, "a"]`, ""},
+		"contains mismatch":      {`{"contains": {"type": "string"}}`, `[1]`, ensure.CodeSchemaContains},
+		"contains empty":         {`{"contains": true}`, `[]`, ensure.CodeSchemaContains},
+		"all of":                 {`{"allOf": [{"minLength": 1}, {"maxLength": 3}]}`, `"ab"`, ""},
+		"all of mismatch":        {`{"allOf": [{"minLength": 1}, {"maxLength": 3}]}`, `"abcd"`, ensure.CodeStringLengthLess},
+		"any of":                 {`{"anyOf": [{"type": "string"}, {"type": "number"}]}`, `1`, ""},
+		"any of mismatch":        {`{"anyOf": [{"type": "string"}, {"type": "number"}]}`, `true`, ensure.CodeAnyNone},
+		"not":                    {`{"not": {"type": "string"}}`, `1`, ""},
+		"not mismatch":           {`{"not": {"type": "string"}}`, `"a"`, ensure.CodeSchemaNot},
+		"ref":                    {`{"$defs": {"id": {"type": "integer"}}, "$ref": "#/$defs/id"}`, `1.5`, ensure.CodeSchemaType},
+		"ref escaped":            {`{"$defs": {"a/b~c": {"type": "integer"}}, "$ref": "#/$defs/a~1b~0c"}`, `1.5`, ensure.CodeSchemaType},
+		"ref array index":        {`{"allOf": [{"type": "string"}], "items": {"$ref": "#/allOf/0"}}`, `["a", 1]`, ensure.CodeSchemaType},
+		"ref root":               {`{"items": {"$ref": "#"}, "maxItems": 1}`, `[[1, 2]]`, ensure.CodeArrayLengthLess},
+		"annotations":            {`{"$schema": "x", "title": "t", "format": "email", "x-owner": "team"}`, `"a"`, ""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := mustFromJSONSchema(tc.schema).ValidateUntyped(decodeJSON(t, tc.value))
+
+			if tc.code == "" {
+				if err != nil {
+					t.Errorf(`expected no error; got "%s"`, err)
+				}
+				return
+			}
+
+			vErrs := ensure.NewValidationErrors()
+			vErrs.Append(err)
+
+			if len(vErrs.ValidationErrors()) != 1 {
+				t.Fatalf(`expected a single validation error; got "%v"`, err)
+			}
+
+			if code := vErrs.ValidationErrors()[0].Code(); code != tc.code {
+				t.Errorf(`expected code "%s"; got "%s"`, tc.code, code)
+			}
+		})
+	}
+}
+
+func TestFromJSONSchema_Paths(t *testing.T) {
+	v := mustFromJSONSchema(`{
+		"$defs": {
+			"pet": {
+				"type": "object",
+				"properties": {"name": {"type": "string", "minLength": 1}},
+				"required": ["name"]
+			}
+		},
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"pets": {"type": "array", "items": {"$ref": "#/$defs/pet"}}
+		},
+		"required": ["name", "pets"]
+	}`)
+
+	value := decodeJSON(t, `{"name": 1, "pets": [{"name": "Rex"}, {"name": ""}, {}]}`)
+
+	err := v.ValidateUntyped(value, with.Options(with.OptionCollectAllErrors()))
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil {
+		t.Fatalf(`expected validation errors; got "%v"`, err)
+	}
+
+	var got []string
+
+	for _, e := range vErrs.ValidationErrors() {
+		got = append(got, e.Path().JSONPointer()+" "+e.Code())
+	}
+
+	expect := []string{
+		"/name schema.type",
+		"/pets/1/name string.length.gt",
+		"/pets/2/name object.required",
+	}
+
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected errors %v; got %v", expect, got)
+	}
+
+	if err := v.ValidateUntyped(decodeJSON(t, `{"name": "Ann", "pets": []}`)); err != nil {
+		t.Errorf(`expected no error; got "%s"`, err)
+	}
+}
+
+func TestFromJSONSchema_Recursive(t *testing.T) {
+	v := mustFromJSONSchema(`{
+		"$defs": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "integer"},
+					"next": {"anyOf": [{"type": "null"}, {"$ref": "#/$defs/node"}]}
+				}
+			}
+		},
+		"$ref": "#/$defs/node"
+	}`)
+
+	if err := v.ValidateUntyped(decodeJSON(t, `{"value": 1, "next": {"value": 2, "next": null}}`)); err != nil {
+		t.Errorf(`expected no error; got "%s"`, err)
+	}
+
+	if err := v.ValidateUntyped(decodeJSON(t, `{"value": 1, "next": {"value": "2"}}`)); err == nil {
+		t.Errorf("expected an error")
+	}
+
+	// values that didn't come from encoding/json are reported with their Go type
+	vErr := &ensure.ValidationError{}
+
+	if err := v.ValidateUntyped(1); !errors.As(err, &vErr) || vErr.Params()["actual"] != "int" {
+		t.Errorf(`expected a type error for "int"; got "%v"`, err)
+	}
+
+	if v.Type() != "interface {}" {
+		t.Errorf(`expected type "interface {}"; got "%s"`, v.Type())
+	}
+}
+
+func TestFromJSONSchema_Error(t *testing.T) {
+	testCases := map[string]string{
+		"invalid json":           `{`,
+		"not a schema":           `1`,
+		"unsupported keyword":    `{"oneOf": [true]}`,
+		"nested unsupported":     `{"properties": {"a": {"if": true}}}`,
+		"ref not string":         `{"$ref": 1}`,
+		"remote ref":             `{"$ref": "other.json#/a"}`,
+		"ref not pointer":        `{"$ref": "#a"}`,
+		"ref missing":            `{"$ref": "#/$defs/a"}`,
+		"ref missing index":      `{"allOf": [true], "not": {"$ref": "#/allOf/1"}}`,
+		"ref bad index":          `{"allOf": [true], "not": {"$ref": "#/allOf/a"}}`,
+		"ref through scalar":     `{"title": "a", "not": {"$ref": "#/title/a"}}`,
+		"ref invalid target":     `{"title": "a", "not": {"$ref": "#/title"}}`,
+		"type not string":        `{"type": 1}`,
+		"type list not strings":  `{"type": [1]}`,
+		"unknown type":           `{"type": "float"}`,
+		"enum not array":         `{"enum": "a"}`,
+		"min length not number":  `{"minLength": "1"}`,
+		"max length negative":    `{"maxLength": -1}`,
+		"min items fraction":     `{"minItems": 1.5}`,
+		"max items invalid":      `{"maxItems": "1"}`,
+		"min properties invalid": `{"minProperties": "1"}`,
+		"max properties invalid": `{"maxProperties": "1"}`,
+		"pattern not string":     `{"pattern": 1}`,
+		"pattern not supported":  `{"pattern": "(?=a)"}`,
+		"go pattern not string":  `{"x-go-pattern": 1}`,
+		"minimum not number":     `{"minimum": "1"}`,
+		"required not array":     `{"required": "a"}`,
+		"required not strings":   `{"required": [1]}`,
+		"properties not object":  `{"properties": []}`,
+		"invalid property":       `{"properties": {"a": 1}}`,
+		"invalid additional":     `{"additionalProperties": 1}`,
+		"items array":            `{"items": [true]}`,
+		"invalid items":          `{"items": 1}`,
+		"all of empty":           `{"allOf": []}`,
+		"all of invalid":         `{"allOf": [1]}`,
+		"any of not array":       `{"anyOf": {}}`,
+		"any of invalid":         `{"anyOf": [1]}`,
+		"invalid not":            `{"not": 1}`,
+		"nested ref error":       `{"$defs": {"a": {"oneOf": []}}, "$ref": "#/$defs/a"}`,
+		"multiple of not number": `{"multipleOf": "2"}`,
+		"multiple of zero":       `{"multipleOf": 0}`,
+		"unique items not bool":  `{"uniqueItems": 1}`,
+		"invalid contains":       `{"contains": 1}`,
+		"invalid property names": `{"propertyNames": 1}`,
+		"ref cycle":              `{"$ref": "#"}`,
+		"all of cycle":           `{"allOf": [{"$ref": "#"}]}`,
+		"any of cycle":           `{"anyOf": [true, {"$ref": "#"}]}`,
+		"not cycle":              `{"$defs": {"a": {"not": {"$ref": "#/$defs/b"}}, "b": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+		"cycle through property": `{"$defs": {"a": {"allOf": [{"$ref": "#"}]}}, "properties": {"b": {"$ref": "#/$defs/a"}}, "allOf": [{"$ref": "#/$defs/a"}]}`,
+	}
+
+	for name, schema := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ensure.FromJSONSchema(strings.NewReader(schema)); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+// TestFromJSONSchema_RoundTrip checks that schemas exported by JSONSchema() can be imported,
+// and that the imported validator accepts and rejects the same values as the original
+func TestFromJSONSchema_RoundTrip(t *testing.T) {
+	testCases := map[string]struct {
+		validator with.UntypedValidator
+		valid     string
+		invalid   string
+	}{
+		"unique":      {ensure.ComparableArray[int]().ContainsNoDuplicates(), `[1, 2]`, `[1, 1]`},
+		"contains":    {ensure.ComparableArray[int]().Contains(1), `[2, 1]`, `[2]`},
+		"even":        {ensure.Number[int]().IsEven(), `2`, `3`},
+		"odd":         {ensure.Number[int]().IsOdd(), `3`, `2`},
+		"duration":    {ensure.Duration().IsMultipleOf(time.Second), `2000000000`, `1`},
+		"key lengths": {ensure.Map[string, int]().EachKey(ensure.String().HasLength(2)), `{"ab": 1}`, `{"a": 1}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schema, err := ensure.JSONSchema(tc.validator)
+
+			if err != nil {
+				t.Fatalf(`expected no error; got "%s"`, err)
+			}
+
+			v, err := ensure.FromJSONSchema(bytes.NewReader(schema))
+
+			if err != nil {
+				t.Fatalf(`expected the exported schema to import; got "%s"`, err)
+			}
+
+			if err := v.ValidateUntyped(decodeJSON(t, tc.valid)); err != nil {
+				t.Errorf(`expected no error for %s; got "%s"`, tc.valid, err)
+			}
+
+			if err := v.ValidateUntyped(decodeJSON(t, tc.invalid)); err == nil {
+				t.Errorf("expected an error for %s", tc.invalid)
+			}
+		})
+	}
+}
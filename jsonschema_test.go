@@ -0,0 +1,449 @@
+package ensure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string  `json:"street"`
+	Unit   *string `json:"unit,omitempty"`
+}
+
+type schemaUser struct {
+	Name     string         `json:"name"`
+	Nickname string         `json:",omitempty"`
+	Password string         `json:"-"`
+	Email    *string        `json:"email"`
+	Phone    *string        `json:"phone"`
+	Address  *schemaAddress `json:"address"`
+	Start    int
+	End      int
+}
+
+func (u schemaUser) GetName() string {
+	return u.Name
+}
+
+type schemaNode struct {
+	Value string      `json:"value"`
+	Next  *schemaNode `json:"next"`
+}
+
+type schemaTree struct {
+	Left  *schemaNode `json:"left"`
+	Right *schemaNode `json:"right"`
+}
+
+// expectSchema fails the test if the schema generated for v doesn't match the expected JSON
+// The $schema keyword is checked separately, so it can be left out of expect
+func expectSchema(t *testing.T, v with.UntypedValidator, expect string) {
+	t.Helper()
+
+	out, err := ensure.JSONSchema(v)
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	var got, want map[string]any
+
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("schema is not valid JSON: %s", err)
+	}
+
+	if err := json.Unmarshal([]byte(expect), &want); err != nil {
+		t.Fatalf("invalid expected schema: %s", err)
+	}
+
+	if got["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf(`expected 2020-12 dialect; got "%v"`, got["$schema"])
+	}
+
+	delete(got, "$schema")
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected schema\n%s\ngot\n%s", expect, out)
+	}
+}
+
+func TestJSONSchema_String(t *testing.T) {
+	testCases := map[string]struct {
+		validator *ensure.StringValidator
+		expect    string
+	}{
+		"no rules":            {ensure.String(), `{"type": "string"}`},
+		"equals":              {ensure.String().Equals("a"), `{"type": "string", "const": "a"}`},
+		"does not equal":      {ensure.String().DoesNotEqual("a"), `{"type": "string", "not": {"const": "a"}}`},
+		"starts with":         {ensure.String().StartsWith("a."), `{"type": "string", "pattern": "^a\\."}`},
+		"does not start":      {ensure.String().DoesNotStartWith("a"), `{"type": "string", "not": {"pattern": "^a"}}`},
+		"ends with":           {ensure.String().EndsWith("a"), `{"type": "string", "pattern": "a$"}`},
+		"does not end":        {ensure.String().DoesNotEndWith("a"), `{"type": "string", "not": {"pattern": "a$"}}`},
+		"contains":            {ensure.String().Contains("a+"), `{"type": "string", "pattern": "a\\+"}`},
+		"does not contain":    {ensure.String().DoesNotContain("a"), `{"type": "string", "not": {"pattern": "a"}}`},
+		"one of":              {ensure.String().IsOneOf([]string{"a", "b"}), `{"type": "string", "enum": ["a", "b"]}`},
+		"not one of":          {ensure.String().IsNotOneOf([]string{"a"}), `{"type": "string", "not": {"enum": ["a"]}}`},
+		"matches":             {ensure.String().Matches(ensure.Numbers), `{"type": "string", "pattern": "^\\d+$"}`},
+		"matches named group": {ensure.String().Matches(`^(?:a|(?<b>b))$`), `{"type": "string", "pattern": "^(?:a|(?<b>b))$"}`},
+		"matches go flags":    {ensure.String().Matches(`(?i)^abc$`), `{"type": "string", "x-go-pattern": "(?i)^abc$"}`},
+		"matches go group":    {ensure.String().Matches(`^(?P<a>a)$`), `{"type": "string", "x-go-pattern": "^(?P<a>a)$"}`},
+		"matches go escape":   {ensure.String().Matches(`\Aa\z`), `{"type": "string", "x-go-pattern": "\\Aa\\z"}`},
+		"matches go class":    {ensure.String().Matches(`^[[:alpha:]]+$`), `{"type": "string", "x-go-pattern": "^[[:alpha:]]+$"}`},
+		"matches go hex":      {ensure.String().Matches(`\x{41}`), `{"type": "string", "x-go-pattern": "\\x{41}"}`},
+		"matches escapes":     {ensure.String().Matches(`\x41\.\\`), `{"type": "string", "pattern": "\\x41\\.\\\\"}`},
+		"empty":               {ensure.String().IsEmpty(), `{"type": "string", "maxLength": 0}`},
+		"not empty":           {ensure.String().IsNotEmpty(), `{"type": "string", "minLength": 1}`},
+		"length":              {ensure.String().HasLength(3), `{"type": "string", "minLength": 3, "maxLength": 3}`},
+		"longer than":         {ensure.String().IsLongerThan(3), `{"type": "string", "minLength": 4}`},
+		"shorter than":        {ensure.String().IsShorterThan(3), `{"type": "string", "maxLength": 2}`},
+		"shorter than zero":   {ensure.String().IsShorterThan(0), `{"type": "string", "not": {}}`},
+		"repeated keyword":    {ensure.String().StartsWith("a").EndsWith("b"), `{"type": "string", "pattern": "^a", "allOf": [{"pattern": "b$"}]}`},
+		"is":                  {ensure.String().Is(func(string) error { return nil }), `{"type": "string", "x-ensure-rules": [{"rule": "Is"}]}`},
+		"length where range":  {ensure.String().HasLengthWhere(ensure.Length().IsInRange(2, 5)), `{"type": "string", "minLength": 2, "maxLength": 4}`},
+		"length where bounds": {
+			ensure.String().HasLengthWhere(ensure.Length().IsGreaterThanOrEqualTo(2).IsLessThanOrEqualTo(5)),
+			`{"type": "string", "minLength": 2, "maxLength": 5}`,
+		},
+		"length where exclusive": {
+			ensure.String().HasLengthWhere(ensure.Length().IsGreaterThan(2).IsLessThan(5)),
+			`{"type": "string", "minLength": 3, "maxLength": 4}`,
+		},
+		"length where equals": {
+			ensure.String().HasLengthWhere(ensure.Length().Equals(2)),
+			`{"type": "string", "minLength": 2, "maxLength": 2}`,
+		},
+		"length where other": {
+			ensure.String().HasLengthWhere(ensure.Length().IsPositive().IsEven()),
+			`{
+				"type": "string",
+				"minLength": 1,
+				"x-ensure-rules": [{
+					"rule": "HasLengthWhere",
+					"schema": {"type": "integer", "exclusiveMinimum": 0, "multipleOf": 2}
+				}]
+			}`,
+		},
+		"normalized": {
+			ensure.String().Trim().HasLength(3).ToLower().Matches(`^[a-z]+$`),
+			`{
+				"type": "string",
+				"x-ensure-rules": [
+					{"rule": "Trim"},
+					{"rule": "HasLength", "params": {"expected": 3}},
+					{"rule": "ToLower"},
+					{"rule": "Matches", "params": {"pattern": "^[a-z]+$"}}
+				]
+			}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expectSchema(t, tc.validator, tc.expect)
+		})
+	}
+}
+
+func TestJSONSchema_StringLengthUnits(t *testing.T) {
+	// String() counts bytes, but JSON Schema lengths count characters, so a schema can
+	// accept strings with multibyte characters that the validator rejects
+	sv := ensure.String().HasLength(3)
+
+	expectSchema(t, sv, `{"type": "string", "minLength": 3, "maxLength": 3}`)
+
+	out, _ := ensure.JSONSchema(sv)
+	compiled, err := ensure.FromJSONSchema(bytes.NewReader(out))
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	for str, bytesOk := range map[string]bool{"abc": true, "äöü": false} {
+		if err := sv.Validate(str); (err == nil) != bytesOk {
+			t.Errorf(`expected validator to accept "%s" (%t); got "%v"`, str, bytesOk, err)
+		}
+
+		if err := compiled.ValidateUntyped(str); err != nil {
+			t.Errorf(`expected schema to accept "%s"; got "%s"`, str, err)
+		}
+	}
+}
+
+func TestJSONSchema_StringNormalizers(t *testing.T) {
+	// Keywords would be checked before the string is trimmed, so the schema
+	// mustn't reject values the validator accepts once they're normalized
+	sv := ensure.String().Trim().HasLength(3)
+
+	if err := sv.Validate(" abc "); err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	out, _ := ensure.JSONSchema(sv)
+	compiled, err := ensure.FromJSONSchema(bytes.NewReader(out))
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	if err := compiled.ValidateUntyped(" abc "); err != nil {
+		t.Errorf(`expected schema to accept " abc "; got "%s"`, err)
+	}
+}
+
+func TestJSONSchema_Number(t *testing.T) {
+	testCases := map[string]struct {
+		validator with.UntypedValidator
+		expect    string
+	}{
+		"int":             {ensure.Number[int](), `{"type": "integer"}`},
+		"float":           {ensure.Number[float64](), `{"type": "number"}`},
+		"equals":          {ensure.Number[int]().Equals(1), `{"type": "integer", "const": 1}`},
+		"does not equal":  {ensure.Number[int]().IsNotZero(), `{"type": "integer", "not": {"const": 0}}`},
+		"less":            {ensure.Number[int]().IsLessThan(1), `{"type": "integer", "exclusiveMaximum": 1}`},
+		"less or equal":   {ensure.Number[int]().IsLessThanOrEqualTo(1), `{"type": "integer", "maximum": 1}`},
+		"greater":         {ensure.Number[int]().IsGreaterThan(1), `{"type": "integer", "exclusiveMinimum": 1}`},
+		"greater or eq":   {ensure.Number[int]().IsGreaterThanOrEqualTo(1), `{"type": "integer", "minimum": 1}`},
+		"range":           {ensure.Number[float64]().IsInRange(0, 1.5), `{"type": "number", "minimum": 0, "exclusiveMaximum": 1.5}`},
+		"one of":          {ensure.Number[int]().IsOneOf([]int{1, 2}), `{"type": "integer", "enum": [1, 2]}`},
+		"not one of":      {ensure.Number[int]().IsNotOneOf([]int{1}), `{"type": "integer", "not": {"enum": [1]}}`},
+		"even":            {ensure.Number[int]().IsEven(), `{"type": "integer", "multipleOf": 2}`},
+		"odd":             {ensure.Number[float64]().IsOdd(), `{"type": "number", "multipleOf": 1, "not": {"multipleOf": 2}}`},
+		"is ctx":          {ensure.Number[int]().IsCtx(nil), `{"type": "integer", "x-ensure-rules": [{"rule": "IsCtx"}]}`},
+		"duration":        {ensure.Duration().IsPositive().IsMultipleOf(time.Second), `{"type": "integer", "exclusiveMinimum": 0, "multipleOf": 1000000000}`},
+		"duration is":     {ensure.Duration().Is(nil), `{"type": "integer", "x-ensure-rules": [{"rule": "Is"}]}`},
+		"duration string": {ensure.DurationString(ensure.Duration().IsPositive()), `{"type": "string", "x-ensure-rules": [{"rule": "DurationString", "schema": {"type": "integer", "exclusiveMinimum": 0}}]}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expectSchema(t, tc.validator, tc.expect)
+		})
+	}
+}
+
+func TestJSONSchema_Scalars(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		validator with.UntypedValidator
+		expect    string
+	}{
+		"bool":       {ensure.Bool(), `{"type": "boolean"}`},
+		"true":       {ensure.Bool().IsTrue(), `{"type": "boolean", "const": true}`},
+		"false":      {ensure.Bool().IsFalse(), `{"type": "boolean", "const": false}`},
+		"true false": {ensure.Bool().IsTrue().IsFalse(), `{"type": "boolean", "const": true, "allOf": [{"const": false}]}`},
+		"time":       {ensure.Time(), `{"type": "string", "format": "date-time"}`},
+		"time rules": {
+			ensure.Time().IsAfter(start).IsWeekday().IsWithin(time.Second),
+			`{
+				"type": "string",
+				"format": "date-time",
+				"x-ensure-rules": [
+					{"rule": "IsAfter", "params": {"expected": "2025-01-01T00:00:00Z"}},
+					{"rule": "IsWeekday"},
+					{"rule": "IsWithin", "params": {"expected": 1000000000}}
+				]
+			}`,
+		},
+		"custom": {errValidator{}, `{"x-ensure-type": "string"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expectSchema(t, tc.validator, tc.expect)
+		})
+	}
+}
+
+func TestJSONSchema_Collections(t *testing.T) {
+	testCases := map[string]struct {
+		validator with.UntypedValidator
+		expect    string
+	}{
+		"array":          {ensure.Array[string]().Each(ensure.String().IsNotEmpty()), `{"type": "array", "items": {"type": "string", "minLength": 1}}`},
+		"array count":    {ensure.Array[int]().HasCount(2), `{"type": "array", "minItems": 2, "maxItems": 2}`},
+		"array more":     {ensure.Array[int]().HasMoreThan(2), `{"type": "array", "minItems": 3}`},
+		"array fewer":    {ensure.Array[int]().HasFewerThan(2), `{"type": "array", "maxItems": 1}`},
+		"array is":       {ensure.Array[int]().Is(nil), `{"type": "array", "x-ensure-rules": [{"rule": "Is"}]}`},
+		"contains":       {ensure.ComparableArray[int]().Contains(1), `{"type": "array", "contains": {"const": 1}}`},
+		"not contains":   {ensure.ComparableArray[int]().DoesNotContain(1), `{"type": "array", "not": {"contains": {"const": 1}}}`},
+		"contains only":  {ensure.ComparableArray[int]().ContainsOnly(1, 2), `{"type": "array", "items": {"enum": [1, 2]}}`},
+		"unique":         {ensure.ComparableArray[int]().ContainsNoDuplicates(), `{"type": "array", "uniqueItems": true}`},
+		"contains any":   {ensure.ComparableArray[int]().ContainsAnyOf(1, 2), `{"type": "array", "contains": {"enum": [1, 2]}}`},
+		"contains none":  {ensure.ComparableArray[int]().DoesNotContainAnyOf(1), `{"type": "array", "not": {"contains": {"enum": [1]}}}`},
+		"map":            {ensure.Map[string, int]().IsNotEmpty().EachValue(ensure.Number[int]()), `{"type": "object", "minProperties": 1, "additionalProperties": {"type": "integer"}}`},
+		"map empty":      {ensure.Map[string, int]().IsEmpty(), `{"type": "object", "maxProperties": 0}`},
+		"map string key": {ensure.Map[string, int]().EachKey(ensure.String().HasLength(2)), `{"type": "object", "propertyNames": {"type": "string", "minLength": 2, "maxLength": 2}}`},
+		"map int key":    {ensure.Map[int, int]().EachKey(ensure.Number[int]()), `{"type": "object", "x-ensure-rules": [{"rule": "EachKey", "schema": {"type": "integer"}}]}`},
+		"map is":         {ensure.Map[string, int]().Is(nil), `{"type": "object", "x-ensure-rules": [{"rule": "Is"}]}`},
+		"pointer":        {ensure.Pointer[string](ensure.String()), `{"type": "string"}`},
+		"optional":       {ensure.OptionalPointer[string](ensure.String()), `{"anyOf": [{"type": "string"}, {"type": "null"}]}`},
+		"any":            {ensure.Any[string](ensure.String().IsEmpty(), ensure.String().HasLength(2)), `{"anyOf": [{"type": "string", "maxLength": 0}, {"type": "string", "minLength": 2, "maxLength": 2}]}`},
+		"object": {
+			ensure.Object().
+				RequiredKey("id", ensure.String()).
+				OptionalKey("count", ensure.Number[float64]()).
+				NoAdditionalKeys().
+				Is(nil),
+			`{
+				"type": "object",
+				"properties": {"id": {"type": "string"}, "count": {"type": "number"}},
+				"required": ["id"],
+				"additionalProperties": false,
+				"x-ensure-rules": [{"rule": "Is"}]
+			}`,
+		},
+		"object additional": {ensure.Object().AdditionalKeys(ensure.String()), `{"type": "object", "additionalProperties": {"type": "string"}}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expectSchema(t, tc.validator, tc.expect)
+		})
+	}
+}
+
+func TestJSONSchema_Struct(t *testing.T) {
+	validAddress := ensure.Struct[schemaAddress]().HasFields(with.Validators{
+		"Street": ensure.String().IsNotEmpty(),
+		"Unit":   ensure.OptionalPointer[string](ensure.String()),
+	})
+
+	validUser := ensure.Struct[schemaUser]().HasFields(with.Validators{
+		"Name":     ensure.String().IsNotEmpty(),
+		"Nickname": ensure.String(),
+		"Password": ensure.String().IsLongerThan(8),
+		"Address":  ensure.Pointer[schemaAddress](validAddress),
+		"Start":    ensure.Number[int](),
+	}, with.DisplayNames{
+		"Name": "Full Name",
+	}).HasFields(with.Validators{
+		"Name": ensure.String().IsShorterThan(10),
+	}).When(func(u schemaUser) bool { return u.Name != "" }, with.Validators{
+		"Email": ensure.Pointer[string](ensure.String()),
+	}).HasFields(with.Validators{
+		"Phone": ensure.Pointer[string](ensure.String()),
+	}).InGroups("create").HasGetters(with.Validators{
+		"GetName": ensure.String(),
+	}).FieldLessThan("Start", "End")
+
+	expectSchema(t, validUser, `{
+		"type": "object",
+		"properties": {
+			"name": {
+				"allOf": [
+					{"type": "string", "minLength": 1, "title": "Full Name"},
+					{"type": "string", "maxLength": 9}
+				]
+			},
+			"Nickname": {"type": "string"},
+			"email": {"type": "string"},
+			"phone": {"type": "string"},
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string", "minLength": 1},
+					"unit": {"anyOf": [{"type": "string"}, {"type": "null"}]}
+				}
+			},
+			"Start": {"type": "integer"}
+		},
+		"required": ["address"],
+		"x-ensure-rules": [{"rule": "FieldLessThan", "params": {"field": "Start", "other": "End"}}]
+	}`)
+}
+
+func TestJSONSchema_Recursive(t *testing.T) {
+	left := ensure.Struct[schemaNode]()
+	left.HasFields(with.Validators{
+		"Value": ensure.String(),
+		"Next":  ensure.OptionalPointer[schemaNode](left),
+	})
+
+	right := ensure.Struct[schemaNode]()
+	right.HasFields(with.Validators{
+		"Next": ensure.OptionalPointer[schemaNode](right),
+	})
+
+	expectSchema(t, left, `{
+		"$ref": "#/$defs/schemaNode",
+		"$defs": {
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"},
+					"next": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]}
+				}
+			}
+		}
+	}`)
+
+	// other validators for the same type get their own name
+	tree := ensure.Struct[schemaTree]().HasFields(with.Validators{
+		"Left": ensure.Pointer[schemaNode](left),
+	}).HasFields(with.Validators{
+		"Right": ensure.Pointer[schemaNode](right),
+	})
+
+	expectSchema(t, tree, `{
+		"type": "object",
+		"properties": {
+			"left": {"$ref": "#/$defs/schemaNode"},
+			"right": {"$ref": "#/$defs/schemaNode2"}
+		},
+		"required": ["left", "right"],
+		"$defs": {
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"},
+					"next": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]}
+				}
+			},
+			"schemaNode2": {
+				"type": "object",
+				"properties": {
+					"next": {"anyOf": [{"$ref": "#/$defs/schemaNode2"}, {"type": "null"}]}
+				}
+			}
+		}
+	}`)
+
+	// a validator that has been defined is referenced wherever else it appears
+	shared := ensure.Struct[schemaTree]().HasFields(with.Validators{
+		"Left":  ensure.Pointer[schemaNode](left),
+		"Right": ensure.OptionalPointer[schemaNode](left),
+	})
+
+	expectSchema(t, shared, `{
+		"type": "object",
+		"properties": {
+			"left": {"$ref": "#/$defs/schemaNode"},
+			"right": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]}
+		},
+		"required": ["left"],
+		"$defs": {
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"},
+					"next": {"anyOf": [{"$ref": "#/$defs/schemaNode"}, {"type": "null"}]}
+				}
+			}
+		}
+	}`)
+}
+
+func TestJSONSchema_Error(t *testing.T) {
+	// channels are comparable, but can't be encoded as JSON
+	v := ensure.ComparableArray[chan int]().Contains(make(chan int))
+
+	if _, err := ensure.JSONSchema(v); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -1,6 +1,7 @@
 package ensure
 
 import (
+	"context"
 	"github.com/chriscasto/go-ensure/with"
 	"reflect"
 )
@@ -15,14 +16,11 @@ type MapValidator[K comparable, V any] struct {
 
 // Map constructs a MapValidator instance with keys of type K and values of type V and returns a pointer to it
 func Map[K comparable, V any]() *MapValidator[K, V] {
-	var mapZero map[K]V
-	var keyZero K
-	var valZero V
-
+	// TypeFor is used since TypeOf returns nil for the zero value of an interface such as any
 	return &MapValidator[K, V]{
-		typeStr:      reflect.TypeOf(mapZero).String(),
-		keyTypeStr:   reflect.TypeOf(keyZero).String(),
-		valueTypeStr: reflect.TypeOf(valZero).String(),
+		typeStr:      reflect.TypeFor[map[K]V]().String(),
+		keyTypeStr:   reflect.TypeFor[K]().String(),
+		valueTypeStr: reflect.TypeFor[V]().String(),
 		checks:       newMapIterChecks[K, V](),
 	}
 }
@@ -35,6 +33,7 @@ func (mv *MapValidator[K, V]) Type() string {
 // HasLengthWhere adds a NumberValidator for validating the length of the string
 func (mv *MapValidator[K, V]) HasLengthWhere(nv *NumberValidator[int]) *MapValidator[K, V] {
 	mv.checks.AddHasLengthWhere(nv)
+	mv.checks.Record(rule{name: "HasLengthWhere", nested: nv})
 	return mv
 }
 
@@ -48,18 +47,41 @@ func (mv *MapValidator[K, V]) ValidateUntyped(value any, options ...*with.Valida
 
 // Validate applies all checks against a map and returns an error if any fail
 func (mv *MapValidator[K, V]) Validate(mp map[K]V, options ...*with.ValidationOptions) error {
-	return mv.checks.Evaluate(mp, getValidationOptions(options))
+	return mv.validate(mp, getValidationOptions(options))
+}
+
+// validate applies all checks against a map with the provided options
+func (mv *MapValidator[K, V]) validate(mp map[K]V, opts *with.ValidationOptions) error {
+	return mv.checks.Evaluate(mp, opts)
+}
+
+// validateRef validates the map that a struct field or getter refers to
+func (mv *MapValidator[K, V]) validateRef(ref any, opts *with.ValidationOptions) error {
+	return mv.validate(deref[map[K]V](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (mv *MapValidator[K, V]) valueType() reflect.Type {
+	return reflect.TypeFor[map[K]V]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (mv *MapValidator[K, V]) ValidateContext(ctx context.Context, mp map[K]V, options ...*with.ValidationOptions) error {
+	return mv.Validate(mp, contextOptions(ctx, options))
 }
 
 // EachKey assigns a Validator to be used for validating map keys
 func (mv *MapValidator[K, V]) EachKey(kv with.Validator[K]) *MapValidator[K, V] {
 	mv.checks.AddIterKeyValidator(kv)
+	mv.checks.Record(rule{name: "EachKey", nested: kv})
 	return mv
 }
 
 // EachValue assigns a Validator to be used for validating map values
 func (mv *MapValidator[K, V]) EachValue(vv with.Validator[V]) *MapValidator[K, V] {
 	mv.checks.AddIterValValidator(vv)
+	mv.checks.Record(rule{name: "EachValue", nested: vv})
 	return mv
 }
 
@@ -67,6 +89,7 @@ func (mv *MapValidator[K, V]) EachValue(vv with.Validator[V]) *MapValidator[K, V
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(0))
 func (mv *MapValidator[K, V]) IsEmpty() *MapValidator[K, V] {
 	mv.checks.AddIsEmpty()
+	mv.checks.Record(rule{name: "IsEmpty"})
 	return mv
 }
 
@@ -74,6 +97,7 @@ func (mv *MapValidator[K, V]) IsEmpty() *MapValidator[K, V] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().DoesNotEqual(0))
 func (mv *MapValidator[K, V]) IsNotEmpty() *MapValidator[K, V] {
 	mv.checks.AddIsNotEmpty()
+	mv.checks.Record(rule{name: "IsNotEmpty"})
 	return mv
 }
 
@@ -81,6 +105,7 @@ func (mv *MapValidator[K, V]) IsNotEmpty() *MapValidator[K, V] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(l))
 func (mv *MapValidator[K, V]) HasCount(l int) *MapValidator[K, V] {
 	mv.checks.AddHasLength(l)
+	mv.checks.Record(rule{name: "HasCount", params: map[string]any{"expected": l}})
 	return mv
 }
 
@@ -88,6 +113,7 @@ func (mv *MapValidator[K, V]) HasCount(l int) *MapValidator[K, V] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().IsGreaterThan(l))
 func (mv *MapValidator[K, V]) HasMoreThan(l int) *MapValidator[K, V] {
 	mv.checks.AddIsLongerThan(l)
+	mv.checks.Record(rule{name: "HasMoreThan", params: map[string]any{"expected": l}})
 	return mv
 }
 
@@ -95,6 +121,14 @@ func (mv *MapValidator[K, V]) HasMoreThan(l int) *MapValidator[K, V] {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().IsLessThan(l))
 func (mv *MapValidator[K, V]) HasFewerThan(l int) *MapValidator[K, V] {
 	mv.checks.AddIsShorterThan(l)
+	mv.checks.Record(rule{name: "HasFewerThan", params: map[string]any{"expected": l}})
+	return mv
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (mv *MapValidator[K, V]) WithMessage(tmpl string) *MapValidator[K, V] {
+	mv.checks.SetMessage(tmpl)
 	return mv
 }
 
@@ -103,6 +137,17 @@ func (mv *MapValidator[K, V]) Is(fn func(map[K]V) error) *MapValidator[K, V] {
 	mv.checks.Append(func(val map[K]V, _ *with.ValidationOptions) error {
 		return fn(val)
 	})
+	mv.checks.Record(rule{name: "Is"})
+	return mv
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (mv *MapValidator[K, V]) IsCtx(fn func(context.Context, map[K]V) error) *MapValidator[K, V] {
+	mv.checks.Append(func(val map[K]V, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	mv.checks.Record(rule{name: "IsCtx"})
 	return mv
 }
 
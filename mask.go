@@ -0,0 +1,42 @@
+package ensure
+
+import (
+	"github.com/chriscasto/go-ensure/with"
+	"strings"
+)
+
+// fieldMaskOptions returns the options to use when validating a struct field or
+// getter under the field mask in opts, and whether it should be validated at all
+// A field named in the mask is validated in full, while a field that only has
+// nested paths in the mask (eg "Address.City") is validated with those paths
+func fieldMaskOptions(name string, opts *with.ValidationOptions) (*with.ValidationOptions, bool) {
+	paths, masked := opts.FieldMask()
+
+	if !masked {
+		return opts, true
+	}
+
+	var nested []string
+
+	for _, path := range paths {
+		if path == name {
+			return withOption(opts, with.OptionClearFieldMask()), true
+		}
+
+		if rest, ok := strings.CutPrefix(path, name+"."); ok {
+			nested = append(nested, rest)
+		}
+	}
+
+	if len(nested) == 0 {
+		return opts, false
+	}
+
+	return withOption(opts, with.OptionFieldMask(nested...)), true
+}
+
+// isMasked returns true if a field mask is set in opts
+func isMasked(opts *with.ValidationOptions) bool {
+	_, masked := opts.FieldMask()
+	return masked
+}
@@ -0,0 +1,164 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+)
+
+func TestFieldMask(t *testing.T) {
+	validPet := ensure.Struct[pathPet]().HasFields(with.Validators{
+		"Name": ensure.String().IsNotEmpty(),
+	})
+
+	validPerson := ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Name":   ensure.String().IsNotEmpty(),
+		"Pets":   ensure.Array[pathPet]().Each(validPet),
+		"Labels": ensure.Map[string, string]().IsNotEmpty(),
+		"Best":   ensure.OptionalPointer[pathPet](validPet),
+	}).HasGetters(with.Validators{
+		"GetName": ensure.String().HasLength(3),
+	})
+
+	invalid := pathPerson{
+		Pets: []pathPet{{}},
+		Best: &pathPet{},
+	}
+
+	testCases := map[string]struct {
+		mask        []string
+		expectPaths []string
+	}{
+		"no fields":     {[]string{}, nil},
+		"single field":  {[]string{"Name"}, []string{"/Name"}},
+		"getter":        {[]string{"GetName"}, []string{"/GetName"}},
+		"nested array":  {[]string{"Pets.Name"}, []string{"/Pets/0/Name"}},
+		"nested fields": {[]string{"Best.Name", "Labels"}, []string{"/Best/Name", "/Labels"}},
+		"whole field":   {[]string{"Best"}, []string{"/Best/Name"}},
+		"nested other":  {[]string{"Best.Age"}, nil},
+		"unknown field": {[]string{"Missing"}, nil},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validPerson.Validate(invalid, with.Options(
+				with.OptionCollectAllErrors(),
+				with.OptionFieldMask(tc.mask...),
+			))
+
+			if len(tc.expectPaths) == 0 {
+				if err != nil {
+					t.Errorf(`expected no error; got "%s"`, err)
+				}
+				return
+			}
+
+			paths := validationErrorPaths(t, err)
+
+			if len(paths) != len(tc.expectPaths) {
+				t.Errorf("expected %d errors; got %v", len(tc.expectPaths), paths)
+			}
+
+			for _, path := range tc.expectPaths {
+				if _, ok := paths[path]; !ok {
+					t.Errorf(`expected an error at path "%s"; got %v`, path, paths)
+				}
+			}
+		})
+	}
+
+	// without a mask, every field is validated
+	paths := validationErrorPaths(t, validPerson.Validate(invalid, with.Options(with.OptionCollectAllErrors())))
+
+	if len(paths) != 5 {
+		t.Errorf("expected 5 errors; got %v", paths)
+	}
+}
+
+func TestFieldMask_StructChecks(t *testing.T) {
+	errCheck := errors.New("check failed")
+	failing := func(fieldRuleForm) error { return errCheck }
+
+	testCases := map[string]struct {
+		validator *ensure.StructValidator[fieldRuleForm]
+		mask      []string
+		willPass  bool
+	}{
+		"check skipped": {
+			ensure.Struct[fieldRuleForm]().Is(failing),
+			[]string{"Password"},
+			true,
+		},
+		"check applied": {
+			ensure.Struct[fieldRuleForm]().Is(failing).ApplyWhenMasked(),
+			[]string{"Password"},
+			false,
+		},
+		"check with message applied": {
+			ensure.Struct[fieldRuleForm]().Is(failing).WithMessage("custom").ApplyWhenMasked(),
+			[]string{"Password"},
+			false,
+		},
+		"field rule in mask": {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password"),
+			[]string{"Confirm"},
+			false,
+		},
+		"field rule not in mask": {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password"),
+			[]string{"Password"},
+			true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.validator.Validate(fieldRuleForm{Password: "a"}, with.Options(with.OptionFieldMask(tc.mask...)))
+
+			if err != nil && tc.willPass {
+				t.Errorf(`expected no error; got "%s"`, err)
+			} else if err == nil && !tc.willPass {
+				t.Errorf("expected error but got none")
+			}
+		})
+	}
+
+	// checks still run without a mask
+	if err := ensure.Struct[fieldRuleForm]().Is(failing).Validate(fieldRuleForm{}); !errors.Is(err, errCheck) {
+		t.Errorf(`expected check to fail; got "%v"`, err)
+	}
+}
+
+func TestFieldMask_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"no checks": func() {
+			ensure.Struct[fieldRuleForm]().ApplyWhenMasked()
+		},
+		"after fields": func() {
+			ensure.Struct[fieldRuleForm]().Is(func(fieldRuleForm) error { return nil }).HasFields(with.Validators{
+				"Password": ensure.String(),
+			}).ApplyWhenMasked()
+		},
+		"after getters": func() {
+			ensure.Struct[pathPerson]().Is(func(pathPerson) error { return nil }).HasGetters(with.Validators{
+				"GetName": ensure.String(),
+			}).ApplyWhenMasked()
+		},
+		"after field rule": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password").ApplyWhenMasked()
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package ensure
+
+import (
+	"fmt"
+	"maps"
+	"sync/atomic"
+)
+
+// messageOverrides contains the templates set with SetDefaultMessage, keyed by error code
+// It is replaced rather than modified so it can be read without locking
+var messageOverrides atomic.Pointer[map[string]string]
+
+// SetDefaultMessage replaces the default (English) message template for an error code
+// Like catalog messages, the template can use the params of the check as placeholders
+// The default message is used for every locale that doesn't have a registered catalog
+// with a message for the code
+func SetDefaultMessage(code string, tmpl string) {
+	if _, ok := englishMessages[code]; !ok {
+		panic(fmt.Sprintf(`unknown error code "%s"`, code))
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	overrides := map[string]string{}
+
+	if current := messageOverrides.Load(); current != nil {
+		maps.Copy(overrides, *current)
+	}
+
+	overrides[code] = tmpl
+	messageOverrides.Store(&overrides)
+}
+
+// defaultMessage returns the template used for an error code when no catalog applies
+func defaultMessage(code string) Message {
+	if overrides := messageOverrides.Load(); overrides != nil {
+		if tmpl, ok := (*overrides)[code]; ok {
+			return Message{Text: tmpl}
+		}
+	}
+
+	return englishMessages[code]
+}
+
+// overrideMessage returns a copy of err with its message replaced by the
+// rendered template passed to WithMessage
+// The value that failed is available to the template as {actual} if the check
+// doesn't already provide it
+func overrideMessage(err error, tmpl string, actual any) error {
+	switch e := err.(type) {
+	case *ValidationErrors:
+		vErrs := newValidationErrors()
+		vErrs.tErrs = append(vErrs.tErrs, e.tErrs...)
+
+		for _, vErr := range e.vErrs {
+			vErrs.vErrs = append(vErrs.vErrs, overrideMessage(vErr, tmpl, actual).(*ValidationError))
+		}
+
+		return vErrs
+	case *TypeError, *CanceledError:
+		return err
+	case *ValidationError:
+		vErr := *e
+		vErr.err = renderOverride(tmpl, e.params, actual)
+		vErr.custom = true
+		return &vErr
+	default:
+		return &ValidationError{err: renderOverride(tmpl, nil, actual), custom: true, cause: err}
+	}
+}
+
+// renderOverride fills in a template passed to WithMessage
+func renderOverride(tmpl string, params map[string]any, actual any) string {
+	withActual := map[string]any{"actual": actual}
+	maps.Copy(withActual, params)
+
+	return Message{Text: tmpl}.render(withActual, nil)
+}
@@ -0,0 +1,240 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+	"time"
+)
+
+func TestWithMessage(t *testing.T) {
+	saturday := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	plainErr := errors.New("plain")
+	nilStr := (*string)(nil)
+
+	testCases := map[string]struct {
+		err    error
+		code   string
+		expect string
+	}{
+		"length": {
+			ensure.String().HasLength(8).WithMessage("PIN must be {expected} digits; got {actual}").Validate("123"),
+			ensure.CodeStringLengthEquals,
+			"PIN must be 8 digits; got 3",
+		},
+		"actual value": {
+			ensure.String().StartsWith("x").WithMessage(`"{actual}" must start with "{expected}"`).Validate("abc"),
+			ensure.CodeStringStartsWith,
+			`"abc" must start with "x"`,
+		},
+		"earlier rule unchanged": {
+			ensure.String().IsNotEmpty().HasLength(2).WithMessage("wrong length").Validate(""),
+			ensure.CodeStringNotEmpty,
+			"must not be empty",
+		},
+		"not translated": {
+			ensure.String().HasLength(1).WithMessage("one character").Validate("ab", with.Options(with.OptionLocale("de"))),
+			ensure.CodeStringLengthEquals,
+			"one character",
+		},
+		"unknown placeholder": {
+			ensure.String().IsNotEmpty().WithMessage("{missing} value").Validate(""),
+			ensure.CodeStringNotEmpty,
+			"{missing} value",
+		},
+		"number": {
+			ensure.Number[int]().IsEven().WithMessage("{actual} is odd").Validate(3),
+			ensure.CodeNumberEven,
+			"3 is odd",
+		},
+		"map count": {
+			ensure.Map[string, int]().HasCount(1).WithMessage("need {expected} entry").Validate(map[string]int{}),
+			ensure.CodeMapLengthEquals,
+			"need 1 entry",
+		},
+		"comparable array": {
+			ensure.ComparableArray[int]().ContainsNoDuplicates().WithMessage("{actual} is repeated").Contains(1).Validate([]int{2, 2}),
+			ensure.CodeArrayUnique,
+			"2 is repeated",
+		},
+		"time": {
+			ensure.Time().IsWeekday().WithMessage("no weekends").Validate(saturday),
+			ensure.CodeTimeWeekday,
+			"no weekends",
+		},
+		"duration": {
+			ensure.Duration().IsPositive().WithMessage("must be positive").Validate(0),
+			ensure.CodeDurationGreater,
+			"must be positive",
+		},
+		"custom check": {
+			ensure.String().Is(func(string) error { return plainErr }).WithMessage("custom {actual}").Validate("abc"),
+			ensure.CodeInvalid,
+			"custom abc",
+		},
+		"struct check": {
+			ensure.Struct[pathPet]().Is(func(pathPet) error { return plainErr }).WithMessage("invalid pet").Validate(pathPet{}),
+			ensure.CodeInvalid,
+			"invalid pet",
+		},
+		"bool true": {
+			ensure.Bool().IsTrue().WithMessage("terms must be accepted").Validate(false),
+			ensure.CodeBoolTrue,
+			"terms must be accepted",
+		},
+		"bool false": {
+			ensure.Bool().IsFalse().WithMessage("must be {actual}").IsTrue().Validate(true),
+			ensure.CodeBoolFalse,
+			"must be true",
+		},
+		"pointer": {
+			ensure.Pointer[string](ensure.String()).WithMessage("value is required").Validate(nilStr),
+			ensure.CodePointerRequired,
+			"value is required",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			vErr := &ensure.ValidationError{}
+
+			if !errors.As(tc.err, &vErr) {
+				t.Fatalf(`expected a validation error; got "%v"`, tc.err)
+			}
+
+			if vErr.Message() != tc.expect {
+				t.Errorf(`expected "%s"; got "%s"`, tc.expect, vErr.Message())
+			}
+
+			if vErr.Code() != tc.code {
+				t.Errorf(`expected code "%s"; got "%s"`, tc.code, vErr.Code())
+			}
+		})
+	}
+
+	if err := ensure.String().Is(func(string) error { return plainErr }).WithMessage("custom").Validate(""); !errors.Is(err, plainErr) {
+		t.Errorf(`expected custom message to wrap the original error; got "%v"`, err)
+	}
+
+	if err := ensure.Pointer[string](ensure.String()).WithMessage("required").Validate(nilStr); !errors.Is(err, ensure.RequiredPointerMissingErr) {
+		t.Errorf(`expected custom message to match RequiredPointerMissingErr; got "%v"`, err)
+	}
+}
+
+func TestWithMessage_Nested(t *testing.T) {
+	opts := with.Options(with.OptionCollectAllErrors())
+
+	err := ensure.Array[string]().Each(ensure.String().IsNotEmpty()).WithMessage("item {actual} is required").
+		Validate([]string{"", "a", ""}, opts)
+
+	expect := map[string]string{
+		"/0": "item  is required",
+		"/2": "item  is required",
+	}
+
+	paths := validationErrorPaths(t, err)
+
+	if len(paths) != len(expect) {
+		t.Errorf("expected %d errors; got %d (%v)", len(expect), len(paths), paths)
+	}
+
+	for path, msg := range expect {
+		if paths[path] != msg {
+			t.Errorf(`expected "%s" at path "%s"; got "%s"`, msg, path, paths[path])
+		}
+	}
+
+	err = ensure.Map[string, int]().EachKey(ensure.String().StartsWith("x")).WithMessage("bad key {actual}").
+		Validate(map[string]int{"ab": 1}, opts)
+
+	if paths := validationErrorPaths(t, err); paths["/ab"] != "bad key ab" {
+		t.Errorf(`expected "bad key ab" at path "/ab"; got %v`, paths)
+	}
+
+	// every error from a nested validator gets the message
+	err = ensure.String().HasLengthWhere(ensure.Length().IsOdd().IsLessThan(3)).WithMessage("bad length {actual}").
+		Validate("abcd", opts)
+
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil || len(vErrs.ValidationErrors()) != 2 {
+		t.Fatalf(`expected two errors; got "%v"`, err)
+	}
+
+	for _, vErr := range vErrs.ValidationErrors() {
+		if vErr.Message() != "bad length 4" {
+			t.Errorf(`expected "bad length 4"; got "%s"`, vErr.Message())
+		}
+	}
+
+	// type errors are left alone
+	tErrs := ensure.NewValidationErrors()
+	tErrs.Append(ensure.NewTypeError("type mismatch"))
+
+	err = ensure.Array[pathPerson]().Each(ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Name": errValidator{tErrs},
+	})).WithMessage("invalid person").Validate([]pathPerson{{}}, opts)
+
+	if vErrs := ensure.ErrorAsValidationErrors(err); vErrs == nil || !vErrs.HasTypeErrors() || vErrs.HasValidationErrors() {
+		t.Errorf(`expected only type errors; got "%v"`, err)
+	}
+
+	tErr := &ensure.TypeError{}
+	err = ensure.String().Is(func(string) error { return ensure.NewTypeError("type mismatch") }).WithMessage("custom").Validate("")
+
+	if !errors.As(err, &tErr) {
+		t.Errorf(`expected a type error; got "%v"`, err)
+	}
+}
+
+func TestWithMessage_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"string":           func() { ensure.String().WithMessage("msg") },
+		"bool":             func() { ensure.Bool().WithMessage("msg") },
+		"optional pointer": func() { ensure.OptionalPointer[string](ensure.String()).WithMessage("msg") },
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
+
+func TestSetDefaultMessage(t *testing.T) {
+	t.Cleanup(func() {
+		ensure.SetDefaultMessage(ensure.CodeDurationMultipleOf, "duration must be a multiple of {expected}; got {actual}")
+	})
+
+	ensure.SetDefaultMessage(ensure.CodeDurationMultipleOf, "must be whole {expected} units")
+	ensure.SetDefaultMessage(ensure.CodeDurationMultipleOf, "must be a multiple of {expected}")
+
+	err := ensure.Duration().IsMultipleOf(time.Second).Validate(time.Millisecond)
+	expect := "must be a multiple of 1s"
+
+	if err == nil || err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%v"`, expect, err)
+	}
+
+	// registered catalogs still take precedence
+	err = ensure.Number[int]().IsLessThan(1).Validate(2, with.Options(with.OptionLocale("de")))
+
+	if err == nil || err.Error() != "Zahl muss kleiner als 1 sein" {
+		t.Errorf(`expected translated message; got "%v"`, err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	ensure.SetDefaultMessage("unknown.code", "msg")
+}
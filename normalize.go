@@ -0,0 +1,48 @@
+package ensure
+
+import (
+	"github.com/chriscasto/go-ensure/with"
+	"strings"
+	"unicode"
+)
+
+// normalizer is implemented by validators that can write normalized values back into a struct
+type normalizer interface {
+	// normalizeRef normalizes the value that ref points to in place
+	normalizeRef(ref any, opts *with.ValidationOptions)
+}
+
+// collapseSpaces replaces each run of whitespace in a string with a single space
+// The string is only copied if it changes
+func collapseSpaces(str string) string {
+	var b strings.Builder
+	changed := false
+	inSpace := false
+
+	for i, r := range str {
+		space := unicode.IsSpace(r)
+
+		// Whitespace that follows other whitespace is dropped, and anything other than a space is replaced
+		if space && (inSpace || r != ' ') && !changed {
+			changed = true
+			b.Grow(len(str))
+			b.WriteString(str[:i])
+		}
+
+		if changed && !(space && inSpace) {
+			if space {
+				b.WriteByte(' ')
+			} else {
+				b.WriteRune(r)
+			}
+		}
+
+		inSpace = space
+	}
+
+	if !changed {
+		return str
+	}
+
+	return b.String()
+}
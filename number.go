@@ -1,13 +1,13 @@
 package ensure
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/chriscasto/go-ensure/with"
 	"golang.org/x/exp/constraints"
 	"math"
 	"reflect"
-	"strings"
+	"slices"
 )
 
 // NumberType defines the set of values accepted by NumberValidator
@@ -100,10 +100,9 @@ func isOdd(typeStr string, i any) bool {
 
 // NumberValidator contains information and logic used to validate a number of type T
 type NumberValidator[T NumberType] struct {
-	typeStr     string
-	isFloat     bool
-	checks      *valChecks[T]
-	placeholder string
+	typeStr string
+	isFloat bool
+	checks  *valChecks[T]
 }
 
 // Type returns a string with the type of the number this validator expects
@@ -117,29 +116,15 @@ func Number[T constraints.Integer | constraints.Float]() *NumberValidator[T] {
 
 	kind := reflect.TypeOf(zero).Kind()
 
-	// Int placeholder value
-	ph := "%d"
-	isFloat := false
-
-	// if it's actually a Float, use that placeholder instead
-	if string(kind.String()[0]) == "f" {
-		ph = "%g"
-		isFloat = true
-	}
+	isFloat := string(kind.String()[0]) == "f"
 
 	return &NumberValidator[T]{
-		typeStr:     reflect.TypeOf(zero).String(),
-		placeholder: ph,
-		isFloat:     isFloat,
-		checks:      newValChecks[T](),
+		typeStr: reflect.TypeOf(zero).String(),
+		isFloat: isFloat,
+		checks:  newValChecks[T](),
 	}
 }
 
-// fmtErrorMsg replaces occurrences of "{}" in error messages with type-specific placeholder
-func (v *NumberValidator[T]) fmtErrorMsg(msg string) string {
-	return strings.Replace(msg, "{}", v.placeholder, -1)
-}
-
 // IsInRange adds a check that returns an error if number being validated is not between the two numbers provided
 // Range is inclusive of the lower bound and exclusive of the upper bound
 func (v *NumberValidator[T]) IsInRange(min T, max T) *NumberValidator[T] {
@@ -147,13 +132,9 @@ func (v *NumberValidator[T]) IsInRange(min T, max T) *NumberValidator[T] {
 		panic(fmt.Sprintf("max cannot be less than min"))
 	}
 
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsInRange", params: map[string]any{"min": min, "max": max}}, func(i T) error {
 		if i < min || i >= max {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be in the range [{}, {}); got {}"),
-					min, max, i),
-			)
+			return newCheckError(CodeNumberRange, map[string]any{"min": min, "max": max, "actual": i})
 		}
 
 		return nil
@@ -162,12 +143,9 @@ func (v *NumberValidator[T]) IsInRange(min T, max T) *NumberValidator[T] {
 
 // Equals adds a check that returns an error if number being validated is not exactly the number provided
 func (v *NumberValidator[T]) Equals(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "Equals", params: map[string]any{"expected": target}}, func(i T) error {
 		if i != target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must equal {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberEquals, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -176,12 +154,9 @@ func (v *NumberValidator[T]) Equals(target T) *NumberValidator[T] {
 
 // DoesNotEqual adds a check that returns an error if number being validated is exactly the same as the number provided
 func (v *NumberValidator[T]) DoesNotEqual(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "DoesNotEqual", params: map[string]any{"expected": target}}, func(i T) error {
 		if i == target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must not equal {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberNotEquals, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -190,12 +165,9 @@ func (v *NumberValidator[T]) DoesNotEqual(target T) *NumberValidator[T] {
 
 // IsLessThan adds a check that returns an error if number being validated is not lees than the number provided
 func (v *NumberValidator[T]) IsLessThan(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsLessThan", params: map[string]any{"expected": target}}, func(i T) error {
 		if i >= target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be less than {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberLess, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -204,12 +176,9 @@ func (v *NumberValidator[T]) IsLessThan(target T) *NumberValidator[T] {
 
 // IsLessThanOrEqualTo adds a check that returns an error if number being validated is not lees than or equal to the number provided
 func (v *NumberValidator[T]) IsLessThanOrEqualTo(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsLessThanOrEqualTo", params: map[string]any{"expected": target}}, func(i T) error {
 		if i > target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be less than or equal to {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberLessOrEqual, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -218,12 +187,9 @@ func (v *NumberValidator[T]) IsLessThanOrEqualTo(target T) *NumberValidator[T] {
 
 // IsGreaterThan adds a check that returns an error if number being validated is not greater than the number provided
 func (v *NumberValidator[T]) IsGreaterThan(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsGreaterThan", params: map[string]any{"expected": target}}, func(i T) error {
 		if i <= target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be greater than {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberGreater, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -232,12 +198,9 @@ func (v *NumberValidator[T]) IsGreaterThan(target T) *NumberValidator[T] {
 
 // IsGreaterThanOrEqualTo adds a check that returns an error if number being validated is not greater than or equal to than the number provided
 func (v *NumberValidator[T]) IsGreaterThanOrEqualTo(target T) *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsGreaterThanOrEqualTo", params: map[string]any{"expected": target}}, func(i T) error {
 		if i < target {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be greater than or equal to {}; got {}"), target, i),
-			)
+			return newCheckError(CodeNumberGreaterOrEqual, map[string]any{"expected": target, "actual": i})
 		}
 
 		return nil
@@ -246,12 +209,9 @@ func (v *NumberValidator[T]) IsGreaterThanOrEqualTo(target T) *NumberValidator[T
 
 // IsEven adds a check that returns an error if number being validated is not even
 func (v *NumberValidator[T]) IsEven() *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsEven"}, func(i T) error {
 		if !isEven(v.typeStr, i) {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be even; got {}"), i),
-			)
+			return newCheckError(CodeNumberEven, map[string]any{"actual": i})
 		}
 
 		return nil
@@ -260,12 +220,9 @@ func (v *NumberValidator[T]) IsEven() *NumberValidator[T] {
 
 // IsOdd adds a check that returns an error if number being validated is not odd
 func (v *NumberValidator[T]) IsOdd() *NumberValidator[T] {
-	return v.Is(func(i T) error {
+	return v.addRule(rule{name: "IsOdd"}, func(i T) error {
 		if !isOdd(v.typeStr, i) {
-			return errors.New(
-				fmt.Sprintf(
-					v.fmtErrorMsg("number must be odd; got {}"), i),
-			)
+			return newCheckError(CodeNumberOdd, map[string]any{"actual": i})
 		}
 
 		return nil
@@ -294,6 +251,8 @@ func (v *NumberValidator[T]) IsNotZero() *NumberValidator[T] {
 
 // IsOneOf adds a check that returns an error if number being validated is not in the provided list
 func (v *NumberValidator[T]) IsOneOf(values []T) *NumberValidator[T] {
+	permitted := slices.Clone(values)
+
 	// convert list to map for O(1) lookups
 	lookup := map[T]bool{}
 
@@ -301,9 +260,9 @@ func (v *NumberValidator[T]) IsOneOf(values []T) *NumberValidator[T] {
 		lookup[num] = true
 	}
 
-	return v.Is(func(num T) error {
+	return v.addRule(rule{name: "IsOneOf", params: map[string]any{"values": permitted}}, func(num T) error {
 		if _, ok := lookup[num]; !ok {
-			return errors.New(`number must be one of the permitted values`)
+			return newCheckError(CodeNumberOneOf, map[string]any{"expected": permitted, "actual": num})
 		}
 		return nil
 	})
@@ -318,9 +277,9 @@ func (v *NumberValidator[T]) IsNotOneOf(values []T) *NumberValidator[T] {
 		lookup[num] = true
 	}
 
-	return v.Is(func(num T) error {
+	return v.addRule(rule{name: "IsNotOneOf", params: map[string]any{"values": slices.Clone(values)}}, func(num T) error {
 		if _, ok := lookup[num]; ok {
-			return errors.New(`number must not be one of the prohibited values`)
+			return newCheckError(CodeNumberNotOneOf, map[string]any{"actual": num})
 		}
 		return nil
 	})
@@ -336,14 +295,63 @@ func (v *NumberValidator[T]) ValidateUntyped(value any, options ...*with.Validat
 
 // Validate applies all checks against a number of the expected type and returns an error if any fail
 func (v *NumberValidator[T]) Validate(n T, options ...*with.ValidationOptions) error {
-	return v.checks.Evaluate(n, getValidationOptions(options))
+	return v.validate(n, getValidationOptions(options))
 }
 
-// Is adds the provided function as a check against any values to be validated
-func (v *NumberValidator[T]) Is(fn func(T) error) *NumberValidator[T] {
+// validate applies all checks against a number with the provided options
+func (v *NumberValidator[T]) validate(n T, opts *with.ValidationOptions) error {
+	return v.checks.Evaluate(n, opts)
+}
+
+// validateScalar validates a number read from a struct field
+func (v *NumberValidator[T]) validateScalar(s scalar, opts *with.ValidationOptions) error {
+	return v.validate(scalarNumber[T](s), opts)
+}
+
+// validateRef validates the number that a struct field or getter refers to
+func (v *NumberValidator[T]) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[T](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *NumberValidator[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (v *NumberValidator[T]) ValidateContext(ctx context.Context, n T, options ...*with.ValidationOptions) error {
+	return v.Validate(n, contextOptions(ctx, options))
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (v *NumberValidator[T]) WithMessage(tmpl string) *NumberValidator[T] {
+	v.checks.SetMessage(tmpl)
+	return v
+}
+
+// addRule adds a check along with the rule that describes it
+func (v *NumberValidator[T]) addRule(r rule, fn func(T) error) *NumberValidator[T] {
 	v.checks.Append(func(val T, _ *with.ValidationOptions) error {
 		return fn(val)
 	})
+	v.checks.Record(r)
+	return v
+}
+
+// Is adds the provided function as a check against any values to be validated
+func (v *NumberValidator[T]) Is(fn func(T) error) *NumberValidator[T] {
+	return v.addRule(rule{name: "Is"}, fn)
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (v *NumberValidator[T]) IsCtx(fn func(context.Context, T) error) *NumberValidator[T] {
+	v.checks.Append(func(val T, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	v.checks.Record(rule{name: "IsCtx"})
 	return v
 }
 
@@ -0,0 +1,206 @@
+package ensure
+
+import (
+	"context"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// objectType is the type string for map[string]any
+var objectType = reflect.TypeOf(map[string]any{}).String()
+
+// ObjectValidator contains information and logic used to validate a map[string]any
+// with a known set of keys, such as a JSON document decoded into an any
+type ObjectValidator struct {
+	checks     *valChecks[map[string]any]
+	keys       map[string]bool
+	additional bool
+}
+
+// Object constructs an ObjectValidator and returns a pointer to it
+func Object() *ObjectValidator {
+	return &ObjectValidator{
+		checks: newValChecks[map[string]any](),
+		keys:   map[string]bool{},
+	}
+}
+
+// Type returns the string "map[string]interface {}"
+func (ov *ObjectValidator) Type() string {
+	return objectType
+}
+
+// addKey adds a check that validates the value of a key against the provided validator
+func (ov *ObjectValidator) addKey(name string, v with.UntypedValidator, required bool) {
+	if ov.keys[name] {
+		panic(fmt.Sprintf(`key "%s" already has a validator`, name))
+	}
+
+	ov.keys[name] = true
+
+	ov.checks.Append(func(obj map[string]any, opts *with.ValidationOptions) error {
+		val, ok := obj[name]
+
+		if !ok {
+			if required {
+				return prependPath(newCheckError(CodeObjectRequiredKey, map[string]any{"key": name}), keySegment(name))
+			}
+			return nil
+		}
+
+		if err := v.ValidateUntyped(val, opts); err != nil {
+			return prependPath(err, keySegment(name))
+		}
+
+		return nil
+	})
+}
+
+// RequiredKey adds a check that returns an error if the key is missing or its value fails validation
+func (ov *ObjectValidator) RequiredKey(name string, v with.UntypedValidator) *ObjectValidator {
+	ov.addKey(name, v, true)
+	ov.checks.Record(rule{name: "RequiredKey", params: map[string]any{"key": name}, nested: v})
+	return ov
+}
+
+// OptionalKey adds a check that returns an error if the key is present and its value fails validation
+func (ov *ObjectValidator) OptionalKey(name string, v with.UntypedValidator) *ObjectValidator {
+	ov.addKey(name, v, false)
+	ov.checks.Record(rule{name: "OptionalKey", params: map[string]any{"key": name}, nested: v})
+	return ov
+}
+
+// addAdditional adds a check against every key that doesn't have its own validator
+// Keys are checked in sorted order so errors are reported consistently
+func (ov *ObjectValidator) addAdditional(check func(key string, val any, opts *with.ValidationOptions) error) {
+	if ov.additional {
+		panic("additional keys have already been configured")
+	}
+
+	ov.additional = true
+
+	ov.checks.Append(func(obj map[string]any, opts *with.ValidationOptions) error {
+		vErrs := newValidationErrors()
+
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			if ov.keys[key] {
+				continue
+			}
+
+			if err := contextError(opts); err != nil {
+				return err
+			}
+
+			if err := check(key, obj[key], opts); err != nil {
+				if cErr := contextError(opts); cErr != nil {
+					return cErr
+				}
+
+				if !opts.CollectAllErrors() {
+					return prependPath(err, keySegment(key))
+				}
+
+				vErrs.Append(prependPath(err, keySegment(key)))
+			}
+		}
+
+		if vErrs.HasErrors() {
+			return vErrs
+		}
+
+		return nil
+	})
+}
+
+// AdditionalKeys adds a validator for the values of any keys that don't have
+// their own validator from RequiredKey or OptionalKey
+// Without AdditionalKeys or NoAdditionalKeys, other keys are allowed and not validated
+func (ov *ObjectValidator) AdditionalKeys(v with.UntypedValidator) *ObjectValidator {
+	ov.addAdditional(func(_ string, val any, opts *with.ValidationOptions) error {
+		return v.ValidateUntyped(val, opts)
+	})
+	ov.checks.Record(rule{name: "AdditionalKeys", nested: v})
+	return ov
+}
+
+// NoAdditionalKeys adds a check that returns an error for any key that doesn't
+// have its own validator from RequiredKey or OptionalKey
+func (ov *ObjectValidator) NoAdditionalKeys() *ObjectValidator {
+	ov.addAdditional(func(key string, _ any, _ *with.ValidationOptions) error {
+		return newCheckError(CodeObjectAdditionalKey, map[string]any{"key": key})
+	})
+	ov.checks.Record(rule{name: "NoAdditionalKeys"})
+	return ov
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {key} are replaced with the rule's params, and {actual} with the value that failed
+func (ov *ObjectValidator) WithMessage(tmpl string) *ObjectValidator {
+	ov.checks.SetMessage(tmpl)
+	return ov
+}
+
+// Is adds the provided function as a check against any values to be validated
+func (ov *ObjectValidator) Is(fn func(map[string]any) error) *ObjectValidator {
+	ov.checks.Append(func(val map[string]any, _ *with.ValidationOptions) error {
+		return fn(val)
+	})
+	ov.checks.Record(rule{name: "Is"})
+	return ov
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (ov *ObjectValidator) IsCtx(fn func(context.Context, map[string]any) error) *ObjectValidator {
+	ov.checks.Append(func(val map[string]any, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	ov.checks.Record(rule{name: "IsCtx"})
+	return ov
+}
+
+// Has adds the provided function as a check against any values to be validated
+// Has is an alias for Is
+func (ov *ObjectValidator) Has(fn func(map[string]any) error) *ObjectValidator {
+	return ov.Is(fn)
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a map[string]any
+func (ov *ObjectValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	obj, ok := value.(map[string]any)
+
+	if !ok {
+		return newTypeErrorFromTypes(objectType, fmt.Sprintf("%T", value))
+	}
+
+	return ov.Validate(obj, options...)
+}
+
+// Validate applies all checks against an object and returns an error if any fail
+func (ov *ObjectValidator) Validate(obj map[string]any, options ...*with.ValidationOptions) error {
+	return ov.validate(obj, getValidationOptions(options))
+}
+
+// validate applies all checks against an object with the provided options
+func (ov *ObjectValidator) validate(obj map[string]any, opts *with.ValidationOptions) error {
+	return ov.checks.Evaluate(obj, opts)
+}
+
+// validateRef validates the object that a struct field or getter refers to
+func (ov *ObjectValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return ov.validate(deref[map[string]any](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (ov *ObjectValidator) valueType() reflect.Type {
+	return reflect.TypeFor[map[string]any]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (ov *ObjectValidator) ValidateContext(ctx context.Context, obj map[string]any, options ...*with.ValidationOptions) error {
+	return ov.Validate(obj, contextOptions(ctx, options))
+}
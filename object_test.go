@@ -0,0 +1,231 @@
+package ensure_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+)
+
+func TestObjectValidator_IsValidator(t *testing.T) {
+	runDefaultValidatorTestCases(t, ensure.Object())
+
+	if err := ensure.Object().ValidateUntyped(map[string]any{}); err != nil {
+		t.Errorf(`expected map[string]any to be accepted; got "%s"`, err)
+	}
+}
+
+// decodeObject decodes a JSON document the same way a webhook handler would
+func decodeObject(t *testing.T, doc string) map[string]any {
+	t.Helper()
+
+	var decoded any
+
+	if err := json.Unmarshal([]byte(doc), &decoded); err != nil {
+		t.Fatalf("invalid test document: %s", err)
+	}
+
+	return decoded.(map[string]any)
+}
+
+func TestObjectValidator_Keys(t *testing.T) {
+	validEvent := ensure.Object().
+		RequiredKey("id", ensure.String().IsNotEmpty()).
+		RequiredKey("attempt", ensure.Number[float64]().IsGreaterThan(0)).
+		OptionalKey("data", ensure.Object().RequiredKey("email", ensure.String().Matches(ensure.Email))).
+		OptionalKey("tags", ensure.Array[any]().HasFewerThan(3)).
+		OptionalKey("meta", ensure.Map[string, any]().HasFewerThan(2))
+
+	testCases := map[string]struct {
+		doc         string
+		expectPaths []string
+	}{
+		"valid":            {`{"id": "evt_1", "attempt": 1}`, nil},
+		"valid optional":   {`{"id": "evt_1", "attempt": 1, "data": {"email": "a@example.com"}, "tags": ["a"]}`, nil},
+		"additional":       {`{"id": "evt_1", "attempt": 1, "extra": true}`, nil},
+		"missing required": {`{}`, []string{"/id", "/attempt"}},
+		"invalid value":    {`{"id": "", "attempt": 0}`, []string{"/id", "/attempt"}},
+		"nested":           {`{"id": "evt_1", "attempt": 1, "data": {"email": "nope"}}`, []string{"/data/email"}},
+		"nested missing":   {`{"id": "evt_1", "attempt": 1, "data": {}}`, []string{"/data/email"}},
+		"optional invalid": {`{"id": "evt_1", "attempt": 1, "tags": [1, 2, 3], "meta": {"a": 1, "b": 2}}`, []string{"/tags", "/meta"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validEvent.Validate(decodeObject(t, tc.doc), with.Options(with.OptionCollectAllErrors()))
+
+			if len(tc.expectPaths) == 0 {
+				if err != nil {
+					t.Errorf(`expected no error; got "%s"`, err)
+				}
+				return
+			}
+
+			paths := validationErrorPaths(t, err)
+
+			if len(paths) != len(tc.expectPaths) {
+				t.Errorf("expected %d errors; got %v", len(tc.expectPaths), paths)
+			}
+
+			for _, path := range tc.expectPaths {
+				if _, ok := paths[path]; !ok {
+					t.Errorf(`expected an error at path "%s"; got %v`, path, paths)
+				}
+			}
+		})
+	}
+
+	err := validEvent.Validate(map[string]any{"attempt": 1.0})
+	expect := `key "id" is required`
+
+	if err == nil || err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%v"`, expect, err)
+	}
+
+	// values of the wrong type are type errors
+	err = validEvent.Validate(map[string]any{"id": 1, "attempt": 1.0})
+
+	tErr := &ensure.TypeError{}
+
+	if !errors.As(err, &tErr) {
+		t.Errorf(`expected a type error; got "%v"`, err)
+	}
+}
+
+func TestObjectValidator_AdditionalKeys(t *testing.T) {
+	doc := map[string]any{"id": "a", "b": "x", "a": 1.0, "c": "y"}
+
+	err := ensure.Object().RequiredKey("id", ensure.String()).NoAdditionalKeys().
+		Validate(doc, with.Options(with.OptionCollectAllErrors()))
+
+	paths := validationErrorPaths(t, err)
+	expect := map[string]string{
+		"/a": `key "a" is not allowed`,
+		"/b": `key "b" is not allowed`,
+		"/c": `key "c" is not allowed`,
+	}
+
+	if len(paths) != len(expect) {
+		t.Errorf("expected %d errors; got %v", len(expect), paths)
+	}
+
+	for path, msg := range expect {
+		if paths[path] != msg {
+			t.Errorf(`expected "%s" at path "%s"; got "%s"`, msg, path, paths[path])
+		}
+	}
+
+	// keys are checked in sorted order
+	err = ensure.Object().RequiredKey("id", ensure.String()).NoAdditionalKeys().Validate(doc)
+
+	if err == nil || err.Error() != `key "a" is not allowed` {
+		t.Errorf(`expected first additional key to fail; got "%v"`, err)
+	}
+
+	validLabels := ensure.Object().OptionalKey("id", ensure.String()).AdditionalKeys(ensure.String().IsNotEmpty())
+
+	if err := validLabels.Validate(map[string]any{"id": "", "b": "x"}); err != nil {
+		t.Errorf(`expected no error; got "%s"`, err)
+	}
+
+	err = validLabels.Validate(map[string]any{"id": "", "b": ""}, with.Options(with.OptionCollectAllErrors()))
+
+	if paths := validationErrorPaths(t, err); len(paths) != 1 || paths["/b"] == "" {
+		t.Errorf(`expected an error at path "/b"; got %v`, paths)
+	}
+}
+
+func TestObjectValidator_Checks(t *testing.T) {
+	errCheck := errors.New("check failed")
+
+	err := ensure.Object().Has(func(map[string]any) error { return errCheck }).Validate(map[string]any{})
+
+	if !errors.Is(err, errCheck) {
+		t.Errorf(`expected check to fail; got "%v"`, err)
+	}
+
+	err = ensure.Object().RequiredKey("id", ensure.String()).WithMessage("an id is needed").Validate(map[string]any{})
+
+	if err == nil || err.Error() != "an id is needed" {
+		t.Errorf(`expected custom message; got "%v"`, err)
+	}
+
+	called := false
+	err = ensure.Object().IsCtx(func(ctx context.Context, _ map[string]any) error {
+		called = ctx.Err() == nil
+		return nil
+	}).ValidateContext(context.Background(), map[string]any{})
+
+	if err != nil || !called {
+		t.Errorf(`expected check to be called with context; got "%v"`, err)
+	}
+}
+
+func TestObjectValidator_Canceled(t *testing.T) {
+	for name, opts := range map[string]*with.ValidationOptions{
+		"fail fast":   with.Options(),
+		"collect all": with.Options(with.OptionCollectAllErrors()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			count := 0
+
+			validLabels := ensure.Object().AdditionalKeys(ensure.String().IsCtx(func(context.Context, string) error {
+				count++
+				cancel()
+				return errors.New("failed")
+			}))
+
+			expectCanceled(t, validLabels.ValidateContext(ctx, map[string]any{"a": "", "b": ""}, opts), context.Canceled)
+
+			if count != 1 {
+				t.Errorf("expected validation to stop after the context was canceled; %d checks were called", count)
+			}
+
+			// a canceled check that doesn't fail still stops the remaining keys
+			ctx, cancel = context.WithCancel(context.Background())
+			defer cancel()
+
+			count = 0
+
+			validLabels = ensure.Object().AdditionalKeys(ensure.String().IsCtx(func(context.Context, string) error {
+				count++
+				cancel()
+				return nil
+			}))
+
+			expectCanceled(t, validLabels.ValidateContext(ctx, map[string]any{"a": "", "b": ""}, opts), context.Canceled)
+
+			if count != 1 {
+				t.Errorf("expected validation to stop after the context was canceled; %d checks were called", count)
+			}
+		})
+	}
+}
+
+func TestObjectValidator_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"duplicate key": func() {
+			ensure.Object().RequiredKey("id", ensure.String()).OptionalKey("id", ensure.String())
+		},
+		"additional twice": func() {
+			ensure.Object().NoAdditionalKeys().AdditionalKeys(ensure.String())
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
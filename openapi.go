@@ -0,0 +1,185 @@
+package ensure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// openAPIRefPrefix is the location of component schemas in an OpenAPI document
+const openAPIRefPrefix = "#/components/schemas/"
+
+// openAPINamePattern matches the names OpenAPI allows for components
+var openAPINamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// schemaDefiner is implemented by validators that can be added to a document as a named definition
+type schemaDefiner interface {
+	schemaDefinition(g *schemaGenerator) schemaObject
+}
+
+// OpenAPIComponents is a registry of named struct validators used to generate
+// the schemas section of an OpenAPI 3.1 document
+type OpenAPIComponents struct {
+	names      []string
+	validators map[string]schemaDefiner
+}
+
+// NewOpenAPIComponents returns an empty registry of component schemas
+func NewOpenAPIComponents() *OpenAPIComponents {
+	return &OpenAPIComponents{
+		validators: map[string]schemaDefiner{},
+	}
+}
+
+// Add registers a struct validator as a component schema with the provided name
+// Panics if the validator isn't a struct validator or the name is invalid or already registered
+func (c *OpenAPIComponents) Add(name string, v with.UntypedValidator) *OpenAPIComponents {
+	definer, ok := v.(schemaDefiner)
+
+	if !ok {
+		panic(fmt.Sprintf(`component "%s" must be a struct validator; got validator for "%s"`, name, v.Type()))
+	}
+
+	if !openAPINamePattern.MatchString(name) {
+		panic(fmt.Sprintf(`"%s" is not a valid component name`, name))
+	}
+
+	if _, ok := c.validators[name]; ok {
+		panic(fmt.Sprintf(`component "%s" is already registered`, name))
+	}
+
+	c.names = append(c.names, name)
+	c.validators[name] = definer
+	return c
+}
+
+// document returns the components object, with a schema for each registered validator
+// Nested struct validators are referenced with $ref, and those that weren't
+// registered are added under the name of their type
+func (c *OpenAPIComponents) document() map[string]any {
+	g := newSchemaGenerator(openAPIRefPrefix)
+	g.refStructs = true
+
+	// names are reserved before any schemas are built so nested structs refer to them
+	for _, name := range c.names {
+		g.refs[c.validators[name]] = name
+		g.names[name] = true
+	}
+
+	for _, name := range c.names {
+		g.defs[name] = c.validators[name].schemaDefinition(g)
+	}
+
+	return map[string]any{
+		"components": map[string]any{
+			"schemas": g.defs,
+		},
+	}
+}
+
+// JSON returns the components object as JSON, ready to be merged into an OpenAPI document
+// It only returns an error if a value passed to a rule can't be encoded as JSON
+func (c *OpenAPIComponents) JSON() ([]byte, error) {
+	return json.MarshalIndent(c.document(), "", "  ")
+}
+
+// YAML returns the components object as YAML, ready to be merged into an OpenAPI document
+// It only returns an error if a value passed to a rule can't be encoded as JSON
+func (c *OpenAPIComponents) YAML() ([]byte, error) {
+	out, err := json.Marshal(c.document())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// decoding the JSON leaves only maps, slices, and scalars to write
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(out))
+	dec.UseNumber()
+	_ = dec.Decode(&doc)
+
+	_, lines := yamlNode(doc)
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// yamlPlainPattern matches strings that can be written in YAML without quotes
+var yamlPlainPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_.$/-]*$`)
+
+// yamlReserved lists plain strings that YAML parsers read as something other than a string
+var yamlReserved = []string{"true", "false", "yes", "no", "on", "off", "null", "y", "n"}
+
+// yamlNode returns a decoded JSON value as YAML
+// Scalars and empty collections are returned inline, and other values are returned as lines
+func yamlNode(value any) (string, []string) {
+	var lines []string
+
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			return "{}", nil
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			inline, nested := yamlNode(v[key])
+
+			if nested == nil {
+				lines = append(lines, yamlString(key)+": "+inline)
+				continue
+			}
+
+			lines = append(lines, yamlString(key)+":")
+
+			for _, line := range nested {
+				lines = append(lines, "  "+line)
+			}
+		}
+	case []any:
+		if len(v) == 0 {
+			return "[]", nil
+		}
+
+		for _, item := range v {
+			inline, nested := yamlNode(item)
+
+			if nested == nil {
+				lines = append(lines, "- "+inline)
+				continue
+			}
+
+			lines = append(lines, "- "+nested[0])
+
+			for _, line := range nested[1:] {
+				lines = append(lines, "  "+line)
+			}
+		}
+	case string:
+		return yamlString(v), nil
+	case nil:
+		return "null", nil
+	default:
+		// bools and json.Number are written the same way in both formats
+		return fmt.Sprint(v), nil
+	}
+
+	return "", lines
+}
+
+// yamlString returns a string as a YAML scalar, quoting it if it could be read as something else
+// JSON escape sequences are also valid in double-quoted YAML strings
+func yamlString(str string) string {
+	if yamlPlainPattern.MatchString(str) && !slices.Contains(yamlReserved, strings.ToLower(str)) {
+		return str
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(str)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
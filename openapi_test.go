@@ -0,0 +1,257 @@
+package ensure_test
+
+import (
+	"encoding/json"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+)
+
+type apiAddress struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip"`
+}
+
+type apiUser struct {
+	Name     string        `json:"name"`
+	Address  *apiAddress   `json:"address"`
+	Previous []*apiAddress `json:"previous"`
+	Manager  *apiUser      `json:"manager"`
+	Node     *schemaNode   `json:"node"`
+}
+
+type apiRefs struct {
+	Name string
+	Refs []*int
+}
+
+type apiChannels struct {
+	Chans []chan int
+}
+
+// apiComponents returns components for a user and an address, with a nested node validator that isn't registered
+func apiComponents() *ensure.OpenAPIComponents {
+	validAddress := ensure.Struct[apiAddress]().HasFields(with.Validators{
+		"Street": ensure.String().IsNotEmpty(),
+		"Zip":    ensure.String().Matches(`^\d{5}$`),
+	})
+
+	validNode := ensure.Struct[schemaNode]().HasFields(with.Validators{
+		"Value": ensure.String(),
+	})
+
+	validUser := ensure.Struct[apiUser]()
+	validUser.HasFields(with.Validators{
+		"Name":     ensure.String(),
+		"Address":  ensure.Pointer[apiAddress](validAddress),
+		"Previous": ensure.Array[*apiAddress]().Each(ensure.Pointer[apiAddress](validAddress)),
+		"Manager":  ensure.OptionalPointer[apiUser](validUser),
+		"Node":     ensure.OptionalPointer[schemaNode](validNode),
+	}, with.DisplayNames{
+		"Name": "Full Name",
+	})
+
+	return ensure.NewOpenAPIComponents().
+		Add("User", validUser).
+		Add("Address", validAddress)
+}
+
+const apiExpectJSON = `{
+	"components": {
+		"schemas": {
+			"Address": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string", "minLength": 1},
+					"zip": {"type": "string", "pattern": "^\\d{5}$"}
+				}
+			},
+			"User": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "title": "Full Name"},
+					"address": {"$ref": "#/components/schemas/Address"},
+					"previous": {"type": "array", "items": {"$ref": "#/components/schemas/Address"}},
+					"manager": {"anyOf": [{"$ref": "#/components/schemas/User"}, {"type": "null"}]},
+					"node": {"anyOf": [{"$ref": "#/components/schemas/schemaNode"}, {"type": "null"}]}
+				},
+				"required": ["address"]
+			},
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"}
+				}
+			}
+		}
+	}
+}`
+
+func TestOpenAPIComponents_JSON(t *testing.T) {
+	out, err := apiComponents().JSON()
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	var got, want map[string]any
+
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("output is not valid JSON: %s", err)
+	}
+
+	if err := json.Unmarshal([]byte(apiExpectJSON), &want); err != nil {
+		t.Fatalf("invalid expected JSON: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected\n%s\ngot\n%s", apiExpectJSON, out)
+	}
+}
+
+func TestOpenAPIComponents_YAML(t *testing.T) {
+	out, err := apiComponents().YAML()
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	expect := `components:
+  schemas:
+    Address:
+      properties:
+        street:
+          minLength: 1
+          type: string
+        zip:
+          pattern: "^\\d{5}$"
+          type: string
+      type: object
+    User:
+      properties:
+        address:
+          $ref: "#/components/schemas/Address"
+        manager:
+          anyOf:
+            - $ref: "#/components/schemas/User"
+            - type: "null"
+        name:
+          title: "Full Name"
+          type: string
+        node:
+          anyOf:
+            - $ref: "#/components/schemas/schemaNode"
+            - type: "null"
+        previous:
+          items:
+            $ref: "#/components/schemas/Address"
+          type: array
+      required:
+        - address
+      type: object
+    schemaNode:
+      properties:
+        value:
+          type: string
+      type: object
+`
+
+	if string(out) != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+}
+
+func TestOpenAPIComponents_YAMLValues(t *testing.T) {
+	v := ensure.Struct[schemaNode]().HasFields(with.Validators{
+		"Value": ensure.String().IsOneOf([]string{"yes", "<a & b>"}).IsNotOneOf([]string{}).Is(func(string) error { return nil }),
+	})
+
+	refs := ensure.Struct[apiRefs]().HasFields(with.Validators{
+		"Refs": ensure.ComparableArray[*int]().Contains(nil),
+	}).FieldRequiredIf("Refs", "Name", "x")
+
+	out, err := ensure.NewOpenAPIComponents().Add("Node", v).Add("Refs", refs).YAML()
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	expect := `components:
+  schemas:
+    Node:
+      properties:
+        value:
+          enum:
+            - "yes"
+            - "<a & b>"
+          not:
+            enum: []
+          type: string
+          x-ensure-rules:
+            - rule: Is
+      type: object
+    Refs:
+      properties:
+        Refs:
+          contains:
+            const: null
+          type: array
+      type: object
+      x-ensure-rules:
+        - params:
+            expected: x
+            field: Refs
+            other: Name
+          rule: FieldRequiredIf
+`
+
+	if string(out) != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+
+	empty, _ := ensure.NewOpenAPIComponents().YAML()
+
+	if string(empty) != "components:\n  schemas: {}\n" {
+		t.Errorf("expected empty schemas; got\n%s", empty)
+	}
+}
+
+func TestOpenAPIComponents_Error(t *testing.T) {
+	// channels are comparable, but can't be encoded as JSON
+	v := ensure.Struct[apiChannels]().HasFields(with.Validators{
+		"Chans": ensure.ComparableArray[chan int]().Contains(make(chan int)),
+	})
+
+	c := ensure.NewOpenAPIComponents().Add("Channels", v)
+
+	if _, err := c.JSON(); err == nil {
+		t.Errorf("expected an error from JSON")
+	}
+
+	if _, err := c.YAML(); err == nil {
+		t.Errorf("expected an error from YAML")
+	}
+}
+
+func TestOpenAPIComponents_Add(t *testing.T) {
+	v := ensure.Struct[schemaNode]()
+
+	testCases := map[string]func(){
+		"not a struct":   func() { ensure.NewOpenAPIComponents().Add("Name", ensure.String()) },
+		"invalid name":   func() { ensure.NewOpenAPIComponents().Add("a name", v) },
+		"duplicate name": func() { ensure.NewOpenAPIComponents().Add("Node", v).Add("Node", v) },
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package ensure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SegmentKind identifies what a PathSegment refers to
+type SegmentKind int
+
+const (
+	// FieldSegment refers to a struct field or getter method
+	FieldSegment SegmentKind = iota
+
+	// IndexSegment refers to an element of an array
+	IndexSegment
+
+	// KeySegment refers to an entry in a map
+	KeySegment
+)
+
+// PathSegment is a single step on the way from the value passed to Validate to
+// the value that failed validation
+type PathSegment struct {
+	Kind SegmentKind
+
+	// Name is the struct field or getter name for a FieldSegment
+	Name string
+
+	// DisplayName is the user-friendly name for a FieldSegment
+	DisplayName string
+
+	// Index is the array index for an IndexSegment
+	Index int
+
+	// Key is the map key for a KeySegment
+	Key any
+}
+
+// fieldSegment returns a PathSegment for a struct field or getter
+func fieldSegment(name string, displayName string) PathSegment {
+	return PathSegment{Kind: FieldSegment, Name: name, DisplayName: displayName}
+}
+
+// indexSegment returns a PathSegment for an array index
+func indexSegment(idx int) PathSegment {
+	return PathSegment{Kind: IndexSegment, Index: idx}
+}
+
+// keySegment returns a PathSegment for a map key
+func keySegment(key any) PathSegment {
+	return PathSegment{Kind: KeySegment, Key: key}
+}
+
+// String returns the segment as it would appear in a dotted path
+func (s PathSegment) String() string {
+	switch s.Kind {
+	case IndexSegment:
+		return strconv.Itoa(s.Index)
+	case KeySegment:
+		return fmt.Sprint(s.Key)
+	default:
+		return s.Name
+	}
+}
+
+// Path identifies the location of a value within a larger structure, such as
+// a field within a struct or an element within an array
+type Path []PathSegment
+
+// String returns the path in dotted notation (eg "Employees.2.FirstName")
+func (p Path) String() string {
+	parts := make([]string, len(p))
+
+	for i, seg := range p {
+		parts[i] = seg.String()
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// JSONPointer returns the path as an RFC 6901 JSON Pointer (eg "/Employees/2/FirstName")
+// The empty path is rendered as "", which refers to the whole document
+// Struct fields are named as they are in Go, not by their json tags, so the pointer only
+// refers to a location in the encoded document if the two names match
+func (p Path) JSONPointer() string {
+	var sb strings.Builder
+
+	for _, seg := range p {
+		sb.WriteString("/")
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(seg.String()))
+	}
+
+	return sb.String()
+}
+
+// Brackets returns the path in bracket notation (eg `Employees[2].FirstName` or `Labels["env"]`)
+func (p Path) Brackets() string {
+	var sb strings.Builder
+
+	for i, seg := range p {
+		switch seg.Kind {
+		case IndexSegment:
+			sb.WriteString(fmt.Sprintf("[%d]", seg.Index))
+		case KeySegment:
+			if str, ok := seg.Key.(string); ok {
+				sb.WriteString(fmt.Sprintf("[%s]", strconv.Quote(str)))
+			} else {
+				sb.WriteString(fmt.Sprintf("[%v]", seg.Key))
+			}
+		default:
+			if i > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(seg.Name)
+		}
+	}
+
+	return sb.String()
+}
+
+// displayPrefix returns the display names of the fields in the path, formatted
+// to be placed in front of an error message (eg "Employees: Last Name: ")
+func (p Path) displayPrefix() string {
+	var sb strings.Builder
+
+	for _, seg := range p {
+		if seg.Kind == FieldSegment {
+			sb.WriteString(seg.DisplayName)
+			sb.WriteString(": ")
+		}
+	}
+
+	return sb.String()
+}
+
+// prepend returns a new path with the segment added to the front
+func (p Path) prepend(seg PathSegment) Path {
+	path := make(Path, 0, len(p)+1)
+	path = append(path, seg)
+	return append(path, p...)
+}
+
+// prependPath returns a copy of err with seg added to the front of its path
+// Errors that aren't already a TypeError or ValidationError are converted to a ValidationError
+func prependPath(err error, seg PathSegment) error {
+	switch e := err.(type) {
+	case *ValidationErrors:
+		vErrs := newValidationErrors()
+
+		for _, tErr := range e.tErrs {
+			vErrs.tErrs = append(vErrs.tErrs, prependPath(tErr, seg).(*TypeError))
+		}
+
+		for _, vErr := range e.vErrs {
+			vErrs.vErrs = append(vErrs.vErrs, prependPath(vErr, seg).(*ValidationError))
+		}
+
+		return vErrs
+	case *TypeError:
+		tErr := *e
+		tErr.path = e.path.prepend(seg)
+		return &tErr
+	case *ValidationError:
+		vErr := *e
+		vErr.path = e.path.prepend(seg)
+		return &vErr
+	default:
+		return &ValidationError{err: err.Error(), path: Path{seg}, cause: err}
+	}
+}
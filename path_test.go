@@ -0,0 +1,215 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+)
+
+type pathPet struct {
+	Name string
+}
+
+type pathPerson struct {
+	Name   string
+	Pets   []pathPet
+	Labels map[string]string
+	Best   *pathPet
+}
+
+func (p pathPerson) GetName() string {
+	return p.Name
+}
+
+func TestPath_Render(t *testing.T) {
+	path := ensure.Path{
+		{Kind: ensure.FieldSegment, Name: "Employees", DisplayName: "Employees"},
+		{Kind: ensure.IndexSegment, Index: 2},
+		{Kind: ensure.FieldSegment, Name: "Labels", DisplayName: "Labels"},
+		{Kind: ensure.KeySegment, Key: "a/b~c"},
+		{Kind: ensure.KeySegment, Key: 7},
+	}
+
+	testCases := map[string]struct {
+		got    string
+		expect string
+	}{
+		"dotted":       {path.String(), "Employees.2.Labels.a/b~c.7"},
+		"json pointer": {path.JSONPointer(), "/Employees/2/Labels/a~1b~0c/7"},
+		"brackets":     {path.Brackets(), `Employees[2].Labels["a/b~c"][7]`},
+		"empty":        {ensure.Path{}.JSONPointer(), ""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.got != tc.expect {
+				t.Errorf(`expected "%s"; got "%s"`, tc.expect, tc.got)
+			}
+		})
+	}
+}
+
+type pathTagged struct {
+	Email string `json:"email"`
+}
+
+// TestPath_JSONPointerFieldNames checks that JSON Pointers use Go field names rather than json tags
+func TestPath_JSONPointerFieldNames(t *testing.T) {
+	sv := ensure.Struct[pathTagged]().HasFields(with.Validators{
+		"Email": ensure.String().IsNotEmpty(),
+	})
+
+	paths := validationErrorPaths(t, sv.Validate(pathTagged{}, with.Options(with.OptionCollectAllErrors())))
+
+	if _, ok := paths["/Email"]; !ok || len(paths) != 1 {
+		t.Errorf(`expected a single error at "/Email"; got %v`, paths)
+	}
+}
+
+// validationErrorPaths maps the JSON Pointer path of each validation error in err to its message
+func validationErrorPaths(t *testing.T, err error) map[string]string {
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil {
+		t.Fatalf(`expected validation errors; got "%v"`, err)
+	}
+
+	paths := map[string]string{}
+
+	for _, vErr := range vErrs.ValidationErrors() {
+		paths[vErr.Path().JSONPointer()] = vErr.Error()
+	}
+
+	return paths
+}
+
+func TestPath_Nesting(t *testing.T) {
+	validPet := ensure.Struct[pathPet]().HasFields(with.Validators{
+		"Name": ensure.String().IsNotEmpty(),
+	}, with.DisplayNames{
+		"Name": "Pet Name",
+	})
+
+	validPerson := ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Pets":   ensure.Array[pathPet]().Each(validPet),
+		"Labels": ensure.Map[string, string]().EachKey(ensure.String().HasLength(3)).EachValue(ensure.String().IsNotEmpty()),
+		"Best":   ensure.OptionalPointer[pathPet](validPet),
+	}).HasGetters(with.Validators{
+		"GetName": ensure.String().IsNotEmpty(),
+	}, with.DisplayNames{
+		"GetName": "Name",
+	})
+
+	person := pathPerson{
+		Pets:   []pathPet{{"Rex"}, {""}},
+		Labels: map[string]string{"abc": "", "toolong": "x"},
+		Best:   &pathPet{},
+	}
+
+	err := validPerson.Validate(person, with.Options(with.OptionCollectAllErrors()))
+
+	expect := map[string]string{
+		"/Pets/1/Name":    "Pets: Pet Name: must not be empty",
+		"/Labels/abc":     "Labels: must not be empty",
+		"/Labels/toolong": "Labels: length must equal 3; got 7",
+		"/Best/Name":      "Best: Pet Name: must not be empty",
+		"/GetName":        "Name: must not be empty",
+	}
+
+	paths := validationErrorPaths(t, err)
+
+	if len(paths) != len(expect) {
+		t.Errorf("expected %d errors; got %d (%v)", len(expect), len(paths), paths)
+	}
+
+	for path, msg := range expect {
+		if paths[path] != msg {
+			t.Errorf(`expected error "%s" at path "%s"; got "%s"`, msg, path, paths[path])
+		}
+	}
+
+	// the first error is returned when not collecting all errors, but it still has a path
+	err = validPerson.Validate(pathPerson{Pets: []pathPet{{""}}})
+
+	if paths := validationErrorPaths(t, err); paths["/Pets/0/Name"] == "" {
+		t.Errorf(`expected error at "/Pets/0/Name"; got %v`, paths)
+	}
+
+	err = ensure.Map[string, int]().EachKey(ensure.String().HasLength(1)).Validate(map[string]int{"ab": 1})
+
+	if vErr := (&ensure.ValidationError{}); !errors.As(err, &vErr) || vErr.Path().Brackets() != `["ab"]` {
+		t.Errorf(`expected error at path ["ab"]; got "%v"`, err)
+	}
+}
+
+func TestPath_TypeErrors(t *testing.T) {
+	// type errors keep their type and gain a path as they pass up through a struct
+	vErrs := ensure.NewValidationErrors()
+	vErrs.Append(ensure.NewTypeError("type mismatch"))
+
+	nested := ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Name": errValidator{vErrs},
+	})
+
+	err := nested.Validate(pathPerson{}, with.Options(with.OptionCollectAllErrors()))
+	collected := ensure.ErrorAsValidationErrors(err)
+
+	if collected == nil || !collected.HasTypeErrors() || collected.HasValidationErrors() {
+		t.Fatalf(`expected only type errors; got "%v"`, err)
+	}
+
+	if path := collected.TypeErrors()[0].Path().String(); path != "Name" {
+		t.Errorf(`expected type error at path "Name"; got "%s"`, path)
+	}
+
+	nested = ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Name": errValidator{ensure.NewTypeError("type mismatch")},
+	})
+
+	err = nested.Validate(pathPerson{})
+	tErr := &ensure.TypeError{}
+
+	if !errors.As(err, &tErr) || tErr.Path().String() != "Name" {
+		t.Errorf(`expected type error at path "Name"; got "%v"`, err)
+	}
+}
+
+func TestPath_Unwrap(t *testing.T) {
+	validPerson := ensure.Struct[pathPerson]().HasFields(with.Validators{
+		"Best": ensure.Pointer[pathPet](ensure.Struct[pathPet]()),
+	})
+
+	err := validPerson.Validate(pathPerson{})
+
+	if !errors.Is(err, ensure.RequiredPointerMissingErr) {
+		t.Errorf(`expected error to wrap RequiredPointerMissingErr; got "%v"`, err)
+	}
+
+	vErr := &ensure.ValidationError{}
+
+	if !errors.As(err, &vErr) {
+		t.Fatalf(`expected a ValidationError; got "%v"`, err)
+	}
+
+	if vErr.Message() != ensure.RequiredPointerMissingErr.Error() {
+		t.Errorf(`expected message "%s"; got "%s"`, ensure.RequiredPointerMissingErr, vErr.Message())
+	}
+
+	if vErr.Error() != "Best: "+ensure.RequiredPointerMissingErr.Error() {
+		t.Errorf(`expected error to be prefixed with display name; got "%s"`, vErr.Error())
+	}
+}
+
+// errValidator is a validator that always returns the same error
+type errValidator struct {
+	err error
+}
+
+func (v errValidator) Type() string {
+	return "string"
+}
+
+func (v errValidator) ValidateUntyped(_ any, _ ...*with.ValidationOptions) error {
+	return v.err
+}
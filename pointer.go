@@ -1,18 +1,21 @@
 package ensure
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/chriscasto/go-ensure/with"
 	"reflect"
 )
 
-var RequiredPointerMissingErr = errors.New("required value cannot be missing")
+// RequiredPointerMissingErr is returned when a required pointer is nil
+// Use errors.Is to check for it, since a copy is made when a path is added to the error
+var RequiredPointerMissingErr error = newCheckError(CodePointerRequired, nil)
 
 type PointerValidator[T any] struct {
 	parent   with.Validator[T]
 	optional bool
 	t        string
+	message  string
 }
 
 // newPtrValidator instantiates a new PointerValidator
@@ -39,6 +42,26 @@ func (v *PointerValidator[T]) Type() string {
 	return v.t
 }
 
+// WithMessage overrides the message returned when a required pointer is nil
+func (v *PointerValidator[T]) WithMessage(tmpl string) *PointerValidator[T] {
+	if v.optional {
+		panic("optional pointers do not return an error when missing")
+	}
+
+	v.message = tmpl
+	return v
+}
+
+// missingError returns the error used when a required pointer is nil, which is
+// RequiredPointerMissingErr itself unless its message is overridden or translated
+func (v *PointerValidator[T]) missingError(opts *with.ValidationOptions) error {
+	if v.message != "" {
+		return overrideMessage(RequiredPointerMissingErr, v.message, nil)
+	}
+
+	return translate(RequiredPointerMissingErr, opts)
+}
+
 // ValidateUntyped accepts an arbitrary input type and validates it if it's a pointer to the expected type
 func (v *PointerValidator[T]) ValidateUntyped(i any, options ...*with.ValidationOptions) error {
 	refVal := reflect.ValueOf(i)
@@ -49,7 +72,7 @@ func (v *PointerValidator[T]) ValidateUntyped(i any, options ...*with.Validation
 
 	if refVal.IsNil() {
 		if !v.optional {
-			return RequiredPointerMissingErr
+			return v.missingError(getValidationOptions(options))
 		}
 		return nil
 	}
@@ -59,12 +82,47 @@ func (v *PointerValidator[T]) ValidateUntyped(i any, options ...*with.Validation
 
 // Validate applies all checks against a boolean value and returns an error if any fail
 func (v *PointerValidator[T]) Validate(i *T, options ...*with.ValidationOptions) error {
+	return v.validate(i, getValidationOptions(options))
+}
+
+// validate applies all checks against a pointer with the provided options
+func (v *PointerValidator[T]) validate(i *T, opts *with.ValidationOptions) error {
 	if i == nil {
 		if !v.optional {
-			return RequiredPointerMissingErr
+			return v.missingError(opts)
 		}
 		return nil
 	}
 
-	return v.parent.Validate(*i, options...)
+	return validateWith(v.parent, *i, opts)
+}
+
+// validateRef validates the pointer that a struct field or getter refers to
+func (v *PointerValidator[T]) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[*T](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *PointerValidator[T]) valueType() reflect.Type {
+	return reflect.TypeFor[*T]()
+}
+
+// normalizeRef normalizes the value that a pointer in a struct field points to, if it isn't nil
+func (v *PointerValidator[T]) normalizeRef(ref any, opts *with.ValidationOptions) {
+	if n, ok := v.parent.(normalizer); ok && *ref.(**T) != nil {
+		n.normalizeRef(*ref.(**T), opts)
+	}
+}
+
+// applyDefaultsRef applies defaults to the struct that a pointer in a struct field points to, if it isn't nil
+func (v *PointerValidator[T]) applyDefaultsRef(ref any, opts *with.ValidationOptions) {
+	if dv, ok := v.parent.(defaulter); ok && *ref.(**T) != nil {
+		dv.applyDefaultsRef(*ref.(**T), opts)
+	}
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// in the nested validators and stops early with a CanceledError once ctx is done
+func (v *PointerValidator[T]) ValidateContext(ctx context.Context, i *T, options ...*with.ValidationOptions) error {
+	return v.Validate(i, contextOptions(ctx, options))
 }
@@ -1,6 +1,7 @@
 package ensure_test
 
 import (
+	"errors"
 	"github.com/chriscasto/go-ensure"
 	"github.com/chriscasto/go-ensure/with"
 	"testing"
@@ -123,6 +124,11 @@ func TestPointer_Validate(t *testing.T) {
 			map[string]bool{"abc": true},
 			false,
 		},
+		"nil optional ptr": {
+			ensure.OptionalPointer[string](ensure.String()),
+			(*string)(nil),
+			true,
+		},
 		"struct ptr": {
 			ensure.Pointer[testStruct](ensure.Struct[testStruct]()),
 			ptrTo(testStruct{}),
@@ -147,6 +153,29 @@ func TestPointer_Validate(t *testing.T) {
 	}
 }
 
+// TestPointer_MissingErr checks that a nil required pointer returns RequiredPointerMissingErr
+// itself, unless its message is translated or overridden
+func TestPointer_MissingErr(t *testing.T) {
+	var nilStr *string
+
+	if err := ensure.Pointer[string](ensure.String()).Validate(nilStr); err != ensure.RequiredPointerMissingErr {
+		t.Errorf(`expected RequiredPointerMissingErr; got "%v"`, err)
+	}
+
+	testCases := map[string]error{
+		"translated": ensure.Pointer[string](ensure.String()).Validate(nilStr, with.Options(with.OptionLocale("de"))),
+		"overridden": ensure.Pointer[string](ensure.String()).WithMessage("required").Validate(nilStr),
+	}
+
+	for name, err := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err == ensure.RequiredPointerMissingErr || !errors.Is(err, ensure.RequiredPointerMissingErr) {
+				t.Errorf(`expected a copy of RequiredPointerMissingErr; got "%v"`, err)
+			}
+		})
+	}
+}
+
 func TestOptionalPointer(t *testing.T) {
 	var nilStr *string
 	validStr := ensure.String()
@@ -0,0 +1,79 @@
+package ensure
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of an RFC 7807 problem details document
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details document describing a failed validation
+// Only validation errors are included in the document; type errors are left out
+// since they should not be passed back to the user
+type ProblemDetails struct {
+	Type     string             `json:"type"`
+	Title    string             `json:"title"`
+	Status   int                `json:"status"`
+	Detail   string             `json:"detail,omitempty"`
+	Instance string             `json:"instance,omitempty"`
+	Errors   []*ValidationError `json:"errors"`
+}
+
+// NewProblemDetails returns a ProblemDetails document for an error returned by Validate
+// The status is 400 Bad Request, unless the error contains only type errors, in which case
+// it is 500 Internal Server Error since type errors indicate a misconfiguration, or validation
+// was canceled, in which case it is 503 Service Unavailable since the value wasn't fully checked
+func NewProblemDetails(err error) *ProblemDetails {
+	vErrs := problemErrors(err)
+	status := http.StatusBadRequest
+
+	if cErr := (&CanceledError{}); errors.As(err, &cErr) {
+		status = http.StatusServiceUnavailable
+	} else if err != nil && len(vErrs) == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	return &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Errors: vErrs,
+	}
+}
+
+// problemErrors returns the validation errors contained in err
+func problemErrors(err error) []*ValidationError {
+	if err == nil {
+		return []*ValidationError{}
+	}
+
+	if vErrs := ErrorAsValidationErrors(err); vErrs != nil {
+		return append([]*ValidationError{}, vErrs.vErrs...)
+	}
+
+	if tErr := (&TypeError{}); errors.As(err, &tErr) {
+		return []*ValidationError{}
+	}
+
+	// a canceled validation says nothing about whether the value is valid
+	if cErr := (&CanceledError{}); errors.As(err, &cErr) {
+		return []*ValidationError{}
+	}
+
+	if vErr := (&ValidationError{}); errors.As(err, &vErr) {
+		return []*ValidationError{vErr}
+	}
+
+	// checks added with Is() may return plain errors, which are treated as validation errors
+	return []*ValidationError{{err: err.Error(), cause: err}}
+}
+
+// Write encodes the document as JSON and writes it to w, along with the
+// matching Content-Type header and status code
+func (p *ProblemDetails) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
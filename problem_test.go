@@ -0,0 +1,179 @@
+package ensure_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type problemPet struct {
+	Name string
+}
+
+type problemPerson struct {
+	Name string
+	Pets []problemPet
+}
+
+// fields are added separately so errors are always reported in the same order
+var validProblemPerson = ensure.Struct[problemPerson]().HasFields(with.Validators{
+	"Name": ensure.String().IsNotEmpty(),
+}).HasFields(with.Validators{
+	"Pets": ensure.Array[problemPet]().Each(
+		ensure.Struct[problemPet]().HasFields(with.Validators{
+			"Name": ensure.String().HasLength(3),
+		}),
+	),
+})
+
+// marshal encodes v as JSON, failing the test on error
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+
+	body, err := json.Marshal(v)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return string(body)
+}
+
+func TestErrors_MarshalJSON(t *testing.T) {
+	err := validProblemPerson.Validate(problemPerson{
+		Pets: []problemPet{{"Rex"}, {"a/b"}, {"Fido"}},
+	}, with.Options(with.OptionCollectAllErrors()))
+
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil {
+		t.Fatalf(`expected validation errors; got "%v"`, err)
+	}
+
+	vErrs.Append(ensure.NewTypeError("string expected"))
+
+	testCases := map[string]struct {
+		value  any
+		expect string
+	}{
+		"validation error": {
+			vErrs.ValidationErrors()[0],
+			`{"path":"/Name","code":"string.not_empty","message":"must not be empty"}`,
+		},
+		"type error": {
+			ensure.NewTypeError("string expected"),
+			`{"path":"","code":"type","message":"value has an unexpected type"}`,
+		},
+		"validation errors": {
+			vErrs,
+			`[{"path":"/Name","code":"string.not_empty","message":"must not be empty"},` +
+				`{"path":"/Pets/2/Name","code":"string.length.eq","message":"length must equal 3; got 4",` +
+				`"params":{"actual":4,"expected":3}}]`,
+		},
+		"custom error": {
+			ensure.NewValidationError("must be odd"),
+			`{"path":"","code":"invalid","message":"must be odd"}`,
+		},
+		"duration params": {
+			ensure.Duration().IsLessThan(time.Hour).Validate(90 * time.Minute),
+			`{"path":"","code":"duration.lt","message":"duration must be less than 1h0m0s; got 1h30m0s",` +
+				`"params":{"actual":"1h30m0s","expected":"1h0m0s"}}`,
+		},
+		"empty validation errors": {
+			&ensure.ValidationErrors{},
+			`[]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := marshal(t, tc.value); got != tc.expect {
+				t.Errorf("expected %s; got %s", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestNewProblemDetails(t *testing.T) {
+	collected := validProblemPerson.Validate(problemPerson{
+		Pets: []problemPet{{"Fido"}},
+	}, with.Options(with.OptionCollectAllErrors()))
+
+	onlyTypeErrors := ensure.NewValidationErrors()
+	onlyTypeErrors.Append(ensure.NewTypeError("string expected"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := map[string]struct {
+		err    error
+		status int
+		paths  []string
+	}{
+		"no error":          {nil, http.StatusBadRequest, []string{}},
+		"collected errors":  {collected, http.StatusBadRequest, []string{"/Name", "/Pets/0/Name"}},
+		"single error":      {validProblemPerson.Validate(problemPerson{}), http.StatusBadRequest, []string{"/Name"}},
+		"plain error":       {errors.New("must be odd"), http.StatusBadRequest, []string{""}},
+		"validation error":  {ensure.NewValidationError("must be odd"), http.StatusBadRequest, []string{""}},
+		"only type errors":  {onlyTypeErrors, http.StatusInternalServerError, []string{}},
+		"single type error": {ensure.NewTypeError("string expected"), http.StatusInternalServerError, []string{}},
+		"canceled":          {validProblemPerson.ValidateContext(ctx, problemPerson{}), http.StatusServiceUnavailable, []string{}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			problem := ensure.NewProblemDetails(tc.err)
+
+			if problem.Status != tc.status {
+				t.Errorf("expected status %d; got %d", tc.status, problem.Status)
+			}
+
+			if problem.Title != http.StatusText(tc.status) {
+				t.Errorf(`expected title "%s"; got "%s"`, http.StatusText(tc.status), problem.Title)
+			}
+
+			if len(problem.Errors) != len(tc.paths) {
+				t.Fatalf("expected %d errors; got %d", len(tc.paths), len(problem.Errors))
+			}
+
+			for i, path := range tc.paths {
+				if got := problem.Errors[i].Path().JSONPointer(); got != path {
+					t.Errorf(`expected path "%s"; got "%s"`, path, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProblemDetails_Write(t *testing.T) {
+	problem := ensure.NewProblemDetails(validProblemPerson.Validate(problemPerson{}))
+	problem.Instance = "/people"
+
+	rec := httptest.NewRecorder()
+
+	if err := problem.Write(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != ensure.ProblemContentType {
+		t.Errorf(`expected content type "%s"; got "%s"`, ensure.ProblemContentType, ct)
+	}
+
+	expect := `{"type":"about:blank","title":"Bad Request","status":400,"instance":"/people",` +
+		`"errors":[{"path":"/Name","code":"string.not_empty","message":"must not be empty"}]}`
+
+	if got := strings.TrimSpace(rec.Body.String()); got != expect {
+		t.Errorf("expected %s; got %s", expect, got)
+	}
+}
@@ -1,10 +1,13 @@
 package ensure
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/chriscasto/go-ensure/with"
+	"golang.org/x/text/unicode/norm"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -35,12 +38,15 @@ const (
 // StringValidator contains information and logic used to validate a string
 type StringValidator struct {
 	checks *lenChecks[string, string, string]
+
+	// normalizers transform the string, in the order they were added, before any checks run
+	normalizers []func(string) string
 }
 
 // String returns an initialized StringValidator
 func String() *StringValidator {
 	return &StringValidator{
-		checks: newLenChecks[string, string, string](),
+		checks: newLenChecks[string, string, string](stringLenCodes),
 	}
 }
 
@@ -52,6 +58,7 @@ func (v *StringValidator) Type() string {
 // HasLengthWhere adds a NumberValidator for validating the length of the string
 func (v *StringValidator) HasLengthWhere(nv *NumberValidator[int]) *StringValidator {
 	v.checks.AddHasLengthWhere(nv)
+	v.checks.Record(rule{name: "HasLengthWhere", nested: nv})
 	return v
 }
 
@@ -68,17 +75,94 @@ func (v *StringValidator) ValidateUntyped(value any, options ...*with.Validation
 
 // Validate applies all checks against a string value and returns an error if any fail
 func (v *StringValidator) Validate(str string, options ...*with.ValidationOptions) error {
-	return v.checks.Evaluate(str, getValidationOptions(options))
+	return v.validate(str, getValidationOptions(options))
+}
+
+// validate applies all checks against a string value with the provided options
+func (v *StringValidator) validate(str string, opts *with.ValidationOptions) error {
+	return v.checks.Evaluate(v.normalize(str), opts)
+}
+
+// ValidateAndNormalize applies any normalizers to the string, then applies all checks against
+// the result and returns it, along with an error if any checks fail
+func (v *StringValidator) ValidateAndNormalize(str string, options ...*with.ValidationOptions) (string, error) {
+	str = v.normalize(str)
+	return str, v.checks.Evaluate(str, getValidationOptions(options))
+}
+
+// normalize applies the normalizers to a string
+func (v *StringValidator) normalize(str string) string {
+	for _, fn := range v.normalizers {
+		str = fn(str)
+	}
+
+	return str
+}
+
+// normalizeRef normalizes the string that a struct field refers to in place
+func (v *StringValidator) normalizeRef(ref any, _ *with.ValidationOptions) {
+	str := ref.(*string)
+	*str = v.normalize(*str)
+}
+
+// validateScalar validates a string read from a struct field
+func (v *StringValidator) validateScalar(s scalar, opts *with.ValidationOptions) error {
+	return v.validate(s.str, opts)
+}
+
+// validateRef validates the string that a struct field or getter refers to
+func (v *StringValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[string](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *StringValidator) valueType() reflect.Type {
+	return reflect.TypeFor[string]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (v *StringValidator) ValidateContext(ctx context.Context, str string, options ...*with.ValidationOptions) error {
+	return v.Validate(str, contextOptions(ctx, options))
+}
+
+// Trim adds a normalizer that removes leading and trailing whitespace before the checks run
+func (v *StringValidator) Trim() *StringValidator {
+	return v.addNormalizer("Trim", strings.TrimSpace)
+}
+
+// ToLower adds a normalizer that converts the string to lowercase before the checks run
+func (v *StringValidator) ToLower() *StringValidator {
+	return v.addNormalizer("ToLower", strings.ToLower)
+}
+
+// CollapseSpaces adds a normalizer that replaces each run of whitespace with a single
+// space before the checks run
+func (v *StringValidator) CollapseSpaces() *StringValidator {
+	return v.addNormalizer("CollapseSpaces", collapseSpaces)
+}
+
+// NormalizeNFC adds a normalizer that converts the string to Unicode Normalization Form C
+// before the checks run, so characters that can be written more than one way compare equal
+func (v *StringValidator) NormalizeNFC() *StringValidator {
+	return v.addNormalizer("NormalizeNFC", norm.NFC.String)
+}
+
+// addNormalizer adds a function that transforms the string before the checks run
+// It's recorded as a rule named after the method that added it, so it's included
+// when the validator is described or exported
+func (v *StringValidator) addNormalizer(name string, fn func(string) string) *StringValidator {
+	v.normalizers = append(v.normalizers, fn)
+	v.checks.Record(rule{name: name})
+	return v
 }
 
 // Equals adds a validation check that returns an error if the target string
 // is not identical to the specified string
 func (v *StringValidator) Equals(same string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "Equals", params: map[string]any{"expected": same}}, func(str string) error {
 		if str != same {
-			return errors.New(
-				fmt.Sprintf(`string must equal "%s"`, same),
-			)
+			return newCheckError(CodeStringEquals, map[string]any{"expected": same, "actual": str})
 		}
 		return nil
 	})
@@ -87,11 +171,9 @@ func (v *StringValidator) Equals(same string) *StringValidator {
 // DoesNotEqual adds a validation check that returns an error if the target string
 // is identical to the specified string
 func (v *StringValidator) DoesNotEqual(diff string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "DoesNotEqual", params: map[string]any{"expected": diff}}, func(str string) error {
 		if str == diff {
-			return errors.New(
-				fmt.Sprintf(`string must not equal "%s"`, diff),
-			)
+			return newCheckError(CodeStringNotEquals, map[string]any{"expected": diff, "actual": str})
 		}
 		return nil
 	})
@@ -100,11 +182,9 @@ func (v *StringValidator) DoesNotEqual(diff string) *StringValidator {
 // StartsWith adds a validation check that returns an error if the target string
 // does not start with the specified substring
 func (v *StringValidator) StartsWith(prefix string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "StartsWith", params: map[string]any{"expected": prefix}}, func(str string) error {
 		if !strings.HasPrefix(str, prefix) {
-			return errors.New(
-				fmt.Sprintf(`string must start with "%s"`, prefix),
-			)
+			return newCheckError(CodeStringStartsWith, map[string]any{"expected": prefix, "actual": str})
 		}
 		return nil
 	})
@@ -113,11 +193,9 @@ func (v *StringValidator) StartsWith(prefix string) *StringValidator {
 // DoesNotStartWith adds a validation check that returns an error if the target string
 // starts with the specified substring
 func (v *StringValidator) DoesNotStartWith(prefix string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "DoesNotStartWith", params: map[string]any{"expected": prefix}}, func(str string) error {
 		if strings.HasPrefix(str, prefix) {
-			return errors.New(
-				fmt.Sprintf(`string must not start with "%s"`, prefix),
-			)
+			return newCheckError(CodeStringNotStartsWith, map[string]any{"expected": prefix, "actual": str})
 		}
 		return nil
 	})
@@ -126,11 +204,9 @@ func (v *StringValidator) DoesNotStartWith(prefix string) *StringValidator {
 // EndsWith adds a validation check that returns an error if the target string
 // does not end with the specified substring
 func (v *StringValidator) EndsWith(suffix string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "EndsWith", params: map[string]any{"expected": suffix}}, func(str string) error {
 		if !strings.HasSuffix(str, suffix) {
-			return errors.New(
-				fmt.Sprintf(`string must end with "%s"`, suffix),
-			)
+			return newCheckError(CodeStringEndsWith, map[string]any{"expected": suffix, "actual": str})
 		}
 		return nil
 	})
@@ -139,11 +215,9 @@ func (v *StringValidator) EndsWith(suffix string) *StringValidator {
 // DoesNotEndWith adds a validation check that returns an error if the target string
 // ends with the specified substring
 func (v *StringValidator) DoesNotEndWith(suffix string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "DoesNotEndWith", params: map[string]any{"expected": suffix}}, func(str string) error {
 		if strings.HasSuffix(str, suffix) {
-			return errors.New(
-				fmt.Sprintf(`string must not end with "%s"`, suffix),
-			)
+			return newCheckError(CodeStringNotEndsWith, map[string]any{"expected": suffix, "actual": str})
 		}
 		return nil
 	})
@@ -152,11 +226,9 @@ func (v *StringValidator) DoesNotEndWith(suffix string) *StringValidator {
 // Contains adds a validation check that returns an error if the target string
 // does not contain the specified substring
 func (v *StringValidator) Contains(substr string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "Contains", params: map[string]any{"expected": substr}}, func(str string) error {
 		if !strings.Contains(str, substr) {
-			return errors.New(
-				fmt.Sprintf(`string must contain "%s"`, substr),
-			)
+			return newCheckError(CodeStringContains, map[string]any{"expected": substr, "actual": str})
 		}
 		return nil
 	})
@@ -165,11 +237,9 @@ func (v *StringValidator) Contains(substr string) *StringValidator {
 // DoesNotContain adds a validation check that returns an error if the target string
 // contains the specified substring
 func (v *StringValidator) DoesNotContain(substr string) *StringValidator {
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "DoesNotContain", params: map[string]any{"expected": substr}}, func(str string) error {
 		if strings.Contains(str, substr) {
-			return errors.New(
-				fmt.Sprintf(`string must not contain "%s"`, substr),
-			)
+			return newCheckError(CodeStringNotContains, map[string]any{"expected": substr, "actual": str})
 		}
 		return nil
 	})
@@ -179,6 +249,7 @@ func (v *StringValidator) DoesNotContain(substr string) *StringValidator {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(0))
 func (v *StringValidator) IsEmpty() *StringValidator {
 	v.checks.AddIsEmpty()
+	v.checks.Record(rule{name: "IsEmpty"})
 	return v
 }
 
@@ -186,12 +257,15 @@ func (v *StringValidator) IsEmpty() *StringValidator {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().DoesNotEqual(0))
 func (v *StringValidator) IsNotEmpty() *StringValidator {
 	v.checks.AddIsNotEmpty()
+	v.checks.Record(rule{name: "IsNotEmpty"})
 	return v
 }
 
 // IsOneOf adds a validation check that returns an error if the target string
 // is not in the specified set
 func (v *StringValidator) IsOneOf(values []string) *StringValidator {
+	permitted := slices.Clone(values)
+
 	// convert list to map for O(1) lookups
 	lookup := map[string]bool{}
 
@@ -199,9 +273,9 @@ func (v *StringValidator) IsOneOf(values []string) *StringValidator {
 		lookup[str] = true
 	}
 
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "IsOneOf", params: map[string]any{"values": permitted}}, func(str string) error {
 		if _, ok := lookup[str]; !ok {
-			return errors.New(`string must be one of the permitted values`)
+			return newCheckError(CodeStringOneOf, map[string]any{"expected": permitted, "actual": str})
 		}
 		return nil
 	})
@@ -217,9 +291,9 @@ func (v *StringValidator) IsNotOneOf(values []string) *StringValidator {
 		lookup[str] = true
 	}
 
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "IsNotOneOf", params: map[string]any{"values": slices.Clone(values)}}, func(str string) error {
 		if _, ok := lookup[str]; ok {
-			return errors.New(`string must not be one of the prohibited values`)
+			return newCheckError(CodeStringNotOneOf, map[string]any{"actual": str})
 		}
 		return nil
 	})
@@ -229,6 +303,7 @@ func (v *StringValidator) IsNotOneOf(values []string) *StringValidator {
 // string length is less than or equal to the specified value
 func (v *StringValidator) IsLongerThan(l int) *StringValidator {
 	v.checks.AddIsLongerThan(l)
+	v.checks.Record(rule{name: "IsLongerThan", params: map[string]any{"expected": l}})
 	return v
 }
 
@@ -236,6 +311,7 @@ func (v *StringValidator) IsLongerThan(l int) *StringValidator {
 // string length is greater than or equal to the specified value
 func (v *StringValidator) IsShorterThan(l int) *StringValidator {
 	v.checks.AddIsShorterThan(l)
+	v.checks.Record(rule{name: "IsShorterThan", params: map[string]any{"expected": l}})
 	return v
 }
 
@@ -243,6 +319,7 @@ func (v *StringValidator) IsShorterThan(l int) *StringValidator {
 // This is a convenience function that is equivalent to HasLengthWhere(Length().Equals(l))
 func (v *StringValidator) HasLength(l int) *StringValidator {
 	v.checks.AddHasLength(l)
+	v.checks.Record(rule{name: "HasLength", params: map[string]any{"expected": l}})
 	return v
 }
 
@@ -254,19 +331,42 @@ func (v *StringValidator) Matches(pattern string) *StringValidator {
 		panic(fmt.Sprintf("could not compile regex: %s", err))
 	}
 
-	return v.Is(func(str string) error {
+	return v.addRule(rule{name: "Matches", params: map[string]any{"pattern": pattern}}, func(str string) error {
 		if !r.MatchString(str) {
-			return errors.New(`string does not match expected pattern`)
+			return newCheckError(CodeStringMatches, map[string]any{"actual": str})
 		}
 		return nil
 	})
 }
 
-// Is adds the provided function as a check against any values to be validated
-func (v *StringValidator) Is(fn func(string) error) *StringValidator {
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (v *StringValidator) WithMessage(tmpl string) *StringValidator {
+	v.checks.SetMessage(tmpl)
+	return v
+}
+
+// addRule adds a check along with the rule that describes it
+func (v *StringValidator) addRule(r rule, fn func(string) error) *StringValidator {
 	v.checks.Append(func(val string, _ *with.ValidationOptions) error {
 		return fn(val)
 	})
+	v.checks.Record(r)
+	return v
+}
+
+// Is adds the provided function as a check against any values to be validated
+func (v *StringValidator) Is(fn func(string) error) *StringValidator {
+	return v.addRule(rule{name: "Is"}, fn)
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (v *StringValidator) IsCtx(fn func(context.Context, string) error) *StringValidator {
+	v.checks.Append(func(val string, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	v.checks.Record(rule{name: "IsCtx"})
 	return v
 }
 
@@ -496,3 +496,53 @@ func TestStringValidator_MultiError(t *testing.T) {
 		),
 	)
 }
+
+func TestStringValidator_Normalizers(t *testing.T) {
+	testCases := map[string]struct {
+		v        *ensure.StringValidator
+		value    string
+		expected string
+	}{
+		"trim":                    {ensure.String().Trim(), " \t abc \n", "abc"},
+		"to lower":                {ensure.String().ToLower(), "ABC def", "abc def"},
+		"collapse spaces":         {ensure.String().CollapseSpaces(), "a  b\t\tc\nd", "a b c d"},
+		"collapse leading spaces": {ensure.String().CollapseSpaces(), "\t\t a ", " a "},
+		"collapse unchanged":      {ensure.String().CollapseSpaces(), "a b c", "a b c"},
+		"nfc":                     {ensure.String().NormalizeNFC(), "e\u0301", "\u00e9"},
+		"in order":                {ensure.String().CollapseSpaces().Trim().ToLower(), "  Ann  LEE ", "ann lee"},
+		"none":                    {ensure.String(), " Ann ", " Ann "},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := tc.v.ValidateAndNormalize(tc.value)
+
+			if err != nil {
+				t.Errorf(`expected no error; got "%s"`, err)
+			}
+
+			if actual != tc.expected {
+				t.Errorf(`expected "%s"; got "%s"`, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStringValidator_ValidateAndNormalize(t *testing.T) {
+	sv := ensure.String().Trim().ToLower().Equals("ann@example.com")
+
+	// Checks run against the normalized value
+	if err := sv.Validate("  Ann@Example.com "); err != nil {
+		t.Errorf(`expected no error; got "%s"`, err)
+	}
+
+	actual, err := sv.ValidateAndNormalize(" Bob@Example.com", with.Options(with.OptionCollectAllErrors()))
+
+	if err == nil {
+		t.Errorf("expected error but got none")
+	}
+
+	if actual != "bob@example.com" {
+		t.Errorf(`expected the normalized value to be returned with the error; got "%s"`, actual)
+	}
+}
@@ -1,17 +1,30 @@
 package ensure
 
 import (
+	"context"
 	"fmt"
 	"github.com/chriscasto/go-ensure/with"
+	"maps"
 	"reflect"
+	"slices"
+	"sync"
+	"time"
 )
 
 // validMethod contains information about a method that needs to be called during validation
 type validMethod struct {
-	ref         *reflect.Method
+	name        string
 	displayName string
-	hasValRcvr  bool
 	validator   with.UntypedValidator
+	groups      []string
+
+	// index is the method's index in the method set of a pointer to the struct, which
+	// includes methods with value receivers
+	index int
+
+	// ref is set when the validator accepts exactly the type the method returns, so the
+	// result can be validated without being converted to an interface
+	ref refValidator
 }
 
 // validField contains information about a field that needs to be accessed during validation
@@ -19,14 +32,68 @@ type validField struct {
 	name        string
 	displayName string
 	validator   with.UntypedValidator
+
+	// condition is the position of the When() condition the field depends on, plus one
+	// A value of 0 means the field is always validated
+	condition int
+
+	groups []string
+
+	// index is the field's index path in the struct, resolved when the field is added
+	index []int
+
+	// scalar is set when the field is a string, bool, or number that its validator can
+	// check without the struct being copied
+	scalar scalarValidator
+
+	// check is a checkFunc for the struct's type, set when the field was added with an accessor
+	check any
+
+	// pointer is a func(*T) any that returns a pointer to the field, set when the field was added
+	// with FieldAt, so normalized values can be written back to unexported fields
+	pointer any
+
+	// ref is set when the field isn't a scalar and the validator accepts exactly its type,
+	// so it can be validated without being converted to an interface
+	ref refValidator
+}
+
+// setValidator sets the fast paths for reading a field of type t, which are only used if
+// the validator accepts exactly that type; fields of any other type are read with reflection
+func (vf *validField) setValidator(t reflect.Type) {
+	ref, ok := refFor(vf.validator, t)
+
+	if !ok {
+		return
+	}
+
+	if scalar, ok := ref.(scalarValidator); ok {
+		vf.scalar = scalar
+	} else {
+		vf.ref = ref
+	}
 }
 
 // StructValidator contains information and logic used to validate a struct of type T
 type StructValidator[T any] struct {
-	refVal  reflect.Value
-	checks  *valChecks[T]
-	fields  []*validField
-	getters []*validMethod
+	refVal       reflect.Value
+	checks       *valChecks[T]
+	fields       []*validField
+	getters      []*validMethod
+	conditions   []func(T) bool
+	displayNames map[string]string
+	defaults     []*structDefault
+
+	// frames holds the copies of the struct that values are validated from, so they
+	// can be reused instead of being allocated for each validation
+	frames sync.Pool
+
+	// setLastGroups tags the fields or getters added by the most recent call to
+	// HasFields, HasGetters, When, Unless, Field, or FieldAt with validation groups
+	setLastGroups func(groups []string)
+
+	// setLastMasked causes the most recently added Is() check to run when a field mask is set
+	setLastMasked func()
 }
 
 // Struct constructs a StructValidator instance of type T and returns a pointer to it
@@ -40,12 +107,19 @@ func Struct[T any]() *StructValidator[T] {
 		panic("StructValidator expects a struct type")
 	}
 
-	return &StructValidator[T]{
-		refVal:  ref,
-		fields:  []*validField{},
-		getters: []*validMethod{},
-		checks:  newValChecks[T](),
+	sv := &StructValidator[T]{
+		refVal:       ref,
+		fields:       []*validField{},
+		getters:      []*validMethod{},
+		checks:       newValChecks[T](),
+		displayNames: map[string]string{},
+	}
+
+	sv.frames.New = func() any {
+		return newStructFrame[T]()
 	}
+
+	return sv
 }
 
 // Type returns a string with the name of the struct this validator expects
@@ -53,11 +127,66 @@ func (sv *StructValidator[T]) Type() string {
 	return sv.refVal.Type().String()
 }
 
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (sv *StructValidator[T]) WithMessage(tmpl string) *StructValidator[T] {
+	sv.checks.SetMessage(tmpl)
+	return sv
+}
+
+// addCheck adds a check against the whole struct
+// InGroups and ApplyWhenMasked can't follow every check, so this clears their targets
+func (sv *StructValidator[T]) addCheck(check checkFunc[T]) {
+	sv.checks.Append(check)
+	sv.setLastGroups = nil
+	sv.setLastMasked = nil
+}
+
+// addMaskableCheck adds a check against the whole struct that is skipped when a
+// field mask is set, unless ApplyWhenMasked() follows it
+func (sv *StructValidator[T]) addMaskableCheck(check checkFunc[T]) {
+	applyWhenMasked := false
+
+	sv.addCheck(func(val T, opts *with.ValidationOptions) error {
+		if !applyWhenMasked && isMasked(opts) {
+			return nil
+		}
+		return check(val, opts)
+	})
+
+	sv.setLastMasked = func() {
+		applyWhenMasked = true
+	}
+}
+
+// ApplyWhenMasked causes the preceding Is() or IsCtx() check to run even when
+// only some fields are being validated with with.OptionFieldMask()
+// By default, these checks are skipped under a field mask since they may depend on fields outside it
+func (sv *StructValidator[T]) ApplyWhenMasked() *StructValidator[T] {
+	if sv.setLastMasked == nil {
+		panic("ApplyWhenMasked must follow Is or IsCtx")
+	}
+
+	sv.setLastMasked()
+	return sv
+}
+
 // Is adds the provided function as a check against any values to be validated
 func (sv *StructValidator[T]) Is(fn func(T) error) *StructValidator[T] {
-	sv.checks.Append(func(val T, _ *with.ValidationOptions) error {
+	sv.addMaskableCheck(func(val T, _ *with.ValidationOptions) error {
 		return fn(val)
 	})
+	sv.checks.Record(rule{name: "Is"})
+	return sv
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (sv *StructValidator[T]) IsCtx(fn func(context.Context, T) error) *StructValidator[T] {
+	sv.addMaskableCheck(func(val T, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	sv.checks.Record(rule{name: "IsCtx"})
 	return sv
 }
 
@@ -70,8 +199,33 @@ func (sv *StructValidator[T]) Has(fn func(T) error) *StructValidator[T] {
 // HasFields accepts a map of named fields and their validators to evaluate against a struct during validation
 // It also accepts an optional map of field names to display names to use when printing error messages
 func (sv *StructValidator[T]) HasFields(validators with.Validators, displayNames ...with.DisplayNames) *StructValidator[T] {
+	sv.addFields(validators, displayNames, 0)
+	return sv
+}
+
+// When is like HasFields, but the field validators are only evaluated if the
+// condition returns true for the struct being validated
+// The condition is called once per validation, before any fields are validated
+func (sv *StructValidator[T]) When(condition func(T) bool, validators with.Validators, displayNames ...with.DisplayNames) *StructValidator[T] {
+	sv.conditions = append(sv.conditions, condition)
+	sv.addFields(validators, displayNames, len(sv.conditions))
+	return sv
+}
+
+// Unless is like HasFields, but the field validators are only evaluated if the
+// condition returns false for the struct being validated
+func (sv *StructValidator[T]) Unless(condition func(T) bool, validators with.Validators, displayNames ...with.DisplayNames) *StructValidator[T] {
+	return sv.When(func(s T) bool {
+		return !condition(s)
+	}, validators, displayNames...)
+}
+
+// addFields resolves and type checks field validators and adds them to the list
+// of fields to validate, only when the numbered condition holds if it isn't 0
+func (sv *StructValidator[T]) addFields(validators with.Validators, displayNames []with.DisplayNames, condition int) {
 	ref := sv.refVal
 	aliases := with.DisplayNames{}
+	added := make([]*validField, 0, len(validators))
 
 	// Collect aliases for lookup during field processing
 	if len(displayNames) > 0 {
@@ -86,7 +240,17 @@ func (sv *StructValidator[T]) HasFields(validators with.Validators, displayNames
 		}
 	}
 
-	for name, validator := range validators {
+	// Fields are added in the order they are declared, so they are always validated and described in the same order
+	names := slices.Collect(maps.Keys(validators))
+	slices.SortFunc(names, func(a string, b string) int {
+		fieldA, _ := ref.Type().FieldByName(a)
+		fieldB, _ := ref.Type().FieldByName(b)
+		return slices.Compare(fieldA.Index, fieldB.Index)
+	})
+
+	for _, name := range names {
+		validator := validators[name]
+		structField, _ := ref.Type().FieldByName(name)
 		field := ref.FieldByName(name)
 
 		if !field.IsValid() {
@@ -116,16 +280,184 @@ func (sv *StructValidator[T]) HasFields(validators with.Validators, displayNames
 			displayName = name
 		}
 
-		sv.fields = append(sv.fields, &validField{
+		vf := &validField{
 			name:        name,
 			validator:   validator,
 			displayName: displayName,
-		})
+			condition:   condition,
+			index:       structField.Index,
+		}
+
+		vf.setValidator(field.Type())
+		added = append(added, vf)
+
+		sv.displayNames[name] = displayName
+	}
+
+	sv.fields = append(sv.fields, added...)
+
+	sv.setLastMasked = nil
+	sv.setLastGroups = func(groups []string) {
+		for _, field := range added {
+			field.groups = append(field.groups, groups...)
+		}
+	}
+}
+
+// lookupField returns the exported field with the provided name, or panics if there isn't one
+func (sv *StructValidator[T]) lookupField(name string) reflect.StructField {
+	refType := sv.refVal.Type()
+	field, ok := refType.FieldByName(name)
+
+	if !ok {
+		panic(fmt.Sprintf("field %s does not exist in struct %s", name, refType.String()))
+	}
+
+	if !field.IsExported() {
+		panic(fmt.Sprintf("field %s in struct %s is not exported", name, refType.String()))
+	}
+
+	return field
+}
+
+// fieldDisplayName returns the display name set for a field with HasFields, or
+// the field name if there isn't one, translated for the locale in opts
+func (sv *StructValidator[T]) fieldDisplayName(name string, opts *with.ValidationOptions) string {
+	displayName, ok := sv.displayNames[name]
+
+	if !ok {
+		displayName = name
+	}
+
+	return translateDisplayName(displayName, opts)
+}
+
+// addFieldRule adds a check that compares fields of the struct and attributes
+// any error it returns to the named field
+// When a field mask is set, the check only runs if the named field is in it
+func (sv *StructValidator[T]) addFieldRule(name string, rule func(reflect.Value, *with.ValidationOptions) error) {
+	sv.addCheck(func(s T, opts *with.ValidationOptions) error {
+		if _, ok := fieldMaskOptions(name, opts); !ok {
+			return nil
+		}
+
+		if err := rule(reflect.ValueOf(s), opts); err != nil {
+			return prependPath(err, fieldSegment(name, sv.fieldDisplayName(name, opts)))
+		}
+		return nil
+	})
+}
+
+// FieldEquals adds a rule that returns an error if a field does not equal another field
+// The error is attributed to the first field (eg FieldEquals("PasswordConfirm", "Password"))
+// Both fields must have the same comparable type
+func (sv *StructValidator[T]) FieldEquals(field string, other string) *StructValidator[T] {
+	f := sv.lookupField(field)
+	o := sv.lookupField(other)
+
+	if f.Type != o.Type {
+		panic(fmt.Sprintf("field %s is type [%s] but field %s is type [%s]", field, f.Type.String(), other, o.Type.String()))
+	}
+
+	if !f.Type.Comparable() {
+		panic(fmt.Sprintf("field %s is type [%s], which is not comparable", field, f.Type.String()))
+	}
+
+	sv.addFieldRule(field, func(s reflect.Value, opts *with.ValidationOptions) error {
+		if !s.FieldByIndex(f.Index).Equal(s.FieldByIndex(o.Index)) {
+			return newCheckError(CodeStructFieldEquals, map[string]any{"other": sv.fieldDisplayName(other, opts)})
+		}
+		return nil
+	})
+
+	sv.checks.Record(rule{name: "FieldEquals", params: map[string]any{"field": field, "other": other}})
+	return sv
+}
+
+// FieldLessThan adds a rule that returns an error if a field is not less than another field
+// The error is attributed to the first field (eg FieldLessThan("Start", "End"))
+// Both fields must have the same type, which must be a number, string, or time.Time
+func (sv *StructValidator[T]) FieldLessThan(field string, other string) *StructValidator[T] {
+	f := sv.lookupField(field)
+	o := sv.lookupField(other)
+
+	if f.Type != o.Type {
+		panic(fmt.Sprintf("field %s is type [%s] but field %s is type [%s]", field, f.Type.String(), other, o.Type.String()))
+	}
+
+	less := lessFunc(f.Type)
+
+	if less == nil {
+		panic(fmt.Sprintf("field %s is type [%s], which cannot be ordered", field, f.Type.String()))
+	}
+
+	sv.addFieldRule(field, func(s reflect.Value, opts *with.ValidationOptions) error {
+		if !less(s.FieldByIndex(f.Index), s.FieldByIndex(o.Index)) {
+			return newCheckError(CodeStructFieldLess, map[string]any{"other": sv.fieldDisplayName(other, opts)})
+		}
+		return nil
+	})
+
+	sv.checks.Record(rule{name: "FieldLessThan", params: map[string]any{"field": field, "other": other}})
+	return sv
+}
+
+// FieldRequiredIf adds a rule that returns an error if a field has its zero value
+// while another field equals the provided value (eg FieldRequiredIf("State", "Country", "US"))
+// The value must have the same kind as the other field (eg a string for a field with a custom string type)
+func (sv *StructValidator[T]) FieldRequiredIf(field string, other string, value any) *StructValidator[T] {
+	f := sv.lookupField(field)
+	o := sv.lookupField(other)
+
+	if !o.Type.Comparable() {
+		panic(fmt.Sprintf("field %s is type [%s], which is not comparable", other, o.Type.String()))
 	}
 
+	want := reflect.ValueOf(value)
+
+	if !want.IsValid() || want.Kind() != o.Type.Kind() || !want.CanConvert(o.Type) {
+		panic(fmt.Sprintf("field %s is type [%s] but value is type [%T]", other, o.Type.String(), value))
+	}
+
+	want = want.Convert(o.Type)
+
+	sv.addFieldRule(field, func(s reflect.Value, opts *with.ValidationOptions) error {
+		if s.FieldByIndex(o.Index).Equal(want) && s.FieldByIndex(f.Index).IsZero() {
+			return newCheckError(CodeStructFieldRequired, map[string]any{
+				"other":    sv.fieldDisplayName(other, opts),
+				"expected": value,
+			})
+		}
+		return nil
+	})
+
+	sv.checks.Record(rule{name: "FieldRequiredIf", params: map[string]any{"field": field, "other": other, "expected": value}})
 	return sv
 }
 
+// lessFunc returns a function that reports whether one value is less than another
+// for types with a natural order, or nil for any other type
+func lessFunc(refType reflect.Type) func(a, b reflect.Value) bool {
+	if refType == reflect.TypeOf(time.Time{}) {
+		return func(a, b reflect.Value) bool {
+			return a.Interface().(time.Time).Before(b.Interface().(time.Time))
+		}
+	}
+
+	switch refType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() < b.String() }
+	default:
+		return nil
+	}
+}
+
 // HasGetters accepts a map of named getter methods and their validators to evaluate against a struct during validation
 // It also accepts an optional map of method names to display names to use when printing error messages
 func (sv *StructValidator[T]) HasGetters(validators with.Validators, displayNames ...with.DisplayNames) *StructValidator[T] {
@@ -133,6 +465,7 @@ func (sv *StructValidator[T]) HasGetters(validators with.Validators, displayName
 
 	// To get all the methods on a struct, we have to look at the pointer value
 	ptr := reflect.PointerTo(refType)
+	added := make([]*validMethod, 0, len(validators))
 
 	aliases := with.DisplayNames{}
 
@@ -149,25 +482,12 @@ func (sv *StructValidator[T]) HasGetters(validators with.Validators, displayName
 		}
 	}
 
-	// Collect all methods with value receivers
-	// We use this as a simple check to know whether we should pass a value or a pointer at evaluation time
-	valMethods := map[string]reflect.Method{}
+	// Getters are added in alphabetical order, matching the order of the struct's methods
+	for _, name := range slices.Sorted(maps.Keys(validators)) {
+		validator := validators[name]
 
-	for i := range refType.NumMethod() {
-		method := refType.Method(i)
-		valMethods[method.Name] = method
-	}
-
-	for name, validator := range validators {
-		// First check to see if the method has a value type receiver
-		method, ok := valMethods[name]
-		hasValRcvr := true
-
-		// If we don't find it there, check the ptr version
-		if !ok {
-			hasValRcvr = false
-			method, ok = ptr.MethodByName(name)
-		}
+		// Getters are called on a pointer to a copy of the struct, so both value and pointer receivers work
+		method, ok := ptr.MethodByName(name)
 
 		if !ok {
 			panic(
@@ -223,70 +543,187 @@ func (sv *StructValidator[T]) HasGetters(validators with.Validators, displayName
 			displayName = name
 		}
 
-		sv.getters = append(sv.getters, &validMethod{
+		ref, _ := refFor(validator, retVal)
+
+		added = append(added, &validMethod{
+			name:        name,
 			displayName: displayName,
-			ref:         &method,
-			hasValRcvr:  hasValRcvr,
 			validator:   validator,
+			index:       method.Index,
+			ref:         ref,
 		})
 	}
 
+	sv.getters = append(sv.getters, added...)
+
+	sv.setLastMasked = nil
+	sv.setLastGroups = func(groups []string) {
+		for _, getter := range added {
+			getter.groups = append(getter.groups, groups...)
+		}
+	}
+
 	return sv
 }
 
-// validateStruct is a helper method that does the actual validation used by Validate and ValidateStrict
-func (sv *StructValidator[T]) validateStruct(sRef reflect.Value, s T, options ...*with.ValidationOptions) error {
-	vErrs := newValidationErrors()
-	vOpts := getValidationOptions(options)
+// InGroups tags the fields or getters added by the preceding HasFields, HasGetters,
+// When, Unless, Field, or FieldAt call with one or more validation groups (eg "create" or "update")
+// Tagged fields and getters are only validated when one of their groups is selected
+// with with.OptionGroups(); untagged ones are always validated
+func (sv *StructValidator[T]) InGroups(groups ...string) *StructValidator[T] {
+	if sv.setLastGroups == nil {
+		panic("InGroups must follow HasFields, HasGetters, When, Unless, Field, or FieldAt")
+	}
+
+	if len(groups) == 0 {
+		panic("InGroups requires at least one group")
+	}
 
-	if err := sv.checks.Evaluate(s, vOpts); err != nil {
-		if vOpts.CollectAllErrors() {
-			vErrs.Append(err)
+	sv.setLastGroups(groups)
+	return sv
+}
+
+// inSelectedGroups returns true if a field or getter with the provided groups
+// should be validated with the groups selected in opts
+func inSelectedGroups(groups []string, opts *with.ValidationOptions) bool {
+	if len(groups) == 0 {
+		return true
+	}
+
+	for _, group := range opts.Groups() {
+		if slices.Contains(groups, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structFrame is a copy of a struct being validated, along with its getters bound to the copy
+type structFrame[T any] struct {
+	ptr    *T
+	ptrVal reflect.Value
+
+	// getters holds a method value for each getter, which is a func() R for getters whose
+	// validator accepts their result directly and a reflect.Value for any other getter
+	getters []any
+}
+
+// newStructFrame returns a frame for validating structs of type T
+func newStructFrame[T any]() *structFrame[T] {
+	ptr := new(T)
+	return &structFrame[T]{ptr: ptr, ptrVal: reflect.ValueOf(ptr)}
+}
+
+// bind binds any getters that were added since the frame was last used
+func (f *structFrame[T]) bind(getters []*validMethod) {
+	for _, method := range getters[len(f.getters):] {
+		getter := f.ptrVal.Method(method.index)
+
+		if method.ref != nil {
+			f.getters = append(f.getters, getter.Interface())
 		} else {
+			f.getters = append(f.getters, getter)
+		}
+	}
+}
+
+// validateStruct is a helper method that does the actual validation used by Validate and ValidateUntyped
+// The struct is copied into a frame from a pool, so its fields can be referenced and its getters called
+// without allocating when every check passes
+func (sv *StructValidator[T]) validateStruct(s T, opts *with.ValidationOptions) error {
+	f := sv.frames.Get().(*structFrame[T])
+	*f.ptr = s
+	f.bind(sv.getters)
+
+	err := sv.validateFrame(f, opts)
+
+	// the copy is cleared so the pool doesn't keep the struct's values alive
+	var zero T
+	*f.ptr = zero
+	sv.frames.Put(f)
+
+	return err
+}
+
+// validateFrame applies all checks against the struct in a frame
+func (sv *StructValidator[T]) validateFrame(f *structFrame[T], opts *with.ValidationOptions) error {
+	run := NewStructRun(opts)
+	s := *f.ptr
+
+	if err := sv.checks.Evaluate(s, run.opts); err != nil {
+		if cErr := contextError(run.opts); cErr != nil {
+			return cErr
+		}
+
+		if !run.opts.CollectAllErrors() {
 			return err
 		}
+
+		run.append(err)
+	}
+
+	// Evaluate each condition once so every field that depends on it sees the same result
+	active := make([]bool, len(sv.conditions))
+
+	for idx, condition := range sv.conditions {
+		active[idx] = condition(s)
 	}
 
+	sRef := f.ptrVal.Elem()
+
 	// Validate fields
 	for _, field := range sv.fields {
-		fieldVal := sRef.FieldByName(field.name)
-		if err := field.validator.ValidateUntyped(fieldVal.Interface(), vOpts); err != nil {
-			vErrs.Append(fmt.Errorf("%s: %s", field.displayName, err.Error()))
+		if field.condition > 0 && !active[field.condition-1] {
+			continue
+		}
 
-			if !vOpts.CollectAllErrors() {
-				return vErrs
-			}
+		fieldOpts, ok := run.Field(field.name, field.groups...)
+
+		if !ok {
+			continue
+		}
+
+		var err error
+
+		switch {
+		case field.check != nil:
+			err = field.check.(checkFunc[T])(s, fieldOpts)
+		case field.scalar != nil:
+			err = field.scalar.validateScalar(readScalar(sRef.FieldByIndex(field.index)), fieldOpts)
+		case field.ref != nil:
+			err = field.ref.validateRef(sRef.FieldByIndex(field.index).Addr().Interface(), fieldOpts)
+		default:
+			err = field.validator.ValidateUntyped(sRef.FieldByIndex(field.index).Interface(), fieldOpts)
+		}
+
+		if run.Fail(err, field.name, field.displayName) {
+			return run.Err()
 		}
 	}
 
 	// Validate getters
-	for _, method := range sv.getters {
-		var receiver reflect.Value
+	for idx, method := range sv.getters {
+		methodOpts, ok := run.Field(method.name, method.groups...)
 
-		if method.hasValRcvr {
-			receiver = reflect.ValueOf(s)
-		} else {
-			receiver = reflect.ValueOf(&s)
+		if !ok {
+			continue
 		}
 
-		// Call the getter method
-		result := method.ref.Func.Call([]reflect.Value{receiver})
-		retVal := result[0].Interface()
-
-		if err := method.validator.ValidateUntyped(retVal, vOpts); err != nil {
-			vErrs.Append(fmt.Errorf("%s: %s", method.displayName, err.Error()))
+		var err error
 
-			if !vOpts.CollectAllErrors() {
-				return vErrs
-			}
+		if method.ref != nil {
+			err = method.ref.validateRef(f.getters[idx], methodOpts)
+		} else {
+			err = method.validator.ValidateUntyped(f.getters[idx].(reflect.Value).Call(nil)[0].Interface(), methodOpts)
 		}
-	}
 
-	if vErrs.HasErrors() {
-		return vErrs
+		if run.Fail(err, method.name, method.displayName) {
+			return run.Err()
+		}
 	}
 
-	return nil
+	return run.Err()
 }
 
 // ValidateUntyped accepts an arbitrary input type and validates it if it's a match for the expected type
@@ -298,11 +735,82 @@ func (sv *StructValidator[T]) ValidateUntyped(value any, options ...*with.Valida
 		return newTypeErrorFromTypes(sv.refVal.Type().String(), sRefType.String())
 	}
 
-	return sv.validateStruct(sRef, value.(T), options...)
+	return sv.validateStruct(value.(T), getValidationOptions(options))
 }
 
 // Validate applies all checks against a struct of the expected type and returns an error if any fail
 func (sv *StructValidator[T]) Validate(s T, options ...*with.ValidationOptions) error {
-	sRef := reflect.ValueOf(s)
-	return sv.validateStruct(sRef, s, options...)
+	return sv.validateStruct(s, getValidationOptions(options))
+}
+
+// validate applies all checks against a struct with the provided options
+func (sv *StructValidator[T]) validate(s T, opts *with.ValidationOptions) error {
+	return sv.validateStruct(s, opts)
+}
+
+// validateRef validates the struct that a field of another struct or a getter refers to
+func (sv *StructValidator[T]) validateRef(ref any, opts *with.ValidationOptions) error {
+	return sv.validateStruct(deref[T](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (sv *StructValidator[T]) valueType() reflect.Type {
+	return sv.refVal.Type()
+}
+
+// ValidateAndNormalize applies the normalizers of the field validators, such as String().Trim(),
+// and writes the normalized values back into the struct before applying all checks against it
+// Fields that are skipped because of a condition, validation group, or field mask are left unchanged
+func (sv *StructValidator[T]) ValidateAndNormalize(s *T, options ...*with.ValidationOptions) error {
+	if s == nil {
+		return NewTypeError("struct pointer expected")
+	}
+
+	opts := getValidationOptions(options)
+	sv.normalizeStruct(s, opts)
+	return sv.validate(*s, opts)
+}
+
+// normalizeStruct writes the normalized values of the fields that would be validated back into the struct
+// Values read with Field accessors can't be written back, so they are only normalized during validation
+func (sv *StructValidator[T]) normalizeStruct(s *T, opts *with.ValidationOptions) {
+	active := make([]bool, len(sv.conditions))
+
+	for idx, condition := range sv.conditions {
+		active[idx] = condition(*s)
+	}
+
+	ptr := reflect.ValueOf(s)
+
+	for _, field := range sv.fields {
+		n, ok := field.validator.(normalizer)
+
+		if !ok || (field.condition > 0 && !active[field.condition-1]) || !inSelectedGroups(field.groups, opts) {
+			continue
+		}
+
+		fieldOpts, inMask := fieldMaskOptions(field.name, opts)
+
+		if !inMask {
+			continue
+		}
+
+		switch {
+		case field.pointer != nil:
+			n.normalizeRef(field.pointer.(func(*T) any)(s), fieldOpts)
+		case field.check == nil:
+			n.normalizeRef(ptr.Elem().FieldByIndex(field.index).Addr().Interface(), fieldOpts)
+		}
+	}
+}
+
+// normalizeRef normalizes the fields of the struct that a field of another struct points to
+func (sv *StructValidator[T]) normalizeRef(ref any, opts *with.ValidationOptions) {
+	sv.normalizeStruct(ref.(*T), opts)
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (sv *StructValidator[T]) ValidateContext(ctx context.Context, s T, options ...*with.ValidationOptions) error {
+	return sv.Validate(s, contextOptions(ctx, options))
 }
@@ -1,9 +1,11 @@
 package ensure_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/chriscasto/go-ensure"
 	"github.com/chriscasto/go-ensure/with"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -753,3 +755,704 @@ func TestStructValidator_Validate(t *testing.T) {
 	// see util_test.go
 	runDefaultValidatorTestCases(t, ensure.Struct[testStruct]())
 }
+
+type region string
+
+// fieldRuleForm is used to test cross-field rules
+type fieldRuleForm struct {
+	Password string
+	Confirm  string
+	Start    time.Time
+	End      time.Time
+	Min      int
+	Max      int
+	Low      uint
+	High     uint
+	Ratio    float64
+	Limit    float64
+	First    string
+	Last     string
+	Country  string
+	State    string
+	Region   region
+	Zone     int
+	Active   bool
+	Tags     []string
+	Labels   []string
+	secret   string
+}
+
+func TestStructValidator_FieldRules_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"missing field": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Missing", "Password")
+		},
+		"missing other field": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Password", "Missing")
+		},
+		"unexported field": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("secret", "Password")
+		},
+		"equals mismatched types": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Password", "Min")
+		},
+		"equals not comparable": func() {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Tags", "Labels")
+		},
+		"less mismatched types": func() {
+			ensure.Struct[fieldRuleForm]().FieldLessThan("Min", "Ratio")
+		},
+		"less not ordered": func() {
+			ensure.Struct[fieldRuleForm]().FieldLessThan("Active", "Active")
+		},
+		"required if nil value": func() {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", nil)
+		},
+		"required if wrong value type": func() {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", 1)
+		},
+		"required if not comparable": func() {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Tags", "a")
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
+
+func TestStructValidator_FieldRules(t *testing.T) {
+	now := time.Now()
+
+	testCases := map[string]struct {
+		validator *ensure.StructValidator[fieldRuleForm]
+		value     fieldRuleForm
+		willPass  bool
+	}{
+		"equals match": {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password"),
+			fieldRuleForm{Password: "secret", Confirm: "secret"},
+			true,
+		},
+		"equals mismatch": {
+			ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password"),
+			fieldRuleForm{Password: "secret", Confirm: "secert"},
+			false,
+		},
+		"less time":          {ensure.Struct[fieldRuleForm]().FieldLessThan("Start", "End"), fieldRuleForm{Start: now, End: now.Add(time.Hour)}, true},
+		"less time equal":    {ensure.Struct[fieldRuleForm]().FieldLessThan("Start", "End"), fieldRuleForm{Start: now, End: now}, false},
+		"less int":           {ensure.Struct[fieldRuleForm]().FieldLessThan("Min", "Max"), fieldRuleForm{Min: -1, Max: 1}, true},
+		"less int greater":   {ensure.Struct[fieldRuleForm]().FieldLessThan("Min", "Max"), fieldRuleForm{Min: 2, Max: 1}, false},
+		"less uint":          {ensure.Struct[fieldRuleForm]().FieldLessThan("Low", "High"), fieldRuleForm{Low: 1, High: 2}, true},
+		"less uint greater":  {ensure.Struct[fieldRuleForm]().FieldLessThan("Low", "High"), fieldRuleForm{Low: 2, High: 1}, false},
+		"less float":         {ensure.Struct[fieldRuleForm]().FieldLessThan("Ratio", "Limit"), fieldRuleForm{Ratio: 0.5, Limit: 1}, true},
+		"less float greater": {ensure.Struct[fieldRuleForm]().FieldLessThan("Ratio", "Limit"), fieldRuleForm{Ratio: 1.5, Limit: 1}, false},
+		"less string":        {ensure.Struct[fieldRuleForm]().FieldLessThan("First", "Last"), fieldRuleForm{First: "a", Last: "b"}, true},
+		"less string after":  {ensure.Struct[fieldRuleForm]().FieldLessThan("First", "Last"), fieldRuleForm{First: "b", Last: "a"}, false},
+		"required if set": {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", "US"),
+			fieldRuleForm{Country: "US", State: "CA"},
+			true,
+		},
+		"required if missing": {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", "US"),
+			fieldRuleForm{Country: "US"},
+			false,
+		},
+		"required if other value": {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("State", "Country", "US"),
+			fieldRuleForm{Country: "CA"},
+			true,
+		},
+		"required if converted value": {
+			ensure.Struct[fieldRuleForm]().FieldRequiredIf("Zone", "Region", "west"),
+			fieldRuleForm{Region: "west"},
+			false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.validator.Validate(tc.value)
+
+			if err != nil && tc.willPass {
+				t.Errorf(`expected no error; got "%s"`, err)
+			} else if err == nil && !tc.willPass {
+				t.Errorf("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestStructValidator_FieldRules_DisplayNames(t *testing.T) {
+	validForm := ensure.Struct[fieldRuleForm]().
+		FieldEquals("Confirm", "Password").
+		FieldRequiredIf("State", "Country", "US").
+		HasFields(with.Validators{
+			"Password": ensure.String(),
+			"Confirm":  ensure.String(),
+		}, with.DisplayNames{
+			"Password": "Full Name",
+			"Confirm":  "Confirm Password",
+		})
+
+	opts := with.Options(with.OptionCollectAllErrors())
+	err := validForm.Validate(fieldRuleForm{Password: "a", Country: "US"}, opts)
+
+	vErrs := ensure.ErrorAsValidationErrors(err)
+
+	if vErrs == nil || len(vErrs.ValidationErrors()) != 2 {
+		t.Fatalf(`expected two errors; got "%v"`, err)
+	}
+
+	expect := map[string]string{
+		"/Confirm": "Confirm Password: must equal Full Name",
+		"/State":   "State: required when Country is US",
+	}
+
+	for _, vErr := range vErrs.ValidationErrors() {
+		path := vErr.Path().JSONPointer()
+
+		if vErr.Error() != expect[path] {
+			t.Errorf(`expected "%s" at path "%s"; got "%s"`, expect[path], path, vErr.Error())
+		}
+	}
+
+	// display names of the other field are translated
+	err = validForm.Validate(fieldRuleForm{Password: "a"}, with.Options(with.OptionLocale("de")))
+	expectMsg := "Confirm Password: must equal Vollständiger Name"
+
+	if err == nil || err.Error() != expectMsg {
+		t.Errorf(`expected "%s"; got "%v"`, expectMsg, err)
+	}
+
+	// rules can have custom messages
+	err = ensure.Struct[fieldRuleForm]().FieldEquals("Confirm", "Password").WithMessage("passwords must match").
+		Validate(fieldRuleForm{Password: "a"})
+
+	if err == nil || err.Error() != "Confirm: passwords must match" {
+		t.Errorf(`expected custom message; got "%v"`, err)
+	}
+}
+
+// checkoutForm is used to test conditional field validation
+type checkoutForm struct {
+	Shipping   bool
+	Address    string
+	PostalCode string
+	Pickup     bool
+	Email      string
+}
+
+func TestStructValidator_When(t *testing.T) {
+	calls := 0
+	needsShipping := func(f checkoutForm) bool {
+		calls++
+		return f.Shipping
+	}
+
+	validForm := ensure.Struct[checkoutForm]().When(needsShipping, with.Validators{
+		"Address":    ensure.String().IsNotEmpty(),
+		"PostalCode": ensure.String().Matches(ensure.Numbers),
+	}, with.DisplayNames{
+		"PostalCode": "Postal Code",
+	}).Unless(func(f checkoutForm) bool { return f.Pickup }, with.Validators{
+		"Email": ensure.String().IsNotEmpty(),
+	})
+
+	testCases := map[string]struct {
+		value       checkoutForm
+		expectPaths []string
+	}{
+		"shipping": {
+			checkoutForm{Shipping: true, Pickup: true},
+			[]string{"/Address", "/PostalCode"},
+		},
+		"shipping valid": {
+			checkoutForm{Shipping: true, Address: "1 Main St", PostalCode: "12345", Pickup: true},
+			nil,
+		},
+		"no shipping": {
+			checkoutForm{Pickup: true},
+			nil,
+		},
+		"no pickup": {
+			checkoutForm{Address: "", Pickup: false},
+			[]string{"/Email"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			err := validForm.Validate(tc.value, with.Options(with.OptionCollectAllErrors()))
+
+			if calls != 1 {
+				t.Errorf("expected condition to be evaluated once; got %d", calls)
+			}
+
+			if len(tc.expectPaths) == 0 {
+				if err != nil {
+					t.Errorf(`expected no error; got "%s"`, err)
+				}
+				return
+			}
+
+			paths := validationErrorPaths(t, err)
+
+			if len(paths) != len(tc.expectPaths) {
+				t.Errorf("expected %d errors; got %v", len(tc.expectPaths), paths)
+			}
+
+			for _, path := range tc.expectPaths {
+				if _, ok := paths[path]; !ok {
+					t.Errorf(`expected an error at path "%s"; got %v`, path, paths)
+				}
+			}
+		})
+	}
+
+	err := validForm.Validate(checkoutForm{Shipping: true, Address: "1 Main St", Pickup: true})
+	expect := "Postal Code: string does not match expected pattern"
+
+	if err == nil || err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%v"`, expect, err)
+	}
+}
+
+func TestStructValidator_When_Panic(t *testing.T) {
+	always := func(checkoutForm) bool { return true }
+
+	testCases := map[string]func(){
+		"invalid field": func() {
+			ensure.Struct[checkoutForm]().When(always, with.Validators{"Missing": ensure.String()})
+		},
+		"wrong field type": func() {
+			ensure.Struct[checkoutForm]().Unless(always, with.Validators{"Shipping": ensure.String()})
+		},
+		"display name for missing validator": func() {
+			ensure.Struct[checkoutForm]().When(always, with.Validators{
+				"Address": ensure.String(),
+			}, with.DisplayNames{
+				"Email": "Email Address",
+			})
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
+
+// groupUser is used to test validation groups
+type groupUser struct {
+	Id       string
+	Email    string
+	Password string
+}
+
+func (u groupUser) GetPassword() string {
+	return u.Password
+}
+
+func TestStructValidator_InGroups(t *testing.T) {
+	validUser := ensure.Struct[groupUser]().HasFields(with.Validators{
+		"Email": ensure.String().IsNotEmpty(),
+	}).HasFields(with.Validators{
+		"Id": ensure.String().IsEmpty(),
+	}).InGroups("create").HasFields(with.Validators{
+		"Id": ensure.String().IsNotEmpty(),
+	}).InGroups("update", "admin").HasGetters(with.Validators{
+		"GetPassword": ensure.String().IsLongerThan(7),
+	}).InGroups("create")
+
+	testCases := map[string]struct {
+		value       groupUser
+		groups      []string
+		expectPaths []string
+	}{
+		"no groups": {
+			groupUser{Id: "1"},
+			nil,
+			[]string{"/Email"},
+		},
+		"create": {
+			groupUser{Id: "1", Password: "short"},
+			[]string{"create"},
+			[]string{"/Email", "/Id", "/GetPassword"},
+		},
+		"create valid": {
+			groupUser{Email: "a@b.c", Password: "long enough"},
+			[]string{"create"},
+			nil,
+		},
+		"update": {
+			groupUser{Email: "a@b.c"},
+			[]string{"update"},
+			[]string{"/Id"},
+		},
+		"update valid": {
+			groupUser{Id: "1", Email: "a@b.c"},
+			[]string{"update"},
+			nil,
+		},
+		"second group": {
+			groupUser{Email: "a@b.c"},
+			[]string{"audit", "admin"},
+			[]string{"/Id"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validUser.Validate(tc.value, with.Options(
+				with.OptionCollectAllErrors(),
+				with.OptionGroups(tc.groups...),
+			))
+
+			if len(tc.expectPaths) == 0 {
+				if err != nil {
+					t.Errorf(`expected no error; got "%s"`, err)
+				}
+				return
+			}
+
+			paths := validationErrorPaths(t, err)
+
+			if len(paths) != len(tc.expectPaths) {
+				t.Errorf("expected %d errors; got %v", len(tc.expectPaths), paths)
+			}
+
+			for _, path := range tc.expectPaths {
+				if _, ok := paths[path]; !ok {
+					t.Errorf(`expected an error at path "%s"; got %v`, path, paths)
+				}
+			}
+		})
+	}
+}
+
+func TestStructValidator_InGroups_Panic(t *testing.T) {
+	testCases := map[string]func(){
+		"no fields": func() {
+			ensure.Struct[groupUser]().InGroups("create")
+		},
+		"after check": func() {
+			ensure.Struct[groupUser]().HasFields(with.Validators{
+				"Id": ensure.String(),
+			}).Is(func(groupUser) error { return nil }).InGroups("create")
+		},
+		"no groups": func() {
+			ensure.Struct[groupUser]().HasFields(with.Validators{
+				"Id": ensure.String(),
+			}).InGroups()
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic")
+				}
+			}()
+
+			fn()
+		})
+	}
+}
+
+type kindsTestStruct struct {
+	Small    int8
+	Count    uint16
+	Ratio    float32
+	Timeout  time.Duration
+	Interval string
+	Payload  map[string]any
+	Created  time.Time
+	Owner    *string
+}
+
+func (s kindsTestStruct) Enabled() bool {
+	return s.Count > 0
+}
+
+func (s kindsTestStruct) Wait() time.Duration {
+	return s.Timeout
+}
+
+func (s kindsTestStruct) Every() string {
+	return s.Interval
+}
+
+func (s kindsTestStruct) Since() time.Time {
+	return s.Created
+}
+
+func (s kindsTestStruct) Label() string {
+	return fmt.Sprintf("%d", s.Small)
+}
+
+func (s *kindsTestStruct) Scaled() float32 {
+	return s.Ratio * 2
+}
+
+func (s kindsTestStruct) Keys() []string {
+	keys := make([]string, 0, len(s.Payload))
+
+	for key := range s.Payload {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func (s kindsTestStruct) Fields() map[string]any {
+	return s.Payload
+}
+
+func (s kindsTestStruct) Totals() map[string]uint16 {
+	return map[string]uint16{"count": s.Count}
+}
+
+func (s kindsTestStruct) Author() *string {
+	return s.Owner
+}
+
+type kindsTestWindow struct {
+	Timeout time.Duration
+}
+
+func (s *kindsTestStruct) Window() kindsTestWindow {
+	return kindsTestWindow{Timeout: s.Timeout}
+}
+
+// TestStructValidator_FieldKinds checks that fields and getters of each kind are read correctly
+func TestStructValidator_FieldKinds(t *testing.T) {
+	now := time.Now()
+
+	sv := ensure.Struct[kindsTestStruct]().HasFields(with.Validators{
+		"Small":    ensure.Number[int8]().IsNegative(),
+		"Count":    ensure.Number[uint16]().IsGreaterThan(1),
+		"Ratio":    ensure.Number[float32]().IsLessThan(1),
+		"Timeout":  ensure.Duration().IsLessThan(time.Minute),
+		"Interval": ensure.DurationString(ensure.Duration().IsLessThan(time.Minute)),
+		"Payload":  ensure.Object().RequiredKey("id", ensure.String()),
+		"Created":  ensure.Time().IsBefore(now),
+		"Owner":    ensure.OptionalPointer[string](ensure.String().IsNotEmpty()),
+	}).HasGetters(with.Validators{
+		"Enabled": ensure.Bool().IsTrue(),
+		"Wait":    ensure.Duration().IsLessThan(time.Minute),
+		"Every":   ensure.DurationString(ensure.Duration().IsLessThan(time.Minute)),
+		"Since":   ensure.Time().IsBefore(now),
+		"Label":   ensure.Any[string](ensure.String().HasLength(2)),
+		"Scaled":  ensure.Number[float32]().IsLessThan(2),
+		"Keys":    ensure.Array[string]().IsNotEmpty(),
+		"Fields":  ensure.Object().RequiredKey("id", ensure.String()),
+		"Totals":  ensure.Map[string, uint16]().EachValue(ensure.Number[uint16]().IsGreaterThan(1)),
+		"Author":  ensure.OptionalPointer[string](ensure.String().IsNotEmpty()),
+		"Window": ensure.Struct[kindsTestWindow]().HasFields(with.Validators{
+			"Timeout": ensure.Duration().IsLessThan(time.Minute),
+		}),
+	})
+
+	valid := kindsTestStruct{
+		Small:    -1,
+		Count:    2,
+		Ratio:    0.5,
+		Timeout:  time.Second,
+		Interval: "1s",
+		Payload:  map[string]any{"id": "abc"},
+		Created:  now.Add(-time.Hour),
+	}
+
+	if err := sv.Validate(valid); err != nil {
+		t.Errorf("expected no error; got %s", err)
+	}
+
+	empty := ""
+	invalid := kindsTestStruct{
+		Small:    1,
+		Ratio:    2,
+		Timeout:  time.Hour,
+		Interval: "1h",
+		Payload:  map[string]any{},
+		Created:  now.Add(time.Hour),
+		Owner:    &empty,
+	}
+
+	paths := validationErrorPaths(t, sv.Validate(invalid, with.Options(with.OptionCollectAllErrors())))
+	expect := []string{
+		"/Small", "/Count", "/Ratio", "/Timeout", "/Interval", "/Payload/id", "/Created", "/Owner",
+		"/Enabled", "/Wait", "/Every", "/Since", "/Label",
+		"/Scaled", "/Keys", "/Fields/id", "/Totals/count", "/Author", "/Window/Timeout",
+	}
+
+	for _, path := range expect {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("expected an error at %s; got %v", path, paths)
+		}
+	}
+
+	if len(paths) != len(expect) {
+		t.Errorf("expected %d errors; got %v", len(expect), paths)
+	}
+}
+
+type sameNamePoint struct {
+	X int
+}
+
+type sameNameTestStruct struct {
+	Point sameNamePoint
+}
+
+func (s sameNameTestStruct) Origin() sameNamePoint {
+	return s.Point
+}
+
+// TestStructValidator_SameTypeName checks that a field or getter whose type has the same
+// name as the validator's type, but is a different type, fails with a TypeError
+func TestStructValidator_SameTypeName(t *testing.T) {
+	value := sameNameTestStruct{Point: sameNamePoint{X: 1}}
+
+	// Types declared in a function are named after their package, like the ones declared outside it
+	type sameNamePoint struct {
+		X int
+	}
+
+	validPoint := ensure.Struct[sameNamePoint]().HasFields(with.Validators{
+		"X": ensure.Number[int]().IsPositive(),
+	})
+
+	testCases := map[string]*ensure.StructValidator[sameNameTestStruct]{
+		"field":  ensure.Struct[sameNameTestStruct]().HasFields(with.Validators{"Point": validPoint}),
+		"getter": ensure.Struct[sameNameTestStruct]().HasGetters(with.Validators{"Origin": validPoint}),
+	}
+
+	for name, sv := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var typeErr *ensure.TypeError
+
+			if err := sv.Validate(value); !errors.As(err, &typeErr) {
+				t.Errorf("expected a TypeError; got %v", err)
+			}
+		})
+	}
+}
+
+type normalizeTestAddress struct {
+	City string
+}
+
+type normalizeTestStruct struct {
+	Email    string
+	Name     string
+	Nickname string
+	Note     string
+	Role     string
+	Address  normalizeTestAddress
+	Billing  *normalizeTestAddress
+	Shipping *normalizeTestAddress
+	Count    int
+	code     string
+}
+
+// TestStructValidator_ValidateAndNormalize checks that normalized values are written back into the struct
+func TestStructValidator_ValidateAndNormalize(t *testing.T) {
+	address := ensure.Struct[normalizeTestAddress]().HasFields(with.Validators{
+		"City": ensure.String().Trim().IsNotEmpty(),
+	})
+
+	sv := ensure.Struct[normalizeTestStruct]().HasFields(with.Validators{
+		"Email":    ensure.String().Trim().ToLower().Matches(ensure.Email),
+		"Address":  address,
+		"Billing":  ensure.OptionalPointer[normalizeTestAddress](address),
+		"Shipping": ensure.OptionalPointer[normalizeTestAddress](address),
+		"Count":    ensure.Number[int](),
+	}).When(func(s normalizeTestStruct) bool { return s.Count > 0 }, with.Validators{
+		"Note": ensure.String().Trim(),
+	}).HasFields(with.Validators{
+		"Role": ensure.String().Trim(),
+	}).InGroups("admin")
+
+	ensure.FieldAt(sv, func(s *normalizeTestStruct) *string { return &s.code }, ensure.String().ToLower())
+	ensure.Field(sv, "Nickname", func(s normalizeTestStruct) string { return s.Nickname }, ensure.String().Trim().IsNotEmpty())
+
+	value := normalizeTestStruct{
+		Email:    " Ann@Example.com ",
+		Nickname: " Annie ",
+		Note:     " note ",
+		Role:     " admin ",
+		Address:  normalizeTestAddress{City: " Springfield "},
+		Billing:  &normalizeTestAddress{City: " Shelbyville "},
+		code:     "ABC",
+	}
+
+	if err := sv.ValidateAndNormalize(&value); err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	expected := normalizeTestStruct{
+		Email: "ann@example.com",
+		// Values read by Field accessors can't be written back
+		Nickname: " Annie ",
+		// Fields skipped because of a condition or validation group are left unchanged
+		Note:    " note ",
+		Role:    " admin ",
+		Address: normalizeTestAddress{City: "Springfield"},
+		Billing: &normalizeTestAddress{City: "Shelbyville"},
+		code:    "abc",
+	}
+
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected %+v; got %+v", expected, value)
+	}
+
+	// Fields outside the field mask are left unchanged
+	masked := normalizeTestStruct{Email: " A@B.co ", Address: normalizeTestAddress{City: " X "}, Note: " note ", Role: " admin ", Count: 1}
+	err := sv.ValidateAndNormalize(&masked, with.Options(
+		with.OptionFieldMask("Address.City", "Note", "Role"),
+		with.OptionGroups("admin"),
+	))
+
+	if err != nil {
+		t.Fatalf(`expected no error; got "%s"`, err)
+	}
+
+	if masked.Email != " A@B.co " || masked.Address.City != "X" || masked.Note != "note" || masked.Role != "admin" {
+		t.Errorf("expected only the masked fields to be normalized; got %+v", masked)
+	}
+
+	// Errors are reported against the normalized values
+	invalid := normalizeTestStruct{Email: " nope ", Nickname: "  "}
+	paths := validationErrorPaths(t, sv.ValidateAndNormalize(&invalid, with.Options(with.OptionCollectAllErrors())))
+
+	if _, ok := paths["/Email"]; !ok || len(paths) != 3 {
+		t.Errorf("expected errors for Email, Address, and Nickname; got %v", paths)
+	}
+
+	if invalid.Email != "nope" {
+		t.Errorf(`expected Email to be normalized even though it's invalid; got "%s"`, invalid.Email)
+	}
+
+	if err := sv.ValidateAndNormalize(nil); err == nil {
+		t.Errorf("expected error for nil struct pointer but got none")
+	}
+}
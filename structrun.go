@@ -0,0 +1,93 @@
+package ensure
+
+import (
+	"github.com/chriscasto/go-ensure/with"
+)
+
+// StructRun validates the fields of a struct one at a time, for the functions generated by
+// cmd/ensuregen, which read the fields themselves rather than through reflection
+// The errors are the same as the ones StructValidator returns for the same fields and validators
+//
+// It is exported only for generated code and is not meant to be used directly; it may
+// change along with the code ensuregen generates
+type StructRun struct {
+	opts *with.ValidationOptions
+	errs *ValidationErrors
+
+	// stopErr is set once validation should stop because the context is done
+	stopErr error
+	stopped bool
+}
+
+// NewStructRun starts validating a struct with the provided options
+// It is exported only for generated code (see StructRun)
+func NewStructRun(options ...*with.ValidationOptions) StructRun {
+	return StructRun{opts: getValidationOptions(options)}
+}
+
+// Field returns the options to validate the named field or getter with, or false if
+// it should be skipped, either because it isn't in the selected groups or field mask
+// or because validation has already stopped
+func (r *StructRun) Field(name string, groups ...string) (*with.ValidationOptions, bool) {
+	if r.stopped || !inSelectedGroups(groups, r.opts) {
+		return nil, false
+	}
+
+	fieldOpts, inMask := fieldMaskOptions(name, r.opts)
+
+	if !inMask {
+		return nil, false
+	}
+
+	if err := contextError(r.opts); err != nil {
+		r.stopErr = err
+		r.stopped = true
+		return nil, false
+	}
+
+	return fieldOpts, true
+}
+
+// Fail records the error returned by the validator for a field or getter, if there is one,
+// and returns true if validation should stop
+func (r *StructRun) Fail(err error, name string, displayName string) bool {
+	if err == nil {
+		return false
+	}
+
+	if cErr := contextError(r.opts); cErr != nil {
+		r.stopErr = cErr
+		r.stopped = true
+		return true
+	}
+
+	r.append(prependPath(err, fieldSegment(name, translateDisplayName(displayName, r.opts))))
+
+	if !r.opts.CollectAllErrors() {
+		r.stopped = true
+	}
+
+	return r.stopped
+}
+
+// Err returns the result of the validation
+func (r *StructRun) Err() error {
+	if r.stopErr != nil {
+		return r.stopErr
+	}
+
+	if r.errs != nil && r.errs.HasErrors() {
+		return r.errs
+	}
+
+	return nil
+}
+
+// append adds an error to the ones collected so far
+func (r *StructRun) append(err error) {
+	if r.errs == nil {
+		r.errs = newValidationErrors()
+	}
+
+	r.errs.Append(err)
+}
@@ -0,0 +1,79 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"testing"
+)
+
+type runUser struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+var (
+	runName  = ensure.String().IsNotEmpty()
+	runEmail = ensure.String().Matches(ensure.Email)
+	runAge   = ensure.Number[int]().IsGreaterThanOrEqualTo(18)
+)
+
+// validateRunUser validates a runUser the way the code generated by ensuregen does,
+// with the fields in the same order as the StructValidator in TestStructRun
+func validateRunUser(u runUser, options ...*with.ValidationOptions) error {
+	run := ensure.NewStructRun(options...)
+
+	if opts, ok := run.Field("Name"); ok {
+		if run.Fail(runName.Validate(u.Name, opts), "Name", "Full name") {
+			return run.Err()
+		}
+	}
+
+	if opts, ok := run.Field("Age"); ok {
+		if run.Fail(runAge.Validate(u.Age, opts), "Age", "Age") {
+			return run.Err()
+		}
+	}
+
+	if opts, ok := run.Field("Email", "contact"); ok {
+		if run.Fail(runEmail.Validate(u.Email, opts), "Email", "Email") {
+			return run.Err()
+		}
+	}
+
+	return run.Err()
+}
+
+func TestStructRun(t *testing.T) {
+	sv := ensure.Struct[runUser]().HasFields(with.Validators{
+		"Name": runName,
+		"Age":  runAge,
+	}, with.DisplayNames{
+		"Name": "Full name",
+	}).HasFields(with.Validators{
+		"Email": runEmail,
+	}).InGroups("contact")
+
+	testCases := map[string]struct {
+		value   runUser
+		options []*with.ValidationOptions
+	}{
+		"valid":          {value: runUser{Name: "Ann", Email: "ann@example.com", Age: 30}},
+		"invalid":        {value: runUser{Email: "nope", Age: 3}},
+		"collect all":    {value: runUser{Email: "nope", Age: 3}, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"group selected": {value: runUser{Name: "Ann", Email: "nope", Age: 3}, options: []*with.ValidationOptions{with.Options(with.OptionGroups("contact"))}},
+		"field mask":     {value: runUser{Email: "nope", Age: 3}, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Age"))}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected := sv.Validate(tc.value, tc.options...)
+			actual := validateRunUser(tc.value, tc.options...)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %#v; got %#v", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,750 @@
+package ensure
+
+import (
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tagKey is the struct tag key read by FromTags
+const tagKey = "ensure"
+
+// namedPatterns maps the lowercase names of the predefined patterns (eg "email" for Email)
+// to the patterns themselves, for use in tags and definitions
+var namedPatterns = map[string]string{
+	"alpha":    Alpha,
+	"alphanum": AlphaNum,
+	"numbers":  Numbers,
+	"decimal":  Decimal,
+	"uuid4":    Uuid4,
+	"ipv4":     Ipv4,
+	"email":    Email,
+	"md5":      Md5,
+	"sha1":     Sha1,
+	"sha256":   Sha256,
+	"sha512":   Sha512,
+}
+
+// tagOperators lists the comparison operators recognized in a rule, longest first
+var tagOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// tagNested matches rules that contain a nested list of rules, such as "each(len>3)"
+var tagNested = regexp.MustCompile(`^([a-z]+)\((.*)\)$`)
+
+// tagRule is a single parsed rule from an ensure tag, such as "len>=3"
+type tagRule struct {
+	name string
+	op   string
+	arg  string
+}
+
+// String reassembles the rule as it appeared in the tag
+func (r tagRule) String() string {
+	if r.op == "()" {
+		return fmt.Sprintf("%s(%s)", r.name, r.arg)
+	}
+	return r.name + r.op + r.arg
+}
+
+// errUnsupported is a helper for reporting rules that don't apply to a type
+func (r tagRule) errUnsupported(typeStr string) error {
+	return fmt.Errorf(`rule "%s" is not supported for type %s`, r, typeStr)
+}
+
+// splitTag splits a tag on commas, ignoring any that appear inside parentheses or single quotes
+func splitTag(tag string) ([]string, error) {
+	var parts []string
+	depth := 0
+	quoted := false
+	start := 0
+
+	for i, c := range tag {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+			continue
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf(`unbalanced ")" in "%s"`, tag)
+			}
+		case c == ',' && depth == 0:
+			parts = append(parts, tag[start:i])
+			start = i + 1
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf(`unterminated quote in "%s"`, tag)
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf(`unbalanced "(" in "%s"`, tag)
+	}
+
+	return append(parts, tag[start:]), nil
+}
+
+// parseTag converts the contents of an ensure tag into a list of rules
+func parseTag(tag string) ([]tagRule, error) {
+	parts, err := splitTag(tag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]tagRule, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		if part == "" {
+			return nil, fmt.Errorf(`empty rule in "%s"`, tag)
+		}
+
+		// nested rules, such as "each(len>3)"
+		if m := tagNested.FindStringSubmatch(part); m != nil {
+			rules = append(rules, tagRule{
+				name: m[1],
+				op:   "()",
+				arg:  m[2],
+			})
+			continue
+		}
+
+		rule := tagRule{name: part}
+
+		// the operator is whichever one starts at the first operator character
+		if idx := strings.IndexAny(part, "!=<>"); idx >= 0 {
+			for _, op := range tagOperators {
+				if strings.HasPrefix(part[idx:], op) {
+					rule.name = part[:idx]
+					rule.op = op
+					rule.arg = strings.Trim(part[idx+len(op):], "'")
+					break
+				}
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseTagInt parses an integer argument for a rule
+func parseTagInt(rule tagRule) (int, error) {
+	i, err := strconv.Atoi(rule.arg)
+
+	if err != nil {
+		return 0, fmt.Errorf(`rule "%s" expects an integer`, rule)
+	}
+
+	return i, nil
+}
+
+// parseTagNumber parses a number argument for a rule as type T
+func parseTagNumber[T NumberType](rule tagRule, arg string) (T, error) {
+	n, ok := parseNumber[T](arg)
+
+	if !ok {
+		return n, fmt.Errorf(`rule "%s" expects a value of type %s`, rule, reflect.TypeFor[T]())
+	}
+
+	return n, nil
+}
+
+// parseNumber parses a string as a number of type T, returning false if it isn't one
+func parseNumber[T NumberType](arg string) (T, bool) {
+	var zero T
+	refType := reflect.TypeOf(zero)
+	refVal := reflect.New(refType).Elem()
+
+	switch refType.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(arg, refType.Bits())
+		if err != nil {
+			return zero, false
+		}
+		refVal.SetFloat(f)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(arg, 10, refType.Bits())
+		if err != nil {
+			return zero, false
+		}
+		refVal.SetUint(u)
+	default:
+		i, err := strconv.ParseInt(arg, 10, refType.Bits())
+		if err != nil {
+			return zero, false
+		}
+		refVal.SetInt(i)
+	}
+
+	return refVal.Interface().(T), true
+}
+
+// lengthFromRule converts a "len" rule into a NumberValidator for the length
+func lengthFromRule(rule tagRule) (*NumberValidator[int], error) {
+	l, err := parseTagInt(rule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch rule.op {
+	case "=":
+		return Length().Equals(l), nil
+	case "!=":
+		return Length().DoesNotEqual(l), nil
+	case ">":
+		return Length().IsGreaterThan(l), nil
+	case ">=":
+		return Length().IsGreaterThanOrEqualTo(l), nil
+	case "<":
+		return Length().IsLessThan(l), nil
+	default:
+		return Length().IsLessThanOrEqualTo(l), nil
+	}
+}
+
+// stringFromTag builds a StringValidator from a list of rules
+func stringFromTag(rules []tagRule) (with.UntypedValidator, error) {
+	v := String()
+
+	for _, rule := range rules {
+		switch {
+		case rule.name == "required" && rule.op == "":
+			v.IsNotEmpty()
+		case rule.name == "len" && rule.op != "()":
+			lv, err := lengthFromRule(rule)
+			if err != nil {
+				return nil, err
+			}
+			v.HasLengthWhere(lv)
+		case rule.name == "" && rule.op == "=":
+			v.Equals(rule.arg)
+		case rule.name == "" && rule.op == "!=":
+			v.DoesNotEqual(rule.arg)
+		case rule.name == "prefix" && rule.op == "=":
+			v.StartsWith(rule.arg)
+		case rule.name == "suffix" && rule.op == "=":
+			v.EndsWith(rule.arg)
+		case rule.name == "contains" && rule.op == "=":
+			v.Contains(rule.arg)
+		case rule.name == "excludes" && rule.op == "=":
+			v.DoesNotContain(rule.arg)
+		case rule.name == "oneof" && rule.op == "=":
+			v.IsOneOf(strings.Split(rule.arg, "|"))
+		case rule.name == "match" && rule.op == "=":
+			pattern, ok := namedPatterns[strings.ToLower(rule.arg)]
+			if !ok {
+				pattern = rule.arg
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf(`rule "%s" has an invalid pattern: %s`, rule, err)
+			}
+			v.Matches(pattern)
+		default:
+			return nil, rule.errUnsupported("string")
+		}
+	}
+
+	return v, nil
+}
+
+// numberFromTag builds a NumberValidator of type T from a list of rules
+func numberFromTag[T NumberType](rules []tagRule) (with.UntypedValidator, error) {
+	v := Number[T]()
+
+	for _, rule := range rules {
+		if rule.name == "" && rule.op != "" && rule.op != "()" {
+			n, err := parseTagNumber[T](rule, rule.arg)
+			if err != nil {
+				return nil, err
+			}
+
+			switch rule.op {
+			case "=":
+				v.Equals(n)
+			case "!=":
+				v.DoesNotEqual(n)
+			case ">":
+				v.IsGreaterThan(n)
+			case ">=":
+				v.IsGreaterThanOrEqualTo(n)
+			case "<":
+				v.IsLessThan(n)
+			default:
+				v.IsLessThanOrEqualTo(n)
+			}
+			continue
+		}
+
+		switch {
+		case rule.name == "required" && rule.op == "":
+			v.IsNotZero()
+		case rule.name == "positive" && rule.op == "":
+			v.IsPositive()
+		case rule.name == "negative" && rule.op == "":
+			v.IsNegative()
+		case rule.name == "even" && rule.op == "":
+			v.IsEven()
+		case rule.name == "odd" && rule.op == "":
+			v.IsOdd()
+		case rule.name == "oneof" && rule.op == "=":
+			var values []T
+			for _, arg := range strings.Split(rule.arg, "|") {
+				n, err := parseTagNumber[T](rule, arg)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, n)
+			}
+			v.IsOneOf(values)
+		default:
+			return nil, rule.errUnsupported(v.Type())
+		}
+	}
+
+	return v, nil
+}
+
+// boolFromTag builds a BooleanValidator from a list of rules
+func boolFromTag(rules []tagRule) (with.UntypedValidator, error) {
+	v := Bool()
+
+	for _, rule := range rules {
+		switch {
+		case (rule.name == "required" || rule.name == "true") && rule.op == "":
+			v.IsTrue()
+		case rule.name == "false" && rule.op == "":
+			v.IsFalse()
+		default:
+			return nil, rule.errUnsupported("bool")
+		}
+	}
+
+	return v, nil
+}
+
+// tagType describes how to build validators for a type T and the containers FromTags supports around it
+type tagType struct {
+	refType reflect.Type
+	build   func([]tagRule) (with.UntypedValidator, error)
+	pointer func(elem with.UntypedValidator, required bool) with.UntypedValidator
+	slice   func(elem with.UntypedValidator, rules []tagRule) (with.UntypedValidator, error)
+	mapOf   func(key with.UntypedValidator, val with.UntypedValidator, rules []tagRule) (with.UntypedValidator, error)
+}
+
+// newTagType creates the set of constructors FromTags uses for values of type T
+func newTagType[T comparable](build func([]tagRule) (with.UntypedValidator, error)) *tagType {
+	return &tagType{
+		refType: reflect.TypeFor[T](),
+		build:   build,
+		pointer: func(elem with.UntypedValidator, required bool) with.UntypedValidator {
+			if required {
+				return Pointer[T](elem.(with.Validator[T]))
+			}
+			return OptionalPointer[T](elem.(with.Validator[T]))
+		},
+		slice: func(elem with.UntypedValidator, rules []tagRule) (with.UntypedValidator, error) {
+			v := ComparableArray[T]()
+
+			if elem != nil {
+				v.Each(elem.(with.Validator[T]))
+			}
+
+			for _, rule := range rules {
+				switch {
+				case rule.name == "required" && rule.op == "":
+					v.IsNotEmpty()
+				case rule.name == "unique" && rule.op == "":
+					v.ContainsNoDuplicates()
+				case rule.name == "len" && rule.op != "()":
+					lv, err := lengthFromRule(rule)
+					if err != nil {
+						return nil, err
+					}
+					v.HasLengthWhere(lv)
+				default:
+					return nil, rule.errUnsupported(v.Type())
+				}
+			}
+
+			return v, nil
+		},
+		mapOf: func(key with.UntypedValidator, val with.UntypedValidator, rules []tagRule) (with.UntypedValidator, error) {
+			v := Map[string, T]()
+
+			if key != nil {
+				v.EachKey(key.(with.Validator[string]))
+			}
+
+			if val != nil {
+				v.EachValue(val.(with.Validator[T]))
+			}
+
+			for _, rule := range rules {
+				switch {
+				case rule.name == "required" && rule.op == "":
+					v.IsNotEmpty()
+				case rule.name == "len" && rule.op != "()":
+					lv, err := lengthFromRule(rule)
+					if err != nil {
+						return nil, err
+					}
+					v.HasLengthWhere(lv)
+				default:
+					return nil, rule.errUnsupported(v.Type())
+				}
+			}
+
+			return v, nil
+		},
+	}
+}
+
+// tagTypes lists the types FromTags knows how to build validators for, keyed by kind
+// Named types with one of these kinds, such as `type Email string`, are validated as their underlying type
+var tagTypes = map[reflect.Kind]*tagType{
+	reflect.String:  newTagType[string](stringFromTag),
+	reflect.Bool:    newTagType[bool](boolFromTag),
+	reflect.Int:     newTagType[int](numberFromTag[int]),
+	reflect.Int8:    newTagType[int8](numberFromTag[int8]),
+	reflect.Int16:   newTagType[int16](numberFromTag[int16]),
+	reflect.Int32:   newTagType[int32](numberFromTag[int32]),
+	reflect.Int64:   newTagType[int64](numberFromTag[int64]),
+	reflect.Uint:    newTagType[uint](numberFromTag[uint]),
+	reflect.Uint8:   newTagType[uint8](numberFromTag[uint8]),
+	reflect.Uint16:  newTagType[uint16](numberFromTag[uint16]),
+	reflect.Uint32:  newTagType[uint32](numberFromTag[uint32]),
+	reflect.Uint64:  newTagType[uint64](numberFromTag[uint64]),
+	reflect.Float32: newTagType[float32](numberFromTag[float32]),
+	reflect.Float64: newTagType[float64](numberFromTag[float64]),
+}
+
+// lookupTagType finds the constructors for a type, returning an error if it isn't supported
+// Containers are built for their element's exact type, so their elements can't be named types
+func lookupTagType(refType reflect.Type) (*tagType, error) {
+	tt, ok := tagTypes[refType.Kind()]
+
+	if !ok || tt.refType != refType {
+		return nil, fmt.Errorf("type %s is not supported", refType)
+	}
+
+	return tt, nil
+}
+
+// unnamedType returns the type that values of a named type are converted to for validation,
+// or the type itself if it isn't named (eg string for `type Email string`, or *string for *Email)
+func unnamedType(refType reflect.Type) reflect.Type {
+	switch refType.Kind() {
+	case reflect.Ptr:
+		return reflect.PointerTo(unnamedType(refType.Elem()))
+	case reflect.Slice:
+		return reflect.SliceOf(refType.Elem())
+	case reflect.Map:
+		return reflect.MapOf(refType.Key(), refType.Elem())
+	default:
+		if tt, ok := tagTypes[refType.Kind()]; ok {
+			return tt.refType
+		}
+
+		return refType
+	}
+}
+
+// extractNested removes rules like "each(...)" from a list and parses their contents
+func extractNested(rules []tagRule, name string) ([]tagRule, []tagRule, bool, error) {
+	var rest []tagRule
+	var nested []tagRule
+	found := false
+
+	for _, rule := range rules {
+		if rule.name != name || rule.op != "()" {
+			rest = append(rest, rule)
+			continue
+		}
+
+		inner, err := parseTag(rule.arg)
+
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		nested = append(nested, inner...)
+		found = true
+	}
+
+	return rest, nested, found, nil
+}
+
+// validatorFromTag builds a validator for a value of the provided type from a list of rules
+func validatorFromTag(refType reflect.Type, rules []tagRule) (with.UntypedValidator, error) {
+	if baseType := unnamedType(refType); baseType != refType {
+		base, err := validatorFromTag(baseType, rules)
+		if err != nil {
+			return nil, err
+		}
+
+		return &namedTypeValidator{refType: refType, baseType: baseType, base: base}, nil
+	}
+
+	switch refType.Kind() {
+	case reflect.Ptr:
+		tt, err := lookupTagType(refType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		// "required" applies to the pointer itself; anything else applies to the value it points to
+		var elemRules []tagRule
+		required := false
+
+		for _, rule := range rules {
+			if rule.name == "required" && rule.op == "" {
+				required = true
+			} else {
+				elemRules = append(elemRules, rule)
+			}
+		}
+
+		elem, err := tt.build(elemRules)
+		if err != nil {
+			return nil, err
+		}
+
+		return tt.pointer(elem, required), nil
+
+	case reflect.Slice:
+		tt, err := lookupTagType(refType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		rules, eachRules, hasEach, err := extractNested(rules, "each")
+		if err != nil {
+			return nil, err
+		}
+
+		var elem with.UntypedValidator
+
+		if hasEach {
+			if elem, err = tt.build(eachRules); err != nil {
+				return nil, err
+			}
+		}
+
+		return tt.slice(elem, rules)
+
+	case reflect.Map:
+		if refType.Key().String() != "string" {
+			return nil, fmt.Errorf("type %s is not supported; map keys must be strings", refType)
+		}
+
+		tt, err := lookupTagType(refType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		rules, keyRules, hasKeys, err := extractNested(rules, "keys")
+		if err != nil {
+			return nil, err
+		}
+
+		rules, valRules, hasVals, err := extractNested(rules, "values")
+		if err != nil {
+			return nil, err
+		}
+
+		var key, val with.UntypedValidator
+
+		if hasKeys {
+			if key, err = stringFromTag(keyRules); err != nil {
+				return nil, err
+			}
+		}
+
+		if hasVals {
+			if val, err = tt.build(valRules); err != nil {
+				return nil, err
+			}
+		}
+
+		return tt.mapOf(key, val, rules)
+
+	default:
+		tt, err := lookupTagType(refType)
+		if err != nil {
+			return nil, err
+		}
+
+		return tt.build(rules)
+	}
+}
+
+// TryFromTags constructs a StructValidator for type T from the "ensure" tags on its fields
+// It returns an error instead of panicking if a tag cannot be parsed
+func TryFromTags[T any]() (*StructValidator[T], error) {
+	var zero T
+
+	refType := reflect.TypeOf(zero)
+
+	if refType == nil || refType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FromTags expects a struct type")
+	}
+
+	fields, err := tagFields(refType, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	sv := Struct[T]()
+
+	// Add fields one at a time so they are evaluated in the order they are declared
+	for _, field := range fields {
+		sv.HasFields(with.Validators{field.name: field.validator})
+	}
+
+	return sv, nil
+}
+
+// tagFields builds a validator for each field of a struct that has an ensure tag, in the order they
+// are declared, along with untagged struct fields whose own fields have tags
+// The path of nested fields is included in errors, starting with prefix
+func tagFields(refType reflect.Type, prefix string) ([]*validField, error) {
+	var fields []*validField
+
+	for i := range refType.NumField() {
+		field := refType.Field(i)
+		tag, ok := field.Tag.Lookup(tagKey)
+
+		if tag == "-" {
+			continue
+		}
+
+		if !ok {
+			if field.Type.Kind() != reflect.Struct || !field.IsExported() {
+				continue
+			}
+
+			nested, err := tagFields(field.Type, prefix+field.Name+".")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(nested) == 0 {
+				continue
+			}
+
+			fields = append(fields, &validField{
+				name:        field.Name,
+				displayName: field.Name,
+				validator:   &taggedStructValidator{refType: field.Type, fields: nested},
+				index:       field.Index,
+			})
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, fmt.Errorf(`ensure tag on field %s%s: field is not exported`, prefix, field.Name)
+		}
+
+		validator, err := tagValidator(field.Type, tag)
+
+		if err != nil {
+			return nil, fmt.Errorf(`ensure tag on field %s%s: %w`, prefix, field.Name, err)
+		}
+
+		fields = append(fields, &validField{
+			name:        field.Name,
+			displayName: field.Name,
+			validator:   validator,
+			index:       field.Index,
+		})
+	}
+
+	return fields, nil
+}
+
+// FromTags constructs a StructValidator for type T from the "ensure" tags on its fields
+// Like HasFields, it panics if the tags describe an invalid validator
+func FromTags[T any]() *StructValidator[T] {
+	sv, err := TryFromTags[T]()
+
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return sv
+}
+
+// tagValidator builds a validator for a value of the provided type from the contents of an ensure tag
+func tagValidator(refType reflect.Type, tag string) (with.UntypedValidator, error) {
+	rules, err := parseTag(tag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return validatorFromTag(refType, rules)
+}
+
+// TryFromTag constructs a validator for values of type F from the contents of an ensure tag (eg "required,len>=3")
+// The validator is the same one FromTags would use for a field of type F with that tag
+// It returns an error instead of panicking if the tag cannot be parsed
+func TryFromTag[F any](tag string) (with.Validator[F], error) {
+	v, err := tagValidator(reflect.TypeFor[F](), tag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if typed, ok := v.(with.Validator[F]); ok {
+		return typed, nil
+	}
+
+	return &typedTagValidator[F]{v}, nil
+}
+
+// typedTagValidator adds a typed Validate method to the validators built for named types,
+// which can only validate values through ValidateUntyped
+type typedTagValidator[F any] struct {
+	with.UntypedValidator
+}
+
+// Validate applies all checks against a value and returns an error if any fail
+func (v *typedTagValidator[F]) Validate(value F, options ...*with.ValidationOptions) error {
+	return v.ValidateUntyped(value, options...)
+}
+
+// describe returns the description of the validator it wraps
+func (v *typedTagValidator[F]) describe(s describeState) Description {
+	return s.validator(v.UntypedValidator)
+}
+
+// jsonSchema returns the schema of the validator it wraps
+func (v *typedTagValidator[F]) jsonSchema(g *schemaGenerator) schemaObject {
+	return g.schema(v.UntypedValidator)
+}
+
+// FromTag constructs a validator for values of type F from the contents of an ensure tag (eg "required,len>=3")
+// Like FromTags, it panics if the tag describes an invalid validator
+func FromTag[F any](tag string) with.Validator[F] {
+	v, err := TryFromTag[F](tag)
+
+	if err != nil {
+		panic(fmt.Sprintf(`ensure tag "%s": %s`, tag, err))
+	}
+
+	return v
+}
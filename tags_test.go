@@ -0,0 +1,541 @@
+package ensure_test
+
+import (
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tagUser struct {
+	Name     string         `ensure:"required,len>=3,len<=64"`
+	Email    string         `ensure:"required,match=email"`
+	Role     string         `ensure:"oneof=admin|member"`
+	Code     string         `ensure:"prefix=ab,suffix=yz,contains=c,excludes=x,len=6,len!=7,len>5,len<7"`
+	Fixed    string         `ensure:"=fixed,!=other"`
+	Pattern  string         `ensure:"match='^[a-z]{1,3}$'"`
+	Age      int            `ensure:">=18,<130"`
+	Score    float64        `ensure:"positive,<=100.5"`
+	Delta    int8           `ensure:"negative,>-100,!=-50"`
+	Count    uint16         `ensure:"required,even,oneof=2|4|6,=2"`
+	Odd      int64          `ensure:"odd"`
+	Agreed   bool           `ensure:"required"`
+	Banned   bool           `ensure:"false"`
+	Nickname *string        `ensure:"len>2"`
+	Manager  *string        `ensure:"required"`
+	Tags     []string       `ensure:"required,unique,len<=3,each(len>=2)"`
+	Labels   map[string]int `ensure:"required,len<3,keys(prefix=l),values(positive)"`
+	Ignored  string         `ensure:"-"`
+	Untagged string
+	Extra    map[string]string `ensure:"len>=0"`
+}
+
+func validTagUser() tagUser {
+	nickname := "nick"
+	manager := "boss"
+
+	return tagUser{
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Role:     "admin",
+		Code:     "abcdyz",
+		Fixed:    "fixed",
+		Pattern:  "abc",
+		Age:      30,
+		Score:    99.5,
+		Delta:    -10,
+		Count:    2,
+		Odd:      3,
+		Agreed:   true,
+		Banned:   false,
+		Nickname: &nickname,
+		Manager:  &manager,
+		Tags:     []string{"go", "rust"},
+		Labels:   map[string]int{"l1": 1},
+	}
+}
+
+func TestFromTags(t *testing.T) {
+	validUser := ensure.FromTags[tagUser]()
+
+	short := "x"
+
+	testCases := structTestCases[tagUser]{
+		"valid": {validTagUser(), true},
+		"no nickname": {func() tagUser {
+			u := validTagUser()
+			u.Nickname = nil
+			return u
+		}(), true},
+		"short nickname": {func() tagUser {
+			u := validTagUser()
+			u.Nickname = &short
+			return u
+		}(), false},
+		"no manager": {func() tagUser {
+			u := validTagUser()
+			u.Manager = nil
+			return u
+		}(), false},
+		"name too short": {func() tagUser {
+			u := validTagUser()
+			u.Name = "Al"
+			return u
+		}(), false},
+		"bad email": {func() tagUser {
+			u := validTagUser()
+			u.Email = "alice"
+			return u
+		}(), false},
+		"bad role": {func() tagUser {
+			u := validTagUser()
+			u.Role = "owner"
+			return u
+		}(), false},
+		"bad code": {func() tagUser {
+			u := validTagUser()
+			u.Code = "abxdyz"
+			return u
+		}(), false},
+		"bad fixed": {func() tagUser {
+			u := validTagUser()
+			u.Fixed = "other"
+			return u
+		}(), false},
+		"bad pattern": {func() tagUser {
+			u := validTagUser()
+			u.Pattern = "abcd"
+			return u
+		}(), false},
+		"too young": {func() tagUser {
+			u := validTagUser()
+			u.Age = 17
+			return u
+		}(), false},
+		"score too high": {func() tagUser {
+			u := validTagUser()
+			u.Score = 100.6
+			return u
+		}(), false},
+		"delta excluded": {func() tagUser {
+			u := validTagUser()
+			u.Delta = -50
+			return u
+		}(), false},
+		"count zero": {func() tagUser {
+			u := validTagUser()
+			u.Count = 0
+			return u
+		}(), false},
+		"even": {func() tagUser {
+			u := validTagUser()
+			u.Odd = 2
+			return u
+		}(), false},
+		"not agreed": {func() tagUser {
+			u := validTagUser()
+			u.Agreed = false
+			return u
+		}(), false},
+		"banned": {func() tagUser {
+			u := validTagUser()
+			u.Banned = true
+			return u
+		}(), false},
+		"duplicate tags": {func() tagUser {
+			u := validTagUser()
+			u.Tags = []string{"go", "go"}
+			return u
+		}(), false},
+		"short tag": {func() tagUser {
+			u := validTagUser()
+			u.Tags = []string{"g"}
+			return u
+		}(), false},
+		"bad label key": {func() tagUser {
+			u := validTagUser()
+			u.Labels = map[string]int{"x": 1}
+			return u
+		}(), false},
+		"bad label value": {func() tagUser {
+			u := validTagUser()
+			u.Labels = map[string]int{"l1": 0}
+			return u
+		}(), false},
+		"ignored fields": {func() tagUser {
+			u := validTagUser()
+			u.Ignored = ""
+			u.Untagged = ""
+			return u
+		}(), true},
+	}
+
+	testCases.run(t, validUser, "FromTags()")
+}
+
+func TestFromTags_FieldOrder(t *testing.T) {
+	type ordered struct {
+		B string `ensure:"required"`
+		A string `ensure:"required"`
+		C string `ensure:"required"`
+	}
+
+	err := ensure.FromTags[ordered]().Validate(ordered{})
+
+	if err == nil || !strings.HasPrefix(err.Error(), "B:") {
+		t.Errorf(`expected first error to be for field "B"; got "%v"`, err)
+	}
+}
+
+func TestFromTags_Panic(t *testing.T) {
+	type badTag struct {
+		Name string `ensure:"nonsense"`
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	ensure.FromTags[badTag]()
+}
+
+// tryFromTags returns the error produced by TryFromTags for type T
+func tryFromTags[T any]() error {
+	_, err := ensure.TryFromTags[T]()
+	return err
+}
+
+func TestTryFromTags_Errors(t *testing.T) {
+	testCases := map[string]func() error{
+		"not a struct": tryFromTags[int],
+		"unexported field": tryFromTags[struct {
+			name string `ensure:"required"`
+		}],
+		"empty rule": tryFromTags[struct {
+			Name string `ensure:"required,,len>1"`
+		}],
+		"unterminated quote": tryFromTags[struct {
+			Name string `ensure:"match='abc"`
+		}],
+		"unbalanced close": tryFromTags[struct {
+			Tags []string `ensure:"each(len>1))"`
+		}],
+		"unbalanced open": tryFromTags[struct {
+			Tags []string `ensure:"each((len>1)"`
+		}],
+		"unknown string rule": tryFromTags[struct {
+			Name string `ensure:"positive"`
+		}],
+		"bad length": tryFromTags[struct {
+			Name string `ensure:"len>abc"`
+		}],
+		"bad pattern": tryFromTags[struct {
+			Name string `ensure:"match='[a-z'"`
+		}],
+		"unknown number rule": tryFromTags[struct {
+			Age int `ensure:"len>3"`
+		}],
+		"bad int": tryFromTags[struct {
+			Age int `ensure:">abc"`
+		}],
+		"int overflow": tryFromTags[struct {
+			Age int8 `ensure:"<1000"`
+		}],
+		"bad uint": tryFromTags[struct {
+			Age uint `ensure:">-1"`
+		}],
+		"bad float": tryFromTags[struct {
+			Score float32 `ensure:">abc"`
+		}],
+		"bad oneof": tryFromTags[struct {
+			Age int `ensure:"oneof=1|two"`
+		}],
+		"unknown bool rule": tryFromTags[struct {
+			Agreed bool `ensure:"positive"`
+		}],
+		"unsupported type": tryFromTags[struct {
+			Nested struct{ Name string } `ensure:"required"`
+		}],
+		"unsupported pointer": tryFromTags[struct {
+			Nested *struct{ Name string } `ensure:"required"`
+		}],
+		"bad pointer rule": tryFromTags[struct {
+			Name *string `ensure:"positive"`
+		}],
+		"unsupported slice": tryFromTags[struct {
+			Nested []struct{ Name string } `ensure:"required"`
+		}],
+		"bad each": tryFromTags[struct {
+			Tags []string `ensure:"each(positive)"`
+		}],
+		"bad each syntax": tryFromTags[struct {
+			Tags []string `ensure:"each(len>1,,len<3)"`
+		}],
+		"bad slice rule": tryFromTags[struct {
+			Tags []string `ensure:"positive"`
+		}],
+		"bad slice length": tryFromTags[struct {
+			Tags []string `ensure:"len>abc"`
+		}],
+		"non-string map key": tryFromTags[struct {
+			Labels map[int]string `ensure:"required"`
+		}],
+		"named slice element": tryFromTags[struct {
+			Emails []tagEmail `ensure:"required"`
+		}],
+		"bad named type rule": tryFromTags[struct {
+			Email tagEmail `ensure:"positive"`
+		}],
+		"unsupported map value": tryFromTags[struct {
+			Labels map[string]struct{} `ensure:"required"`
+		}],
+		"bad keys syntax": tryFromTags[struct {
+			Labels map[string]int `ensure:"keys(,)"`
+		}],
+		"bad values syntax": tryFromTags[struct {
+			Labels map[string]int `ensure:"values(,)"`
+		}],
+		"bad keys": tryFromTags[struct {
+			Labels map[string]int `ensure:"keys(positive)"`
+		}],
+		"bad values": tryFromTags[struct {
+			Labels map[string]int `ensure:"values(len>1)"`
+		}],
+		"bad map rule": tryFromTags[struct {
+			Labels map[string]int `ensure:"unique"`
+		}],
+		"bad map length": tryFromTags[struct {
+			Labels map[string]int `ensure:"len>abc"`
+		}],
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc(); err == nil {
+				t.Errorf("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestTryFromTags_ErrorMessage(t *testing.T) {
+	err := tryFromTags[struct {
+		Name string `ensure:"each(len>1)"`
+	}]()
+
+	expect := `ensure tag on field Name: rule "each(len>1)" is not supported for type string`
+
+	if err == nil || err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%v"`, expect, err)
+	}
+}
+
+func TestFromTag(t *testing.T) {
+	v := ensure.FromTag[string]("required,len>=3")
+
+	if err := v.Validate("abc"); err != nil {
+		t.Errorf("expected no error; got %s", err)
+	}
+
+	if err := v.Validate("ab"); err == nil {
+		t.Errorf("expected error but got none")
+	}
+
+	defer func() {
+		r := recover()
+		expect := `ensure tag "nonsense": rule "nonsense" is not supported for type string`
+
+		if r != expect {
+			t.Errorf(`expected panic "%s"; got "%v"`, expect, r)
+		}
+	}()
+
+	ensure.FromTag[string]("nonsense")
+}
+
+func TestTryFromTag_Errors(t *testing.T) {
+	testCases := map[string]func() error{
+		"bad syntax": func() error {
+			_, err := ensure.TryFromTag[string]("required,,len>1")
+			return err
+		},
+		"unsupported type": func() error {
+			_, err := ensure.TryFromTag[struct{}]("required")
+			return err
+		},
+	}
+
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := fn(); err == nil {
+				t.Errorf("expected error but got none")
+			}
+		})
+	}
+}
+
+type tagEmail string
+
+type tagScore float64
+
+type tagLabels []string
+
+type tagNamed struct {
+	Email   tagEmail  `ensure:"required,match=email"`
+	Backup  *tagEmail `ensure:"len>3"`
+	Score   tagScore  `ensure:"positive"`
+	Labels  tagLabels `ensure:"required,each(len>=2)"`
+	Primary string    `ensure:"required"`
+}
+
+func TestFromTags_NamedTypes(t *testing.T) {
+	validNamed := ensure.FromTags[tagNamed]()
+	short := tagEmail("a@b")
+
+	testCases := structTestCases[tagNamed]{
+		"valid":        {tagNamed{Email: "ann@example.com", Score: 1, Labels: tagLabels{"go"}, Primary: "x"}, true},
+		"bad email":    {tagNamed{Email: "ann", Score: 1, Labels: tagLabels{"go"}, Primary: "x"}, false},
+		"short backup": {tagNamed{Email: "ann@example.com", Backup: &short, Score: 1, Labels: tagLabels{"go"}, Primary: "x"}, false},
+		"bad score":    {tagNamed{Email: "ann@example.com", Score: -1, Labels: tagLabels{"go"}, Primary: "x"}, false},
+		"bad labels":   {tagNamed{Email: "ann@example.com", Score: 1, Labels: tagLabels{"g"}, Primary: "x"}, false},
+	}
+
+	testCases.run(t, validNamed, "FromTags")
+
+	// errors match the ones for the underlying type
+	expected := ensure.FromTag[string]("required,match=email").Validate("ann")
+	actual := ensure.FromTag[tagEmail]("required,match=email").Validate("ann")
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %#v; got %#v", expected, actual)
+	}
+
+	if err := ensure.FromTag[tagEmail]("required").ValidateUntyped("ann"); err == nil {
+		t.Errorf("expected a type error for a value of the underlying type but got none")
+	}
+}
+
+type tagAddress struct {
+	Street string `ensure:"required"`
+	City   string `ensure:"required,len>=2"`
+	Note   string
+}
+
+type tagOrder struct {
+	ID       string `ensure:"required"`
+	Address  tagAddress
+	Created  time.Time
+	Untagged struct{ Name string }
+	Skipped  tagAddress `ensure:"-"`
+}
+
+func TestFromTags_NestedStructs(t *testing.T) {
+	validOrder := ensure.FromTags[tagOrder]()
+	byHand := ensure.Struct[tagOrder]().HasFields(with.Validators{
+		"ID":      ensure.FromTag[string]("required"),
+		"Address": ensure.FromTags[tagAddress](),
+	})
+
+	testCases := map[string]struct {
+		value   tagOrder
+		options []*with.ValidationOptions
+	}{
+		"valid":       {value: tagOrder{ID: "1", Address: tagAddress{Street: "Main", City: "Springfield"}}},
+		"invalid":     {value: tagOrder{ID: "1", Address: tagAddress{City: "S"}}},
+		"collect all": {value: tagOrder{}, options: []*with.ValidationOptions{with.Options(with.OptionCollectAllErrors())}},
+		"field mask":  {value: tagOrder{}, options: []*with.ValidationOptions{with.Options(with.OptionFieldMask("Address.City"))}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected := byHand.Validate(tc.value, tc.options...)
+			actual := validOrder.Validate(tc.value, tc.options...)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %#v; got %#v", expected, actual)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(byHand.Describe(), validOrder.Describe()) {
+		t.Errorf("expected %+v; got %+v", byHand.Describe(), validOrder.Describe())
+	}
+
+	expected, _ := ensure.JSONSchema(byHand)
+	actual, _ := ensure.JSONSchema(validOrder)
+
+	if string(expected) != string(actual) {
+		t.Errorf("expected %s; got %s", expected, actual)
+	}
+
+	components, _ := ensure.NewOpenAPIComponents().Add("Order", validOrder).JSON()
+	expectComponents, _ := ensure.NewOpenAPIComponents().Add("Order", byHand).JSON()
+
+	if string(expectComponents) != string(components) {
+		t.Errorf("expected %s; got %s", expectComponents, components)
+	}
+
+	if err := ensure.TaggedStruct[tagAddress]().ValidateUntyped(tagOrder{}); err == nil {
+		t.Errorf("expected a type error for a value of another type but got none")
+	}
+}
+
+func TestTryFromTags_NestedErrorMessage(t *testing.T) {
+	err := tryFromTags[struct {
+		Address struct {
+			City string `ensure:"positive"`
+		}
+	}]()
+
+	expect := `ensure tag on field Address.City: rule "positive" is not supported for type string`
+
+	if err == nil || err.Error() != expect {
+		t.Errorf(`expected "%s"; got "%v"`, expect, err)
+	}
+}
+
+type tagNamedRequired struct {
+	Email  tagEmail  `ensure:"required"`
+	Backup *tagEmail `ensure:"required"`
+}
+
+func TestFromTags_NamedTypes_Describe(t *testing.T) {
+	children := ensure.FromTags[tagNamedRequired]().Describe().Children
+	d := children[0].Validator
+
+	if d.Kind != ensure.KindString || d.Type != "ensure_test.tagEmail" || len(d.Checks) != 1 {
+		t.Errorf("expected a string description with the named type; got %+v", d)
+	}
+
+	expectSchema(t, ensure.FromTags[tagNamedRequired](), `{
+		"type": "object",
+		"properties": {
+			"Email": {"type": "string", "minLength": 1},
+			"Backup": {"type": "string"}
+		},
+		"required": ["Backup"]
+	}`)
+
+	expected, _ := ensure.JSONSchema(ensure.FromTag[string]("required"))
+	actual, _ := ensure.JSONSchema(ensure.FromTag[tagEmail]("required"))
+
+	if string(expected) != string(actual) {
+		t.Errorf("expected %s; got %s", expected, actual)
+	}
+
+	byHand := ensure.Struct[tagNamedRequired]().HasFields(with.Validators{
+		"Email": ensure.FromTag[tagEmail]("required"),
+	})
+
+	if !reflect.DeepEqual(byHand.Describe().Children[0], children[0]) {
+		t.Errorf("expected %+v; got %+v", children[0], byHand.Describe().Children[0])
+	}
+
+	if d := ensure.FromTags[struct{ E tagEmail }]().Describe(); len(d.Children) != 0 {
+		t.Errorf("expected untagged fields to be skipped; got %+v", d.Children)
+	}
+}
@@ -0,0 +1,120 @@
+package ensure
+
+import (
+	"fmt"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+)
+
+// namedTypeValidator validates values of a named type, such as `type Email string`, by
+// converting them to their underlying type and validating them with a validator for that type
+type namedTypeValidator struct {
+	refType  reflect.Type
+	baseType reflect.Type
+	base     with.UntypedValidator
+}
+
+// Type returns the name of the named type
+func (v *namedTypeValidator) Type() string {
+	return v.refType.String()
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a match for the expected type
+func (v *namedTypeValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	ref := reflect.ValueOf(value)
+
+	if !ref.IsValid() || ref.Type() != v.refType {
+		return newTypeErrorFromTypes(v.refType.String(), fmt.Sprintf("%T", value))
+	}
+
+	return v.base.ValidateUntyped(ref.Convert(v.baseType).Interface(), options...)
+}
+
+// describe returns the description of the validator for the underlying type, under the named type
+func (v *namedTypeValidator) describe(s describeState) Description {
+	d := s.validator(v.base)
+	d.Type = v.Type()
+	return d
+}
+
+// jsonSchema returns the schema of the validator for the underlying type, which encodes the same way
+func (v *namedTypeValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	return g.schema(v.base)
+}
+
+// schemaRequired returns true if the validator for the underlying type is required in a schema
+func (v *namedTypeValidator) schemaRequired() bool {
+	requirer, ok := v.base.(schemaRequirer)
+	return ok && requirer.schemaRequired()
+}
+
+// taggedStructValidator validates a nested struct from the ensure tags on its fields
+// FromTags only knows the types of nested structs through reflection, so it can't use a StructValidator
+type taggedStructValidator struct {
+	refType reflect.Type
+	fields  []*validField
+}
+
+// Type returns the name of the struct type
+func (v *taggedStructValidator) Type() string {
+	return v.refType.String()
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a match for the expected type
+// Errors are the same as the ones a StructValidator with the same fields returns
+func (v *taggedStructValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	ref := reflect.ValueOf(value)
+
+	if !ref.IsValid() || ref.Type() != v.refType {
+		return newTypeErrorFromTypes(v.refType.String(), fmt.Sprintf("%T", value))
+	}
+
+	run := NewStructRun(options...)
+
+	for _, field := range v.fields {
+		fieldOpts, ok := run.Field(field.name)
+
+		if !ok {
+			continue
+		}
+
+		err := field.validator.ValidateUntyped(ref.FieldByIndex(field.index).Interface(), fieldOpts)
+
+		if run.Fail(err, field.name, field.displayName) {
+			return run.Err()
+		}
+	}
+
+	return run.Err()
+}
+
+// describe returns a struct description with a child for each tagged field
+func (v *taggedStructValidator) describe(s describeState) Description {
+	return s.node(v, KindStruct, v.Type(), func(d *Description) {
+		for _, field := range v.fields {
+			d.Children = append(d.Children, ChildDescription{
+				Relation:    RelationField,
+				Name:        field.name,
+				DisplayName: field.displayName,
+				Validator:   s.validator(field.validator),
+			})
+		}
+	})
+}
+
+// jsonSchema returns an object schema for the tagged fields, defined once and referenced like other structs
+func (v *taggedStructValidator) jsonSchema(g *schemaGenerator) schemaObject {
+	build := func() schemaObject {
+		s := schemaObject{"type": "object"}
+		addFieldSchemas(g, s, v.refType, v.fields, func(string) (*structDefault, bool) {
+			return nil, false
+		})
+		return s
+	}
+
+	if g.refStructs {
+		return g.define(v, v.refType.Name(), build)
+	}
+
+	return g.ref(v, v.refType.Name(), build)
+}
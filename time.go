@@ -0,0 +1,210 @@
+package ensure
+
+import (
+	"context"
+	"github.com/chriscasto/go-ensure/with"
+	"reflect"
+	"time"
+)
+
+// timeFormat is the layout used when printing times in error messages
+const timeFormat = time.RFC3339
+
+// TimeValidator contains information and logic used to validate a time.Time value
+type TimeValidator struct {
+	checks *valChecks[time.Time]
+}
+
+// Time returns an initialized TimeValidator
+func Time() *TimeValidator {
+	return &TimeValidator{
+		checks: newValChecks[time.Time](),
+	}
+}
+
+// Type returns the string "time.Time"
+func (v *TimeValidator) Type() string {
+	return "time.Time"
+}
+
+// IsBefore adds a check that returns an error if the time being validated is not before the time provided
+func (v *TimeValidator) IsBefore(target time.Time) *TimeValidator {
+	return v.addRule(rule{name: "IsBefore", params: map[string]any{"expected": target}}, func(t time.Time) error {
+		if !t.Before(target) {
+			return newCheckError(CodeTimeBefore, map[string]any{"expected": target, "actual": t})
+		}
+		return nil
+	})
+}
+
+// IsAfter adds a check that returns an error if the time being validated is not after the time provided
+func (v *TimeValidator) IsAfter(target time.Time) *TimeValidator {
+	return v.addRule(rule{name: "IsAfter", params: map[string]any{"expected": target}}, func(t time.Time) error {
+		if !t.After(target) {
+			return newCheckError(CodeTimeAfter, map[string]any{"expected": target, "actual": t})
+		}
+		return nil
+	})
+}
+
+// IsBetween adds a check that returns an error if the time being validated is not between the two times provided
+// Range is inclusive of the start and exclusive of the end, matching NumberValidator.IsInRange
+func (v *TimeValidator) IsBetween(start time.Time, end time.Time) *TimeValidator {
+	if end.Before(start) {
+		panic("end cannot be before start")
+	}
+
+	return v.addRule(rule{name: "IsBetween", params: map[string]any{"min": start, "max": end}}, func(t time.Time) error {
+		if t.Before(start) || !t.Before(end) {
+			return newCheckError(CodeTimeRange, map[string]any{"min": start, "max": end, "actual": t})
+		}
+		return nil
+	})
+}
+
+// IsInPast adds a check that returns an error if the time being validated is not before the current time
+// The current time is determined by the clock set in the validation options
+func (v *TimeValidator) IsInPast() *TimeValidator {
+	v.checks.Append(func(t time.Time, opts *with.ValidationOptions) error {
+		if !t.Before(opts.Now()) {
+			return newCheckError(CodeTimePast, map[string]any{"actual": t})
+		}
+		return nil
+	})
+	v.checks.Record(rule{name: "IsInPast"})
+	return v
+}
+
+// IsInFuture adds a check that returns an error if the time being validated is not after the current time
+// The current time is determined by the clock set in the validation options
+func (v *TimeValidator) IsInFuture() *TimeValidator {
+	v.checks.Append(func(t time.Time, opts *with.ValidationOptions) error {
+		if !t.After(opts.Now()) {
+			return newCheckError(CodeTimeFuture, map[string]any{"actual": t})
+		}
+		return nil
+	})
+	v.checks.Record(rule{name: "IsInFuture"})
+	return v
+}
+
+// IsWithin adds a check that returns an error if the time being validated is further than the provided
+// duration from the current time in either direction
+// The current time is determined by the clock set in the validation options
+func (v *TimeValidator) IsWithin(d time.Duration) *TimeValidator {
+	if d < 0 {
+		panic("duration cannot be negative")
+	}
+
+	v.checks.Append(func(t time.Time, opts *with.ValidationOptions) error {
+		diff := t.Sub(opts.Now())
+
+		if diff < -d || diff > d {
+			return newCheckError(CodeTimeWithin, map[string]any{"expected": d, "actual": t})
+		}
+		return nil
+	})
+	v.checks.Record(rule{name: "IsWithin", params: map[string]any{"expected": d}})
+	return v
+}
+
+// IsWeekday adds a check that returns an error if the time being validated does not fall on Monday through Friday
+// The day is evaluated in the time's own location
+func (v *TimeValidator) IsWeekday() *TimeValidator {
+	return v.addRule(rule{name: "IsWeekday"}, func(t time.Time) error {
+		day := t.Weekday()
+
+		if day == time.Saturday || day == time.Sunday {
+			return newCheckError(CodeTimeWeekday, map[string]any{"actual": day.String()})
+		}
+		return nil
+	})
+}
+
+// IsInLocation adds a check that returns an error if the time being validated is not in the provided location
+func (v *TimeValidator) IsInLocation(loc *time.Location) *TimeValidator {
+	if loc == nil {
+		panic("location cannot be nil")
+	}
+
+	return v.addRule(rule{name: "IsInLocation", params: map[string]any{"expected": loc.String()}}, func(t time.Time) error {
+		if t.Location().String() != loc.String() {
+			return newCheckError(CodeTimeLocation, map[string]any{"expected": loc.String(), "actual": t.Location().String()})
+		}
+		return nil
+	})
+}
+
+// ValidateUntyped accepts an arbitrary input type and validates it if it's a match for the expected type
+func (v *TimeValidator) ValidateUntyped(value any, options ...*with.ValidationOptions) error {
+	t, ok := value.(time.Time)
+
+	if !ok {
+		return NewTypeError("time.Time expected")
+	}
+
+	return v.Validate(t, options...)
+}
+
+// Validate applies all checks against a time value and returns an error if any fail
+func (v *TimeValidator) Validate(t time.Time, options ...*with.ValidationOptions) error {
+	return v.validate(t, getValidationOptions(options))
+}
+
+// validate applies all checks against a time value with the provided options
+func (v *TimeValidator) validate(t time.Time, opts *with.ValidationOptions) error {
+	return v.checks.Evaluate(t, opts)
+}
+
+// validateRef validates the time that a struct field or getter refers to
+func (v *TimeValidator) validateRef(ref any, opts *with.ValidationOptions) error {
+	return v.validate(deref[time.Time](ref), opts)
+}
+
+// valueType returns the type of value the validator accepts
+func (v *TimeValidator) valueType() reflect.Type {
+	return reflect.TypeFor[time.Time]()
+}
+
+// ValidateContext is like Validate, but passes ctx to checks added with IsCtx()
+// and stops early with a CanceledError once ctx is done
+func (v *TimeValidator) ValidateContext(ctx context.Context, t time.Time, options ...*with.ValidationOptions) error {
+	return v.Validate(t, contextOptions(ctx, options))
+}
+
+// WithMessage overrides the message returned by the most recently added rule
+// Placeholders such as {expected} are replaced with the rule's params, and {actual} with the value that failed
+func (v *TimeValidator) WithMessage(tmpl string) *TimeValidator {
+	v.checks.SetMessage(tmpl)
+	return v
+}
+
+// addRule adds a check along with the rule that describes it
+func (v *TimeValidator) addRule(r rule, fn func(time.Time) error) *TimeValidator {
+	v.checks.Append(func(val time.Time, _ *with.ValidationOptions) error {
+		return fn(val)
+	})
+	v.checks.Record(r)
+	return v
+}
+
+// Is adds the provided function as a check against any values to be validated
+func (v *TimeValidator) Is(fn func(time.Time) error) *TimeValidator {
+	return v.addRule(rule{name: "Is"}, fn)
+}
+
+// IsCtx adds the provided function as a check against any values to be validated
+// The function receives the context passed to ValidateContext() and should return once it is done
+func (v *TimeValidator) IsCtx(fn func(context.Context, time.Time) error) *TimeValidator {
+	v.checks.Append(func(val time.Time, opts *with.ValidationOptions) error {
+		return fn(opts.Context(), val)
+	})
+	v.checks.Record(rule{name: "IsCtx"})
+	return v
+}
+
+// Has adds the provided function as a check against any values to be validated
+// Has is an alias for Is
+func (v *TimeValidator) Has(fn func(time.Time) error) *TimeValidator {
+	return v.Is(fn)
+}
@@ -0,0 +1,225 @@
+package ensure_test
+
+import (
+	"errors"
+	"github.com/chriscasto/go-ensure"
+	"github.com/chriscasto/go-ensure/with"
+	"testing"
+	"time"
+)
+
+// frozenNow is the fixed point in time used by relative time checks in tests
+// It falls on a Wednesday
+var frozenNow = time.Date(2024, time.March, 6, 12, 0, 0, 0, time.UTC)
+
+// frozenOpts returns validation options with the clock frozen at frozenNow
+func frozenOpts() *with.ValidationOptions {
+	return with.Options(
+		with.OptionClock(func() time.Time {
+			return frozenNow
+		}),
+	)
+}
+
+type timeTestCases map[string]struct {
+	value    time.Time
+	willPass bool
+}
+
+func (tcs timeTestCases) run(t *testing.T, tv *ensure.TimeValidator, method string) {
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := tv.Validate(tc.value, frozenOpts())
+			if err != nil && tc.willPass {
+				t.Errorf(`Time().%s.Validate(%v); expected no error, got "%s"`, method, tc.value, err)
+			} else if err == nil && !tc.willPass {
+				t.Errorf(`Time().%s.Validate(%v); expected error but got none`, method, tc.value)
+			}
+		})
+	}
+}
+
+// TestTimeValidator_IsValidator checks to make sure the TimeValidator implements the Validator interfaces
+func TestTimeValidator_IsValidator(t *testing.T) {
+	var _ with.UntypedValidator = ensure.Time()
+	var _ with.Validator[time.Time] = ensure.Time()
+}
+
+func TestTimeValidator_Type(t *testing.T) {
+	tv := ensure.Time()
+
+	if tv.Type() != "time.Time" {
+		t.Errorf(`unexpected type: expected "%s", got "%s"`, "time.Time", tv.Type())
+	}
+}
+
+func TestTimeValidator_IsBefore(t *testing.T) {
+	testCases := timeTestCases{
+		"before":   {frozenNow.Add(-time.Second), true},
+		"equal to": {frozenNow, false},
+		"after":    {frozenNow.Add(time.Second), false},
+	}
+
+	testCases.run(t, ensure.Time().IsBefore(frozenNow), "IsBefore()")
+}
+
+func TestTimeValidator_IsAfter(t *testing.T) {
+	testCases := timeTestCases{
+		"before":   {frozenNow.Add(-time.Second), false},
+		"equal to": {frozenNow, false},
+		"after":    {frozenNow.Add(time.Second), true},
+	}
+
+	testCases.run(t, ensure.Time().IsAfter(frozenNow), "IsAfter()")
+}
+
+func TestTimeValidator_IsBetween(t *testing.T) {
+	start := frozenNow
+	end := frozenNow.Add(time.Hour)
+
+	t.Run("panic if end < start", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		bad := ensure.Time().IsBetween(end, start)
+		if err := bad.Validate(start); err != nil {
+			t.Errorf("validation occured and generated an error: %s", err.Error())
+		}
+	})
+
+	testCases := timeTestCases{
+		"before start":    {start.Add(-time.Second), false},
+		"start of range":  {start, true},
+		"middle of range": {start.Add(time.Minute), true},
+		"end of range":    {end, false},
+		"after end":       {end.Add(time.Second), false},
+	}
+
+	testCases.run(t, ensure.Time().IsBetween(start, end), "IsBetween()")
+}
+
+func TestTimeValidator_IsInPast(t *testing.T) {
+	testCases := timeTestCases{
+		"yesterday": {frozenNow.AddDate(0, 0, -1), true},
+		"now":       {frozenNow, false},
+		"tomorrow":  {frozenNow.AddDate(0, 0, 1), false},
+	}
+
+	testCases.run(t, ensure.Time().IsInPast(), "IsInPast()")
+}
+
+func TestTimeValidator_IsInFuture(t *testing.T) {
+	testCases := timeTestCases{
+		"yesterday": {frozenNow.AddDate(0, 0, -1), false},
+		"now":       {frozenNow, false},
+		"tomorrow":  {frozenNow.AddDate(0, 0, 1), true},
+	}
+
+	testCases.run(t, ensure.Time().IsInFuture(), "IsInFuture()")
+}
+
+func TestTimeValidator_IsWithin(t *testing.T) {
+	t.Run("panic if duration is negative", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		bad := ensure.Time().IsWithin(-time.Hour)
+		if err := bad.Validate(frozenNow); err != nil {
+			t.Errorf("validation occured and generated an error: %s", err.Error())
+		}
+	})
+
+	testCases := timeTestCases{
+		"too far in past":   {frozenNow.Add(-2 * time.Hour), false},
+		"edge of past":      {frozenNow.Add(-time.Hour), true},
+		"now":               {frozenNow, true},
+		"edge of future":    {frozenNow.Add(time.Hour), true},
+		"too far in future": {frozenNow.Add(2 * time.Hour), false},
+	}
+
+	testCases.run(t, ensure.Time().IsWithin(time.Hour), "IsWithin()")
+}
+
+func TestTimeValidator_IsWeekday(t *testing.T) {
+	testCases := timeTestCases{
+		"wednesday": {frozenNow, true},
+		"friday":    {frozenNow.AddDate(0, 0, 2), true},
+		"saturday":  {frozenNow.AddDate(0, 0, 3), false},
+		"sunday":    {frozenNow.AddDate(0, 0, 4), false},
+		"monday":    {frozenNow.AddDate(0, 0, 5), true},
+	}
+
+	testCases.run(t, ensure.Time().IsWeekday(), "IsWeekday()")
+}
+
+func TestTimeValidator_IsInLocation(t *testing.T) {
+	t.Run("panic if location is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("The code did not panic")
+			}
+		}()
+
+		bad := ensure.Time().IsInLocation(nil)
+		if err := bad.Validate(frozenNow); err != nil {
+			t.Errorf("validation occured and generated an error: %s", err.Error())
+		}
+	})
+
+	fixed := time.FixedZone("Test/Zone", 3600)
+
+	testCases := timeTestCases{
+		"utc":       {frozenNow, true},
+		"local":     {frozenNow.In(time.Local), time.Local.String() == time.UTC.String()},
+		"fixed":     {frozenNow.In(fixed), false},
+		"utc again": {frozenNow.In(fixed).UTC(), true},
+	}
+
+	testCases.run(t, ensure.Time().IsInLocation(time.UTC), "IsInLocation()")
+}
+
+func TestTimeValidator_Has(t *testing.T) {
+	onTheHour := func(t time.Time) error {
+		if t.Minute() != 0 || t.Second() != 0 {
+			return errors.New("time must be on the hour")
+		}
+		return nil
+	}
+
+	testCases := timeTestCases{
+		"on the hour":  {frozenNow, true},
+		"half past":    {frozenNow.Add(30 * time.Minute), false},
+		"next hour":    {frozenNow.Add(time.Hour), true},
+		"with seconds": {frozenNow.Add(time.Second), false},
+	}
+
+	testCases.run(t, ensure.Time().Has(onTheHour), "Has()")
+}
+
+func TestTimeValidator_MultiError(t *testing.T) {
+	timeTestCases := multiErrTestCases[time.Time]{
+		"last saturday": {frozenNow.AddDate(0, 0, -4), 2}, // fails weekday, after
+		"last monday":   {frozenNow.AddDate(0, 0, -2), 1}, // fails after
+		"next saturday": {frozenNow.AddDate(0, 0, 3), 1},  // fails weekday
+		"next monday":   {frozenNow.AddDate(0, 0, 5), 0},  // fails none
+	}
+
+	timeTestCases.run(t,
+		ensure.Time().IsWeekday().IsAfter(frozenNow),
+	)
+}
+
+func TestTimeValidator_Validate(t *testing.T) {
+	// see util_test.go
+	runDefaultValidatorTestCases(t, ensure.Time())
+
+	if err := ensure.Time().ValidateUntyped(frozenNow); err != nil {
+		t.Errorf(`expected no error, got "%s"`, err)
+	}
+}
@@ -31,11 +31,21 @@ func testType(value any, expect string) *TypeError {
 	return nil
 }
 
+// withOption returns a copy of opts with another option applied
+func withOption(opts *with.ValidationOptions, opt with.ValidationOption) *with.ValidationOptions {
+	copied := *opts
+	opt(&copied)
+	return &copied
+}
+
+// defaultOptions are used when no options are passed to a validator
+// Options are never modified once created (see withOption), so they can be shared
+var defaultOptions = with.Options()
+
 func getValidationOptions(options []*with.ValidationOptions) *with.ValidationOptions {
 	if len(options) > 0 {
 		return options[0]
 	}
 
-	// default options
-	return with.Options()
+	return defaultOptions
 }
@@ -1,8 +1,22 @@
 package with
 
+import (
+	"context"
+	"time"
+)
+
+// DefaultLocale is the locale used for messages when no locale has been set
+const DefaultLocale = "en"
+
 // ValidationOptions is a struct containing all settings for performing validation
 type ValidationOptions struct {
 	collectAllErrors bool
+	clock            func() time.Time
+	locale           string
+	ctx              context.Context
+	groups           []string
+	fieldMask        []string
+	masked           bool
 }
 
 // CollectAllErrors returns true if all checks need to be evaluated and all errors returned collected
@@ -11,6 +25,47 @@ func (vo *ValidationOptions) CollectAllErrors() bool {
 	return vo.collectAllErrors
 }
 
+// Now returns the current time according to the configured clock
+// If no clock has been set, the system clock (time.Now) is used
+func (vo *ValidationOptions) Now() time.Time {
+	if vo.clock == nil {
+		return time.Now()
+	}
+	return vo.clock()
+}
+
+// Locale returns the locale used to render error messages (eg "en" or "pt-BR")
+// If no locale has been set, DefaultLocale is returned
+func (vo *ValidationOptions) Locale() string {
+	if vo.locale == "" {
+		return DefaultLocale
+	}
+	return vo.locale
+}
+
+// Context returns the context that checks added with IsCtx() receive
+// Validation stops early once the context is done
+// If no context has been set, context.Background() is returned
+func (vo *ValidationOptions) Context() context.Context {
+	if vo.ctx == nil {
+		return context.Background()
+	}
+	return vo.ctx
+}
+
+// Groups returns the validation groups selected for this validation
+// Struct fields and getters tagged with InGroups() are only validated if one
+// of their groups is selected; untagged fields and getters are always validated
+func (vo *ValidationOptions) Groups() []string {
+	return vo.groups
+}
+
+// FieldMask returns the field paths selected with OptionFieldMask(), and whether a mask has been set
+// When a mask is set, only the struct fields in the mask are validated
+func (vo *ValidationOptions) FieldMask() ([]string, bool) {
+	return vo.fieldMask, vo.masked
+}
+
 // ValidationOption is a function signature for an option that can be applied to validation settings
 type ValidationOption func(*ValidationOptions)
 
@@ -21,6 +76,56 @@ func OptionCollectAllErrors() ValidationOption {
 	}
 }
 
+// OptionClock sets the function used to determine the current time for relative checks
+// This is primarily useful for freezing "now" in tests
+func OptionClock(clock func() time.Time) ValidationOption {
+	return func(o *ValidationOptions) {
+		o.clock = clock
+	}
+}
+
+// OptionLocale sets the locale used to render error messages
+// Messages are rendered with English if no catalog has been registered for the locale
+func OptionLocale(locale string) ValidationOption {
+	return func(o *ValidationOptions) {
+		o.locale = locale
+	}
+}
+
+// OptionContext sets the context passed to checks added with IsCtx()
+// ValidateContext() is usually a more convenient way to set this
+func OptionContext(ctx context.Context) ValidationOption {
+	return func(o *ValidationOptions) {
+		o.ctx = ctx
+	}
+}
+
+// OptionGroups selects the validation groups (eg "create" or "update") to validate
+func OptionGroups(groups ...string) ValidationOption {
+	return func(o *ValidationOptions) {
+		// Copies of the options can share the slice, so always append to a new one
+		o.groups = append(o.groups[:len(o.groups):len(o.groups)], groups...)
+	}
+}
+
+// OptionFieldMask limits struct validation to the listed field paths, such as the
+// fields present in a PATCH request
+// Paths are field (or getter) names, with nested fields separated by dots (eg "Address.City")
+func OptionFieldMask(paths ...string) ValidationOption {
+	return func(o *ValidationOptions) {
+		o.fieldMask = paths
+		o.masked = true
+	}
+}
+
+// OptionClearFieldMask removes any field mask set by an earlier option, so every field is validated
+func OptionClearFieldMask() ValidationOption {
+	return func(o *ValidationOptions) {
+		o.fieldMask = nil
+		o.masked = false
+	}
+}
+
 // DefaultValidationOptions returns ValidationOptions with the default values set
 func DefaultValidationOptions() *ValidationOptions {
 	return &ValidationOptions{
@@ -1,8 +1,11 @@
 package with_test
 
 import (
+	"context"
 	"github.com/chriscasto/go-ensure/with"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestValidationOptions_CollectAllErrors(t *testing.T) {
@@ -20,3 +23,137 @@ func TestValidationOptions_CollectAllErrors(t *testing.T) {
 		t.Errorf("expected OptionCollectAllErrors to result in collecting all errors")
 	}
 }
+
+func TestValidationOptions_Now(t *testing.T) {
+	defOpts := with.ValidationOptions{}
+
+	before := time.Now()
+	now := defOpts.Now()
+
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("expected default options to use the system clock, got %s", now)
+	}
+
+	frozen := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	opts := with.Options(
+		with.OptionClock(func() time.Time {
+			return frozen
+		}),
+	)
+
+	if !opts.Now().Equal(frozen) {
+		t.Errorf("expected OptionClock to freeze time at %s, got %s", frozen, opts.Now())
+	}
+}
+
+func TestValidationOptions_Locale(t *testing.T) {
+	defOpts := with.ValidationOptions{}
+
+	if defOpts.Locale() != with.DefaultLocale {
+		t.Errorf(`expected default locale "%s", got "%s"`, with.DefaultLocale, defOpts.Locale())
+	}
+
+	opts := with.Options(
+		with.OptionLocale("pt-BR"),
+	)
+
+	if opts.Locale() != "pt-BR" {
+		t.Errorf(`expected OptionLocale to set locale "pt-BR", got "%s"`, opts.Locale())
+	}
+}
+
+func TestValidationOptions_Context(t *testing.T) {
+	defOpts := with.ValidationOptions{}
+
+	if defOpts.Context() != context.Background() {
+		t.Errorf("expected default options to use the background context")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := with.Options(
+		with.OptionContext(ctx),
+	)
+
+	if opts.Context() != ctx {
+		t.Errorf("expected OptionContext to set the context")
+	}
+}
+
+func TestValidationOptions_Groups(t *testing.T) {
+	defOpts := with.ValidationOptions{}
+
+	if len(defOpts.Groups()) != 0 {
+		t.Errorf("expected no groups to be selected by default, got %v", defOpts.Groups())
+	}
+
+	opts := with.Options(
+		with.OptionGroups("create"),
+		with.OptionGroups("admin", "audit"),
+	)
+
+	if expect := []string{"create", "admin", "audit"}; !slices.Equal(opts.Groups(), expect) {
+		t.Errorf("expected OptionGroups to select %v, got %v", expect, opts.Groups())
+	}
+
+	// copies of options that share a groups slice don't see each other's groups, even
+	// when the slice has spare capacity
+	opts = with.Options(
+		with.OptionGroups("create"),
+		with.OptionGroups("admin"),
+		with.OptionGroups("audit"),
+	)
+
+	first := *opts
+	second := *opts
+
+	with.OptionGroups("first")(&first)
+	with.OptionGroups("second")(&second)
+
+	if expect := []string{"create", "admin", "audit", "first"}; !slices.Equal(first.Groups(), expect) {
+		t.Errorf("expected the first copy to select %v, got %v", expect, first.Groups())
+	}
+
+	if expect := []string{"create", "admin", "audit", "second"}; !slices.Equal(second.Groups(), expect) {
+		t.Errorf("expected the second copy to select %v, got %v", expect, second.Groups())
+	}
+
+	if expect := []string{"create", "admin", "audit"}; !slices.Equal(opts.Groups(), expect) {
+		t.Errorf("expected the original options to still select %v, got %v", expect, opts.Groups())
+	}
+}
+
+func TestValidationOptions_FieldMask(t *testing.T) {
+	defOpts := with.ValidationOptions{}
+
+	if _, masked := defOpts.FieldMask(); masked {
+		t.Errorf("expected default options to have no field mask")
+	}
+
+	opts := with.Options(
+		with.OptionFieldMask("Name", "Address.City"),
+	)
+
+	paths, masked := opts.FieldMask()
+
+	if expect := []string{"Name", "Address.City"}; !masked || !slices.Equal(paths, expect) {
+		t.Errorf("expected OptionFieldMask to set mask %v, got %v", expect, paths)
+	}
+
+	// an empty mask is still a mask
+	opts = with.Options(
+		with.OptionFieldMask(),
+	)
+
+	if _, masked := opts.FieldMask(); !masked {
+		t.Errorf("expected an empty field mask to be set")
+	}
+
+	with.OptionClearFieldMask()(opts)
+
+	if _, masked := opts.FieldMask(); masked {
+		t.Errorf("expected OptionClearFieldMask to remove the field mask")
+	}
+}